@@ -34,3 +34,10 @@ func GetConfig() *Config {
 func MustRegisterSubSection(subSectionKey string, section config.Config) config.Section {
 	return rootSection.MustRegisterSection(subSectionKey, section)
 }
+
+// MustRegisterSubSectionWithUpdates registers a config subsection whose updates, including the initial load, are
+// propagated to updatesFn.
+func MustRegisterSubSectionWithUpdates(subSectionKey string, section config.Config,
+	updatesFn config.SectionUpdated) config.Section {
+	return rootSection.MustRegisterSectionWithUpdates(subSectionKey, section, updatesFn)
+}
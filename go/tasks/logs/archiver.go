@@ -0,0 +1,298 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+)
+
+const (
+	archivedLogsName = "Archived Logs"
+	truncationMarker = "\n[truncated: %d bytes omitted]\n"
+
+	// Number of containers whose archived logs are remembered.
+	archivedContainersCacheSize = 10000
+)
+
+// LogArchiver copies the logs of containers, which are gone once their pod is deleted, to the data store.
+type LogArchiver struct {
+	cfg       *config.LogArchiveConfig
+	clientset kubernetes.Interface
+}
+
+// ArchiveContainerLogs writes the logs of the container, compressed, under the raw output prefix and returns a link to
+// them. The logs are only read the first time, once the container has finished.
+func (a LogArchiver) ArchiveContainerLogs(ctx context.Context, dataStore *storage.DataStore,
+	rawOutputPrefix storage.DataReference, pod *v1.Pod, containerName, nameSuffix string) (*core.TaskLog, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.Timeout.Duration)
+	defer cancel()
+
+	reference, taskLog, err := archivedLogsLocation(ctx, dataStore, rawOutputPrefix, pod, containerName, nameSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := dataStore.Head(ctx, reference)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check for archived logs at [%v]", reference)
+	}
+
+	if metadata.Exists() {
+		return taskLog, nil
+	}
+
+	logs, err := a.readLogs(ctx, pod, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := compress(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dataStore.WriteRaw(ctx, reference, int64(len(compressed)), storage.Options{}, bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to write the logs to [%v]", reference)
+	}
+
+	return taskLog, nil
+}
+
+// archivedLogsLocation returns where the logs of the container are archived and the link to them.
+func archivedLogsLocation(ctx context.Context, dataStore *storage.DataStore, rawOutputPrefix storage.DataReference,
+	pod *v1.Pod, containerName, nameSuffix string) (storage.DataReference, *core.TaskLog, error) {
+
+	reference, err := dataStore.ConstructReference(ctx, rawOutputPrefix, "logs",
+		fmt.Sprintf("%s_%s.log.gz", pod.Name, containerName))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return reference, &core.TaskLog{
+		Uri:           reference.String(),
+		Name:          archivedLogsName + nameSuffix,
+		MessageFormat: core.TaskLog_UNKNOWN,
+	}, nil
+}
+
+// readLogs reads the logs of the container, up to the configured size. Longer logs keep their beginning and their end,
+// which usually holds the reason of a failure, and are marked as truncated.
+func (a LogArchiver) readLogs(ctx context.Context, pod *v1.Pod, containerName string) ([]byte, error) {
+	stream, err := a.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: containerName,
+	}).Stream(ctx)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the logs of container [%v] in pod [%v]", containerName, pod.Name)
+	}
+
+	defer func() {
+		if err := stream.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close the logs of pod [%v]: %v", pod.Name, err)
+		}
+	}()
+
+	logs := &headTailBuffer{size: a.cfg.MaxSizeBytes}
+	if _, err := io.Copy(logs, stream); err != nil {
+		return nil, errors.Wrapf(err, "failed to read the logs of container [%v] in pod [%v]", containerName, pod.Name)
+	}
+
+	return logs.Bytes(), nil
+}
+
+// headTailBuffer keeps the beginning and the end of what's written to it, up to size bytes in total.
+type headTailBuffer struct {
+	size    int64
+	head    []byte
+	tail    []byte
+	written int64
+}
+
+func (b *headTailBuffer) Write(p []byte) (int, error) {
+	b.written += int64(len(p))
+	remaining := p
+	if room := b.headSize() - int64(len(b.head)); room > 0 {
+		if room > int64(len(remaining)) {
+			room = int64(len(remaining))
+		}
+
+		b.head = append(b.head, remaining[:room]...)
+		remaining = remaining[room:]
+	}
+
+	// The tail is only trimmed once it's twice as long as needed to avoid copying it on every write.
+	b.tail = append(b.tail, remaining...)
+	if tailSize := b.size - b.headSize(); int64(len(b.tail)) > 2*tailSize {
+		b.tail = append(b.tail[:0], b.tail[int64(len(b.tail))-tailSize:]...)
+	}
+
+	return len(p), nil
+}
+
+func (b *headTailBuffer) headSize() int64 {
+	return b.size / 2
+}
+
+// Bytes returns what was kept, with a marker between the beginning and the end if anything was omitted.
+func (b *headTailBuffer) Bytes() []byte {
+	logs := append([]byte{}, b.head...)
+	omitted := b.written - b.size
+	if omitted <= 0 {
+		return append(logs, b.tail...)
+	}
+
+	logs = append(logs, fmt.Sprintf(truncationMarker, omitted)...)
+	return append(logs, b.tail[int64(len(b.tail))-(b.size-b.headSize()):]...)
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := bytes.Buffer{}
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func NewLogArchiver(cfg *config.LogArchiveConfig, clientset kubernetes.Interface) LogArchiver {
+	return LogArchiver{
+		cfg:       cfg,
+		clientset: clientset,
+	}
+}
+
+var (
+	// archivedContainers remembers the links to the logs that were archived, keyed by pod uid and container name, so that
+	// later rounds neither check the data store nor read the logs again. The k8s plugin context doesn't carry plugin
+	// state to record it in. Failed archives aren't remembered and are attempted again.
+	archivedContainers, _ = lru.New(archivedContainersCacheSize)
+
+	// archivingContainers holds the keys of the containers whose logs are being archived in the background.
+	archivingLock       sync.Mutex
+	archivingContainers = map[string]struct{}{}
+)
+
+// getClientset returns the clientset of the kube client of the plugin, which the logs are read with.
+func getClientset(pluginContext k8s.PluginContext) (kubernetes.Interface, error) {
+	kubeClientProvider, ok := pluginContext.(k8s.KubeClientProvider)
+	if !ok {
+		return nil, errors.New("the plugin context doesn't provide the kube client of the plugin")
+	}
+
+	clientsetProvider, ok := kubeClientProvider.KubeClient().(pluginsCore.ClientsetProvider)
+	if !ok || clientsetProvider.GetClientset() == nil {
+		return nil, errors.New("the kube client of the plugin doesn't provide a clientset to read logs with")
+	}
+
+	return clientsetProvider.GetClientset(), nil
+}
+
+// startArchiving marks the container as being archived unless it already is, and returns whether it's being archived.
+// Containers aren't archived while all the workers are busy.
+func startArchiving(key string, workers int) bool {
+	archivingLock.Lock()
+	defer archivingLock.Unlock()
+
+	if _, archiving := archivingContainers[key]; archiving {
+		return true
+	}
+
+	if len(archivingContainers) >= workers {
+		return false
+	}
+
+	archivingContainers[key] = struct{}{}
+	return true
+}
+
+func stopArchiving(key string) {
+	archivingLock.Lock()
+	defer archivingLock.Unlock()
+
+	delete(archivingContainers, key)
+}
+
+// ArchiveLogsForContainerInPod archives the logs of the container in the background once it has terminated, if enabled
+// by the k8s plugin config, and returns the link to them. The link is returned as soon as archiving starts since the
+// round that observes a terminated container is often the last one. The logs are read with the kube client of the
+// plugin, which the plugin context must provide. Archiving is best-effort: failures are logged and archiving is attempted
+// again in the next round, if any.
+func ArchiveLogsForContainerInPod(ctx context.Context, pluginContext k8s.PluginContext, pod *v1.Pod, containerName,
+	nameSuffix string) *core.TaskLog {
+
+	cfg := config.GetK8sPluginConfig().LogArchive
+	if !cfg.Enabled || !isTerminated(pod, containerName) {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s/%s", pod.UID, containerName)
+	if archived, found := archivedContainers.Get(key); found {
+		return archived.(*core.TaskLog)
+	}
+
+	clientset, err := getClientset(pluginContext)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to archive the logs of container [%v] in pod [%v]: %v", containerName, pod.Name, err)
+		return nil
+	}
+
+	dataStore := pluginContext.DataStore()
+	rawOutputPrefix := pluginContext.OutputWriter().GetRawOutputPrefix()
+	_, taskLog, err := archivedLogsLocation(ctx, dataStore, rawOutputPrefix, pod, containerName, nameSuffix)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to archive the logs of container [%v] in pod [%v]: %v", containerName, pod.Name, err)
+		return nil
+	}
+
+	if !startArchiving(key, cfg.Workers) {
+		return nil
+	}
+
+	go func() {
+		defer stopArchiving(key)
+
+		// Archiving outlives the round that started it, it mustn't be canceled along with the context of the round.
+		archivedLog, err := NewLogArchiver(&cfg, clientset).ArchiveContainerLogs(context.Background(), dataStore,
+			rawOutputPrefix, pod, containerName, nameSuffix)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to archive the logs of container [%v] in pod [%v]: %v", containerName, pod.Name, err)
+			return
+		}
+
+		archivedContainers.Add(key, archivedLog)
+	}()
+
+	return taskLog
+}
+
+func isTerminated(pod *v1.Pod, containerName string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.State.Terminated != nil
+		}
+	}
+
+	return false
+}
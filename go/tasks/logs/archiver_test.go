@@ -0,0 +1,294 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	storageMocks "github.com/flyteorg/flytestdlib/storage/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
+func newArchivedPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Namespace: "my-namespace",
+			Name:      "my-pod",
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "primary",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readArchivedLogs(t testing.TB, dataStore *storage.DataStore, reference string) string {
+	reader, err := dataStore.ReadRaw(context.TODO(), storage.DataReference(reference))
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	gzipReader, err := gzip.NewReader(reader)
+	assert.NoError(t, err)
+
+	logs, err := ioutil.ReadAll(gzipReader)
+	assert.NoError(t, err)
+	return string(logs)
+}
+
+func TestLogArchiver_ArchiveContainerLogs(t *testing.T) {
+	ctx := context.TODO()
+	pod := newArchivedPod()
+	clientset := fake.NewSimpleClientset(pod)
+	cfg := config.GetK8sPluginConfig().LogArchive
+
+	t.Run("archive", func(t *testing.T) {
+		dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		taskLog, err := NewLogArchiver(&cfg, clientset).ArchiveContainerLogs(ctx, dataStore, "s3://bucket/raw",
+			pod, "primary", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, &core.TaskLog{
+			Uri:           "s3://bucket/raw/logs/my-pod_primary.log.gz",
+			Name:          "Archived Logs (User)",
+			MessageFormat: core.TaskLog_UNKNOWN,
+		}, taskLog)
+
+		// The fake clientset returns the same logs for any pod.
+		assert.Equal(t, "fake logs", readArchivedLogs(t, dataStore, taskLog.Uri))
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		truncatingCfg := cfg
+		truncatingCfg.MaxSizeBytes = 4
+		taskLog, err := NewLogArchiver(&truncatingCfg, clientset).ArchiveContainerLogs(ctx, dataStore,
+			"s3://bucket/raw", pod, "primary", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, "fa\n[truncated: 5 bytes omitted]\ngs", readArchivedLogs(t, dataStore, taskLog.Uri))
+	})
+
+	t.Run("already archived", func(t *testing.T) {
+		dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		archived, err := compress([]byte("archived logs"))
+		assert.NoError(t, err)
+		assert.NoError(t, dataStore.WriteRaw(ctx, "s3://bucket/raw/logs/my-pod_primary.log.gz", int64(len(archived)),
+			storage.Options{}, bytes.NewReader(archived)))
+
+		taskLog, err := NewLogArchiver(&cfg, clientset).ArchiveContainerLogs(ctx, dataStore, "s3://bucket/raw",
+			pod, "primary", " (User)")
+		assert.NoError(t, err)
+		assert.Equal(t, "archived logs", readArchivedLogs(t, dataStore, taskLog.Uri))
+	})
+}
+
+func TestHeadTailBuffer(t *testing.T) {
+	t.Run("short", func(t *testing.T) {
+		buffer := &headTailBuffer{size: 10}
+		_, err := io.WriteString(buffer, "0123")
+		assert.NoError(t, err)
+		_, err = io.WriteString(buffer, "45")
+		assert.NoError(t, err)
+		assert.Equal(t, "012345", string(buffer.Bytes()))
+	})
+
+	t.Run("long", func(t *testing.T) {
+		buffer := &headTailBuffer{size: 6}
+		for _, line := range []string{"first\n", "second\n", "third\n", "last\n"} {
+			n, err := io.WriteString(buffer, line)
+			assert.NoError(t, err)
+			assert.Equal(t, len(line), n)
+		}
+
+		assert.Equal(t, "fir\n[truncated: 18 bytes omitted]\nst\n", string(buffer.Bytes()))
+	})
+}
+
+// clientsetKubeClient is a kube client that provides a clientset, as the one of the plugins does.
+type clientsetKubeClient struct {
+	*coreMocks.KubeClient
+	clientset kubernetes.Interface
+}
+
+func (c clientsetKubeClient) GetClientset() kubernetes.Interface {
+	return c.clientset
+}
+
+// kubeClientPluginContext is a plugin context that provides the kube client of the plugin.
+type kubeClientPluginContext struct {
+	*k8sMocks.PluginContext
+	kubeClient pluginsCore.KubeClient
+}
+
+func (p kubeClientPluginContext) KubeClient() pluginsCore.KubeClient {
+	return p.kubeClient
+}
+
+func newArchivingPluginContext(pod *v1.Pod, dataStore *storage.DataStore) kubeClientPluginContext {
+	outputWriter := &ioMocks.OutputWriter{}
+	outputWriter.OnGetRawOutputPrefix().Return("s3://bucket/raw")
+
+	pluginContext := &k8sMocks.PluginContext{}
+	pluginContext.OnDataStore().Return(dataStore)
+	pluginContext.OnOutputWriter().Return(outputWriter)
+	return kubeClientPluginContext{
+		PluginContext: pluginContext,
+		kubeClient: clientsetKubeClient{
+			KubeClient: &coreMocks.KubeClient{},
+			clientset:  fake.NewSimpleClientset(pod),
+		},
+	}
+}
+
+func enableLogArchive(t testing.TB) func() {
+	cfg := *config.GetK8sPluginConfig()
+	cfg.LogArchive.Enabled = true
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+	return func() {
+		cfg.LogArchive.Enabled = false
+		assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+	}
+}
+
+func isArchiving(key string) bool {
+	archivingLock.Lock()
+	defer archivingLock.Unlock()
+
+	_, archiving := archivingContainers[key]
+	return archiving
+}
+
+func TestArchiveLogsForContainerInPod(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, ArchiveLogsForContainerInPod(context.TODO(), nil, newArchivedPod(), "primary", " (User)"))
+	})
+
+	t.Run("running", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		pod := newArchivedPod()
+		pod.Status.ContainerStatuses[0].State = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+		assert.Nil(t, ArchiveLogsForContainerInPod(context.TODO(), nil, pod, "primary", " (User)"))
+	})
+
+	t.Run("already archived", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		pod := newArchivedPod()
+		pod.UID = "archived-pod"
+		taskLog := &core.TaskLog{Uri: "s3://bucket/raw/logs/my-pod_primary.log.gz"}
+		archivedContainers.Add("archived-pod/primary", taskLog)
+
+		// Neither the data store nor the logs are read again.
+		assert.Equal(t, taskLog, ArchiveLogsForContainerInPod(context.TODO(), nil, pod, "primary", " (User)"))
+	})
+
+	t.Run("archive in the background", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		dataStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		pod := newArchivedPod()
+		pod.UID = "background-pod"
+		taskLog := ArchiveLogsForContainerInPod(context.TODO(), newArchivingPluginContext(pod, dataStore), pod,
+			"primary", " (User)")
+		assert.Equal(t, &core.TaskLog{
+			Uri:           "s3://bucket/raw/logs/my-pod_primary.log.gz",
+			Name:          "Archived Logs (User)",
+			MessageFormat: core.TaskLog_UNKNOWN,
+		}, taskLog)
+
+		assert.Eventually(t, func() bool {
+			_, archived := archivedContainers.Get("background-pod/primary")
+			return archived
+		}, time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, "fake logs", readArchivedLogs(t, dataStore, taskLog.Uri))
+	})
+
+	t.Run("failed archives are attempted again", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		memStore, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		store := &storageMocks.ComposedProtobufStore{}
+		store.OnHeadMatch(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("unavailable"))
+		dataStore := storage.NewCompositeDataStore(memStore.ReferenceConstructor, store)
+
+		pod := newArchivedPod()
+		pod.UID = "failing-pod"
+		pluginContext := newArchivingPluginContext(pod, dataStore)
+		assert.NotNil(t, ArchiveLogsForContainerInPod(context.TODO(), pluginContext, pod, "primary", " (User)"))
+		assert.Eventually(t, func() bool {
+			return !isArchiving("failing-pod/primary")
+		}, time.Second, 10*time.Millisecond)
+
+		_, archived := archivedContainers.Get("failing-pod/primary")
+		assert.False(t, archived)
+
+		assert.NotNil(t, ArchiveLogsForContainerInPod(context.TODO(), pluginContext, pod, "primary", " (User)"))
+		assert.Eventually(t, func() bool {
+			return !isArchiving("failing-pod/primary")
+		}, time.Second, 10*time.Millisecond)
+
+		store.AssertNumberOfCalls(t, "Head", 2)
+	})
+
+	t.Run("no kube client", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		pod := newArchivedPod()
+		pod.UID = "unreadable-pod"
+		assert.Nil(t, ArchiveLogsForContainerInPod(context.TODO(), &k8sMocks.PluginContext{}, pod, "primary", " (User)"))
+	})
+
+	t.Run("kube client without a clientset", func(t *testing.T) {
+		defer enableLogArchive(t)()
+
+		pluginContext := kubeClientPluginContext{
+			PluginContext: &k8sMocks.PluginContext{},
+			kubeClient:    &coreMocks.KubeClient{},
+		}
+
+		pod := newArchivedPod()
+		pod.UID = "unreadable-pod"
+		assert.Nil(t, ArchiveLogsForContainerInPod(context.TODO(), pluginContext, pod, "primary", " (User)"))
+	})
+}
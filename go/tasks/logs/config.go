@@ -1,8 +1,11 @@
 package logs
 
 import (
+	"context"
+
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/config"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
 )
 
 //go:generate pflags LogConfig
@@ -32,16 +35,46 @@ type LogConfig struct {
 	StackDriverTemplateURI     TemplateURI `json:"stackdriver-template-uri" pflag:",Template Uri to use when building stackdriver log links"`
 
 	Templates []TemplateLogPluginConfig `json:"templates" pflag:"-,"`
+
+	// Overrides replace the log plugins above for the executions they select. The first matching override applies.
+	Overrides []LogConfigOverride `json:"overrides" pflag:"-,"`
+}
+
+// LogConfigOverride configures the log links of the executions selected by its selector.
+type LogConfigOverride struct {
+	Selector  LogSelector               `json:"selector" pflag:",Executions the override applies to."`
+	Templates []TemplateLogPluginConfig `json:"templates" pflag:",Log plugins to use for the selected executions."`
+}
+
+// LogSelector selects executions by their project, domain, task type and pod labels. Empty fields match any
+// execution, values within a field are alternatives and all the labels must match.
+type LogSelector struct {
+	Projects  []string          `json:"projects" pflag:",Projects to select."`
+	Domains   []string          `json:"domains" pflag:",Domains to select."`
+	TaskTypes []string          `json:"taskTypes" pflag:",Task types to select."`
+	PodLabels map[string]string `json:"podLabels" pflag:",Labels the pod must have."`
 }
 
 type TemplateLogPluginConfig struct {
 	DisplayName   string                     `json:"displayName" pflag:",Display name for the generated log when displayed in the console."`
 	TemplateURIs  []TemplateURI              `json:"templateUris" pflag:",URI Templates for generating task log links."`
 	MessageFormat core.TaskLog_MessageFormat `json:"messageFormat" pflag:",Log Message Format."`
+
+	// StartTimeOffset and FinishTimeOffset shift the time window of the links, e.g. set StartTimeOffset to -5m to include
+	// the logs emitted while the pod was being scheduled.
+	StartTimeOffset  stdConfig.Duration `json:"startTimeOffset" pflag:",Offset added to the start time of the pod in the log links."`
+	FinishTimeOffset stdConfig.Duration `json:"finishTimeOffset" pflag:",Offset added to the finish time of the pod in the log links."`
 }
 
 var (
-	logConfigSection = config.MustRegisterSubSection("logs", &LogConfig{})
+	defaultConfig = &LogConfig{}
+
+	logConfigSection = config.MustRegisterSubSectionWithUpdates("logs", defaultConfig,
+		func(ctx context.Context, newValue stdConfig.Config) {
+			logResolver.Update(newValue.(*LogConfig))
+		})
+
+	logResolver = NewCachedResolver(defaultConfig)
 )
 
 func GetLogConfig() *LogConfig {
@@ -50,5 +83,10 @@ func GetLogConfig() *LogConfig {
 
 // This method should be used for unit testing only
 func SetLogConfig(logConfig *LogConfig) error {
-	return logConfigSection.SetConfig(logConfig)
+	if err := logConfigSection.SetConfig(logConfig); err != nil {
+		return err
+	}
+
+	logResolver.Update(logConfig)
+	return nil
 }
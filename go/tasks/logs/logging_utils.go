@@ -18,8 +18,14 @@ type logPlugin struct {
 }
 
 // Internal
-func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, nameSuffix string) ([]*core.TaskLog, error) {
-	logPlugin, err := InitializeLogPlugins(GetLogConfig())
+func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, taskExecID *core.TaskExecutionIdentifier,
+	taskType string, index uint32, nameSuffix string) ([]*core.TaskLog, error) {
+	if pod == nil {
+		logger.Error(ctx, "cannot extract logs for a nil container")
+		return nil, nil
+	}
+
+	logPlugin, err := ResolveLogPlugin(NewSelection(taskExecID, taskType, pod.Labels))
 	if err != nil {
 		return nil, err
 	}
@@ -28,11 +34,6 @@ func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, na
 		return nil, nil
 	}
 
-	if pod == nil {
-		logger.Error(ctx, "cannot extract logs for a nil container")
-		return nil, nil
-	}
-
 	if uint32(len(pod.Spec.Containers)) <= index {
 		logger.Errorf(ctx, "container IndexOutOfBound, requested [%d], but total containers [%d] in pod phase [%v]", index, len(pod.Spec.Containers), pod.Status.Phase)
 		return nil, nil
@@ -43,15 +44,24 @@ func GetLogsForContainerInPod(ctx context.Context, pod *v1.Pod, index uint32, na
 		return nil, nil
 	}
 
+	// Until the container finishes, the window of the logs extends to now.
+	finishTime := time.Now()
+	if terminated := pod.Status.ContainerStatuses[index].State.Terminated; terminated != nil && !terminated.FinishedAt.IsZero() {
+		finishTime = terminated.FinishedAt.Time
+	}
+
 	logs, err := logPlugin.GetTaskLogs(
 		tasklog.Input{
-			PodName:           pod.Name,
-			Namespace:         pod.Namespace,
-			ContainerName:     pod.Spec.Containers[index].Name,
-			ContainerID:       pod.Status.ContainerStatuses[index].ContainerID,
-			LogName:           nameSuffix,
-			PodUnixStartTime:  pod.CreationTimestamp.Unix(),
-			PodUnixFinishTime: time.Now().Unix(),
+			PodName:                 pod.Name,
+			Namespace:               pod.Namespace,
+			ContainerName:           pod.Spec.Containers[index].Name,
+			ContainerID:             pod.Status.ContainerStatuses[index].ContainerID,
+			LogName:                 nameSuffix,
+			PodUnixStartTime:        pod.CreationTimestamp.Unix(),
+			PodUnixFinishTime:       finishTime.Unix(),
+			PodLabels:               pod.Labels,
+			PodAnnotations:          pod.Annotations,
+			TaskExecutionIdentifier: taskExecID,
 		},
 	)
 
@@ -117,7 +127,8 @@ func InitializeLogPlugins(cfg *LogConfig) (tasklog.Plugin, error) {
 
 	if len(cfg.Templates) > 0 {
 		for _, cfg := range cfg.Templates {
-			logPlugins = append(logPlugins, logPlugin{Name: cfg.DisplayName, Plugin: tasklog.NewTemplateLogPlugin(cfg.TemplateURIs, cfg.MessageFormat)})
+			logPlugins = append(logPlugins, logPlugin{Name: cfg.DisplayName, Plugin: tasklog.NewTemplateLogPluginWithTimeWindow(cfg.TemplateURIs,
+				cfg.MessageFormat, cfg.StartTimeOffset.Duration, cfg.FinishTimeOffset.Duration)})
 		}
 	}
 
@@ -3,8 +3,10 @@ package logs
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/go-test/deep"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -16,7 +18,7 @@ const podName = "PodName"
 
 func TestGetLogsForContainerInPod_NoPlugins(t *testing.T) {
 	assert.NoError(t, SetLogConfig(&LogConfig{}))
-	l, err := GetLogsForContainerInPod(context.TODO(), nil, 0, " Suffix")
+	l, err := GetLogsForContainerInPod(context.TODO(), nil, nil, "", 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, l)
 }
@@ -27,7 +29,7 @@ func TestGetLogsForContainerInPod_NoLogs(t *testing.T) {
 		CloudwatchRegion:    "us-east-1",
 		CloudwatchLogGroup:  "/kubernetes/flyte-production",
 	}))
-	p, err := GetLogsForContainerInPod(context.TODO(), nil, 0, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), nil, nil, "", 0, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -57,7 +59,7 @@ func TestGetLogsForContainerInPod_BadIndex(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -81,7 +83,7 @@ func TestGetLogsForContainerInPod_MissingStatus(t *testing.T) {
 	}
 	pod.Name = podName
 
-	p, err := GetLogsForContainerInPod(context.TODO(), pod, 1, " Suffix")
+	p, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 1, " Suffix")
 	assert.NoError(t, err)
 	assert.Nil(t, p)
 }
@@ -110,7 +112,7 @@ func TestGetLogsForContainerInPod_Cloudwatch(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -139,7 +141,7 @@ func TestGetLogsForContainerInPod_K8s(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -171,7 +173,7 @@ func TestGetLogsForContainerInPod_All(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 2)
 }
@@ -202,7 +204,7 @@ func TestGetLogsForContainerInPod_Stackdriver(t *testing.T) {
 	}
 	pod.Name = podName
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 0, " Suffix")
 	assert.Nil(t, err)
 	assert.Len(t, logs, 1)
 }
@@ -275,7 +277,7 @@ func assertTestSucceeded(tb testing.TB, config *LogConfig, expectedTaskLogs []*c
 		},
 	}
 
-	logs, err := GetLogsForContainerInPod(context.TODO(), pod, 0, " my-Suffix")
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, nil, "", 0, " my-Suffix")
 	assert.Nil(tb, err)
 	assert.Len(tb, logs, len(expectedTaskLogs))
 	if diff := deep.Equal(logs, expectedTaskLogs); len(diff) > 0 {
@@ -302,3 +304,69 @@ func TestGetLogsForContainerInPod_Templates(t *testing.T) {
 		},
 	})
 }
+
+func TestGetLogsForContainerInPod_TemplateVariables(t *testing.T) {
+	assert.NoError(t, SetLogConfig(&LogConfig{
+		Templates: []TemplateLogPluginConfig{
+			{
+				DisplayName: "Grafana",
+				TemplateURIs: []string{
+					"https://grafana/{{ .executionName }}/{{ .nodeId }}/{{ .taskRetryAttempt }}?app={{ .labels.app }}&from={{ .podUnixStartTime }}&to={{ .podUnixFinishTime }}",
+				},
+				MessageFormat:    core.TaskLog_JSON,
+				StartTimeOffset:  stdConfig.Duration{Duration: -5 * time.Minute},
+				FinishTimeOffset: stdConfig.Duration{Duration: time.Minute},
+			},
+		},
+	}))
+
+	pod := &v1.Pod{
+		ObjectMeta: v12.ObjectMeta{
+			Namespace:         "my-namespace",
+			Name:              "my-pod",
+			Labels:            map[string]string{"app": "my-app"},
+			CreationTimestamp: v12.Unix(1600000300, 0),
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "ContainerName",
+				},
+			},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					ContainerID: "ContainerID",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							FinishedAt: v12.Unix(1600000400, 0),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	taskExecID := &core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			NodeId: "n0",
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: "my-project",
+				Domain:  "my-domain",
+				Name:    "my-execution",
+			},
+		},
+		RetryAttempt: 1,
+	}
+
+	logs, err := GetLogsForContainerInPod(context.TODO(), pod, taskExecID, "", 0, " my-Suffix")
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.TaskLog{
+		{
+			Uri:           "https://grafana/my-execution/n0/1?app=my-app&from=1600000000&to=1600000460",
+			MessageFormat: core.TaskLog_JSON,
+			Name:          "Grafana my-Suffix",
+		},
+	}, logs)
+}
@@ -0,0 +1,203 @@
+package logs
+
+import (
+	"sync"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+)
+
+// Selection describes the execution to resolve the log plugin for.
+type Selection struct {
+	Project   string
+	Domain    string
+	TaskType  string
+	PodLabels map[string]string
+}
+
+// NewSelection builds the selection of an execution from its identifier, which may be nil, its task type and the
+// labels of its pods.
+func NewSelection(taskExecID *core.TaskExecutionIdentifier, taskType string, podLabels map[string]string) Selection {
+	return Selection{
+		Project:   taskExecID.GetNodeExecutionId().GetExecutionId().GetProject(),
+		Domain:    taskExecID.GetNodeExecutionId().GetExecutionId().GetDomain(),
+		TaskType:  taskType,
+		PodLabels: podLabels,
+	}
+}
+
+type resolvedOverride struct {
+	selector LogSelector
+	plugin   tasklog.Plugin
+}
+
+// selectionKey is a project, domain and task type an override selects. Empty fields stand for any value.
+type selectionKey struct {
+	project  string
+	domain   string
+	taskType string
+}
+
+// Resolver resolves the log plugin of an execution out of the overrides of a config, falling back to the plugins of
+// the config itself.
+type Resolver struct {
+	defaultPlugin tasklog.Plugin
+	overrides     []resolvedOverride
+
+	// index lists, in order, the positions of the overrides selecting each project, domain and task type.
+	index map[selectionKey][]int
+}
+
+// Resolve returns the log plugin of the first override selecting the execution, or the default one if none does. The
+// plugin is nil if no log links are configured.
+func (r Resolver) Resolve(selection Selection) tasklog.Plugin {
+	first := len(r.overrides)
+	for _, project := range []string{selection.Project, ""} {
+		for _, domain := range []string{selection.Domain, ""} {
+			for _, taskType := range []string{selection.TaskType, ""} {
+				for _, i := range r.index[selectionKey{project: project, domain: domain, taskType: taskType}] {
+					if i >= first {
+						break
+					}
+
+					if r.overrides[i].selector.matches(selection) {
+						first = i
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if first < len(r.overrides) {
+		return r.overrides[first].plugin
+	}
+
+	return r.defaultPlugin
+}
+
+func (s LogSelector) matches(selection Selection) bool {
+	if !matchesAny(s.Projects, selection.Project) || !matchesAny(s.Domains, selection.Domain) ||
+		!matchesAny(s.TaskTypes, selection.TaskType) {
+		return false
+	}
+
+	for key, value := range s.PodLabels {
+		if actual, found := selection.PodLabels[key]; !found || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keys lists the projects, domains and task types the selector selects.
+func (s LogSelector) keys() []selectionKey {
+	orAny := func(values []string) []string {
+		if len(values) == 0 {
+			return []string{""}
+		}
+
+		return values
+	}
+
+	keys := make([]selectionKey, 0, 1)
+	for _, project := range orAny(s.Projects) {
+		for _, domain := range orAny(s.Domains) {
+			for _, taskType := range orAny(s.TaskTypes) {
+				keys = append(keys, selectionKey{project: project, domain: domain, taskType: taskType})
+			}
+		}
+	}
+
+	return keys
+}
+
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewResolver builds the log plugins of the config and of its overrides.
+func NewResolver(cfg *LogConfig) (*Resolver, error) {
+	defaultPlugin, err := InitializeLogPlugins(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]resolvedOverride, 0, len(cfg.Overrides))
+	index := map[selectionKey][]int{}
+	for i, override := range cfg.Overrides {
+		plugin, err := InitializeLogPlugins(&LogConfig{Templates: override.Templates})
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, resolvedOverride{
+			selector: override.Selector,
+			plugin:   plugin,
+		})
+
+		for _, key := range override.Selector.keys() {
+			if positions := index[key]; len(positions) == 0 || positions[len(positions)-1] != i {
+				index[key] = append(positions, i)
+			}
+		}
+	}
+
+	return &Resolver{
+		defaultPlugin: defaultPlugin,
+		overrides:     overrides,
+		index:         index,
+	}, nil
+}
+
+// CachedResolver keeps the resolver of a config so that the log plugins are built when the config is loaded or
+// changes rather than every time log links are generated.
+type CachedResolver struct {
+	lock     sync.RWMutex
+	resolver *Resolver
+	err      error
+}
+
+// NewCachedResolver builds the resolver of the config. Update it whenever the config changes.
+func NewCachedResolver(cfg *LogConfig) *CachedResolver {
+	c := &CachedResolver{}
+	c.Update(cfg)
+	return c
+}
+
+// Update rebuilds the resolver out of the config. An invalid config is reported by Resolve.
+func (c *CachedResolver) Update(cfg *LogConfig) {
+	resolver, err := NewResolver(cfg)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.resolver = resolver
+	c.err = err
+}
+
+// Resolve returns the log plugin configured for the selected execution.
+func (c *CachedResolver) Resolve(selection Selection) (tasklog.Plugin, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.resolver.Resolve(selection), nil
+}
+
+// ResolveLogPlugin returns the log plugin configured in the logs section for the selected execution.
+func ResolveLogPlugin(selection Selection) (tasklog.Plugin, error) {
+	return logResolver.Resolve(selection)
+}
@@ -0,0 +1,156 @@
+package logs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLogURIs(t testing.TB, plugin tasklog.Plugin) []string {
+	if plugin == nil {
+		return nil
+	}
+
+	o, err := plugin.GetTaskLogs(tasklog.Input{PodName: "my-pod"})
+	assert.NoError(t, err)
+
+	uris := make([]string, 0, len(o.TaskLogs))
+	for _, l := range o.TaskLogs {
+		uris = append(uris, l.Uri)
+	}
+
+	return uris
+}
+
+func TestResolveLogPlugin(t *testing.T) {
+	cfg := &LogConfig{
+		IsKubernetesEnabled:   true,
+		KubernetesTemplateURI: "https://k8s/{{ .podName }}",
+		Overrides: []LogConfigOverride{
+			{
+				Selector: LogSelector{
+					Projects: []string{"team-a"},
+					Domains:  []string{"production"},
+				},
+				Templates: []TemplateLogPluginConfig{
+					{
+						DisplayName:   "Loki",
+						TemplateURIs:  []string{"https://loki/{{ .podName }}"},
+						MessageFormat: core.TaskLog_JSON,
+					},
+				},
+			},
+			{
+				Selector: LogSelector{
+					TaskTypes: []string{"spark"},
+					PodLabels: map[string]string{"team": "b"},
+				},
+				Templates: []TemplateLogPluginConfig{
+					{
+						DisplayName:   "Datadog",
+						TemplateURIs:  []string{"https://datadog/{{ .podName }}"},
+						MessageFormat: core.TaskLog_JSON,
+					},
+				},
+			},
+			{
+				Selector: LogSelector{
+					Projects: []string{"no-logs"},
+				},
+			},
+		},
+	}
+
+	taskExecID := func(project, domain string) *core.TaskExecutionIdentifier {
+		return &core.TaskExecutionIdentifier{
+			NodeExecutionId: &core.NodeExecutionIdentifier{
+				ExecutionId: &core.WorkflowExecutionIdentifier{
+					Project: project,
+					Domain:  domain,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		selection Selection
+		want      []string
+	}{
+		{"default", NewSelection(nil, "container", nil), []string{"https://k8s/my-pod"}},
+		{"project and domain", NewSelection(taskExecID("team-a", "production"), "container", nil),
+			[]string{"https://loki/my-pod"}},
+		{"other domain", NewSelection(taskExecID("team-a", "development"), "container", nil),
+			[]string{"https://k8s/my-pod"}},
+		{"task type and labels", NewSelection(taskExecID("team-b", "production"), "spark",
+			map[string]string{"team": "b", "other": "label"}), []string{"https://datadog/my-pod"}},
+		{"missing label", NewSelection(taskExecID("team-b", "production"), "spark", nil),
+			[]string{"https://k8s/my-pod"}},
+		{"first override wins", NewSelection(taskExecID("team-a", "production"), "spark",
+			map[string]string{"team": "b"}), []string{"https://loki/my-pod"}},
+		{"no templates", NewSelection(taskExecID("no-logs", "production"), "container", nil), nil},
+	}
+
+	assert.NoError(t, SetLogConfig(cfg))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ResolveLogPlugin(tt.selection)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, getLogURIs(t, p))
+		})
+	}
+
+	t.Run("config updates", func(t *testing.T) {
+		updated := *cfg
+		updated.KubernetesTemplateURI = "https://k8s-updated/{{ .podName }}"
+
+		logConfigSection.GetConfigUpdatedHandler()(context.Background(), &updated)
+
+		p, err := ResolveLogPlugin(NewSelection(nil, "container", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"https://k8s-updated/my-pod"}, getLogURIs(t, p))
+	})
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	override := func(name string, selector LogSelector) LogConfigOverride {
+		return LogConfigOverride{
+			Selector:  selector,
+			Templates: []TemplateLogPluginConfig{{TemplateURIs: []string{"https://" + name + "/{{ .podName }}"}}},
+		}
+	}
+
+	resolver, err := NewResolver(&LogConfig{
+		Overrides: []LogConfigOverride{
+			override("labels", LogSelector{Projects: []string{"a"}, PodLabels: map[string]string{"team": "x"}}),
+			override("domains", LogSelector{Domains: []string{"staging", "production"}}),
+			override("projects", LogSelector{Projects: []string{"a", "b"}, TaskTypes: []string{"spark"}}),
+			override("any", LogSelector{}),
+		},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		selection Selection
+		want      []string
+	}{
+		{"labels", Selection{Project: "a", Domain: "production", PodLabels: map[string]string{"team": "x"}},
+			[]string{"https://labels/my-pod"}},
+		{"earlier override", Selection{Project: "a", Domain: "production", TaskType: "spark"},
+			[]string{"https://domains/my-pod"}},
+		{"listed project", Selection{Project: "b", Domain: "development", TaskType: "spark"},
+			[]string{"https://projects/my-pod"}},
+		{"unlisted project", Selection{Project: "c", Domain: "development", TaskType: "spark"},
+			[]string{"https://any/my-pod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getLogURIs(t, resolver.Resolve(tt.selection)))
+		})
+	}
+}
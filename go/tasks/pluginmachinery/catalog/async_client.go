@@ -6,6 +6,7 @@ import (
 	"github.com/flyteorg/flytestdlib/bitarray"
 
 	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 )
@@ -26,6 +27,12 @@ type UploadRequest struct {
 	Key              Key
 	ArtifactData     io.OutputReader
 	ArtifactMetadata Metadata
+	// Output prefix of the execution that produced the artifact. Uploads of the same key by different executions are
+	// told apart by it.
+	OutputPrefix storage.DataReference
+	// Groups the request with the other requests of the same task execution, so that the writer workqueue is shared
+	// fairly between executions. Defaults to the execution and node ids in the context.
+	Owner string
 }
 
 type ReadyHandler func(ctx context.Context, future Future)
@@ -51,6 +58,9 @@ type UploadFuture interface {
 type DownloadRequest struct {
 	Key    Key
 	Target io.OutputWriter
+	// Groups the request with the other requests of the same task execution, so that the reader workqueue is shared
+	// fairly between executions. Defaults to the execution and node ids in the context.
+	Owner string
 }
 
 // Catalog download future to represent async process of downloading catalog artifacts.
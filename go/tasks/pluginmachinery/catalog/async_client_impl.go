@@ -8,6 +8,7 @@ import (
 	"reflect"
 
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 
 	"github.com/flyteorg/flytestdlib/bitarray"
 
@@ -30,6 +31,17 @@ func formatWorkItemID(key Key, idx int, suffix string) string {
 	return fmt.Sprintf("%v-%v-%v", key, idx, suffix)
 }
 
+// queueOptions returns the options to queue the work item of a request with. Lookups are queued with a higher priority
+// than writes since tasks wait for them to launch.
+func queueOptions(owner string, priority workqueue.Priority) []workqueue.QueueOption {
+	opts := []workqueue.QueueOption{workqueue.WithPriority(priority)}
+	if len(owner) > 0 {
+		opts = append(opts, workqueue.WithOwner(owner))
+	}
+
+	return opts
+}
+
 func consistentHash(str string) (string, error) {
 	hasher := fnv.New32a()
 	_, err := hasher.Write([]byte(str))
@@ -55,7 +67,7 @@ func (c AsyncClientImpl) Download(ctx context.Context, requests ...DownloadReque
 		workItemID := formatWorkItemID(request.Key, idx, uniqueOutputLoc)
 		err = c.Reader.Queue(ctx, workItemID, NewReaderWorkItem(
 			request.Key,
-			request.Target))
+			request.Target), queueOptions(request.Owner, workqueue.PriorityHigh)...)
 
 		if err != nil {
 			return nil, err
@@ -95,11 +107,16 @@ func (c AsyncClientImpl) Upload(ctx context.Context, requests ...UploadRequest)
 	status := ResponseStatusReady
 	var respErr error
 	for idx, request := range requests {
-		workItemID := formatWorkItemID(request.Key, idx, "")
-		err := c.Writer.Queue(ctx, workItemID, NewWriterWorkItem(
+		uniqueOutputLoc, err := consistentHash(request.OutputPrefix.String())
+		if err != nil {
+			return nil, err
+		}
+
+		workItemID := formatWorkItemID(request.Key, idx, uniqueOutputLoc)
+		err = c.Writer.Queue(ctx, workItemID, NewWriterWorkItem(
 			request.Key,
 			request.ArtifactData,
-			request.ArtifactMetadata))
+			request.ArtifactMetadata), queueOptions(request.Owner, workqueue.PriorityLow)...)
 
 		if err != nil {
 			return nil, err
@@ -155,3 +172,26 @@ func NewAsyncClient(client Client, cfg Config, scope promutils.Scope) (AsyncClie
 		Writer: writerWorkQueue,
 	}, nil
 }
+
+// NewPersistentAsyncClient creates an async client whose workqueues persist processed items in the data store, if
+// persistence is enabled in the config, so that lookups and writes aren't redone after a restart.
+func NewPersistentAsyncClient(ctx context.Context, client Client, cfg Config, store *storage.DataStore,
+	scope promutils.Scope) (AsyncClientImpl, error) {
+
+	readerWorkQueue, err := workqueue.NewPersistentIndexedWorkQueue(ctx, "reader", NewReaderProcessor(client),
+		cfg.ReaderWorkqueueConfig, cfg.Persistence, store, scope.NewSubScope("reader"))
+	if err != nil {
+		return AsyncClientImpl{}, err
+	}
+
+	writerWorkQueue, err := workqueue.NewPersistentIndexedWorkQueue(ctx, "writer", NewWriterProcessor(client),
+		cfg.WriterWorkqueueConfig, cfg.Persistence, store, scope.NewSubScope("writer"))
+	if err != nil {
+		return AsyncClientImpl{}, err
+	}
+
+	return AsyncClientImpl{
+		Reader: readerWorkQueue,
+		Writer: writerWorkQueue,
+	}, nil
+}
@@ -5,10 +5,17 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	mocks2 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue/mocks"
 	"github.com/flyteorg/flytestdlib/bitarray"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
 )
@@ -21,7 +28,7 @@ func TestAsyncClientImpl_Download(t *testing.T) {
 	info.OnItem().Return(NewReaderWorkItem(Key{}, &mocks2.OutputWriter{}))
 	info.OnStatus().Return(workqueue.WorkStatusSucceeded)
 	q.OnGetMatch(mock.Anything).Return(info, true, nil)
-	q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	ow := &mocks2.OutputWriter{}
 	ow.OnGetOutputPrefixPath().Return("/prefix/")
@@ -65,8 +72,8 @@ func TestAsyncClientImpl_Upload(t *testing.T) {
 	info := &mocks.WorkItemInfo{}
 	info.OnItem().Return(NewReaderWorkItem(Key{}, &mocks2.OutputWriter{}))
 	info.OnStatus().Return(workqueue.WorkStatusSucceeded)
-	q.OnGet("{UNSPECIFIED     {} [] 0}:-0-").Return(info, true, nil)
-	q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	q.OnGetMatch(mock.Anything).Return(info, true, nil)
+	q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	tests := []struct {
 		name          string
@@ -76,7 +83,8 @@ func TestAsyncClientImpl_Upload(t *testing.T) {
 	}{
 		{"UploadSucceeded", []UploadRequest{
 			{
-				Key: Key{},
+				Key:          Key{},
+				OutputPrefix: "/prefix/",
 			},
 		}, newUploadFuture(ResponseStatusReady, nil), false},
 	}
@@ -97,6 +105,89 @@ func TestAsyncClientImpl_Upload(t *testing.T) {
 	}
 }
 
+func TestAsyncClientImpl_Upload_UniquePerExecution(t *testing.T) {
+	ctx := context.Background()
+
+	info := &mocks.WorkItemInfo{}
+	info.OnStatus().Return(workqueue.WorkStatusSucceeded)
+
+	var queuedIDs []workqueue.WorkItemID
+	q := &mocks.IndexedWorkQueue{}
+	q.OnGetMatch(mock.Anything).Return(info, true, nil)
+	q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		queuedIDs = append(queuedIDs, args.Get(1).(workqueue.WorkItemID))
+	}).Return(nil)
+
+	c := AsyncClientImpl{
+		Writer: q,
+	}
+
+	// A later execution writing the same key must not be mistaken for the first one.
+	for _, prefix := range []storage.DataReference{"/first/", "/second/", "/first/"} {
+		_, err := c.Upload(ctx, UploadRequest{Key: Key{}, OutputPrefix: prefix})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, queuedIDs, 3)
+	assert.NotEqual(t, queuedIDs[0], queuedIDs[1])
+	assert.Equal(t, queuedIDs[0], queuedIDs[2])
+}
+
+// notCachedClient finds nothing in the catalog and accepts every write.
+type notCachedClient struct{}
+
+func (notCachedClient) Get(_ context.Context, _ Key) (Entry, error) {
+	return Entry{}, grpcStatus.Error(codes.NotFound, "not cached")
+}
+
+func (notCachedClient) Put(_ context.Context, _ Key, _ io.OutputReader, _ Metadata) (Status, error) {
+	return NewStatus(core.CatalogCacheStatus_CACHE_POPULATED, nil), nil
+}
+
+func TestAsyncClientImpl_QueueOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workqueueConfig := workqueue.Config{
+		Workers:            1,
+		MaxRetries:         1,
+		IndexCacheMaxItems: 10,
+	}
+
+	c, err := NewAsyncClient(notCachedClient{}, Config{
+		ReaderWorkqueueConfig: workqueueConfig,
+		WriterWorkqueueConfig: workqueueConfig,
+	}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, c.Start(ctx))
+
+	ow := &mocks2.OutputWriter{}
+	ow.OnGetOutputPrefixPath().Return("/prefix/")
+
+	_, err = c.Download(ctx, DownloadRequest{Key: Key{}, Target: ow, Owner: "execution"})
+	assert.NoError(t, err)
+
+	_, err = c.Upload(ctx, UploadRequest{Key: Key{}, OutputPrefix: "/prefix/", Owner: "execution"})
+	assert.NoError(t, err)
+
+	uniqueOutputLoc, err := consistentHash("/prefix/")
+	assert.NoError(t, err)
+	workItemID := formatWorkItemID(Key{}, 0, uniqueOutputLoc)
+
+	// Lookups, which tasks wait for to launch, are served before writes.
+	info, found, err := c.Reader.Get(workItemID)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "execution", info.Owner())
+	assert.Equal(t, workqueue.PriorityHigh, info.Priority())
+
+	info, found, err = c.Writer.Get(workItemID)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "execution", info.Owner())
+	assert.Equal(t, workqueue.PriorityLow, info.Priority())
+}
+
 func TestAsyncClientImpl_Start(t *testing.T) {
 	type fields struct {
 		Reader workqueue.IndexedWorkQueue
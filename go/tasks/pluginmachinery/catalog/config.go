@@ -1,6 +1,10 @@
 package catalog
 
 import (
+	"time"
+
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
 )
@@ -12,6 +16,8 @@ var cfgSection = config.MustRegisterSubSection("catalogCache", defaultConfig)
 type Config struct {
 	ReaderWorkqueueConfig workqueue.Config `json:"reader" pflag:",Catalog reader workqueue config. Make sure the index cache must be big enough to accommodate the biggest array task allowed to run on the system."`
 	WriterWorkqueueConfig workqueue.Config `json:"writer" pflag:",Catalog writer workqueue config. Make sure the index cache must be big enough to accommodate the biggest array task allowed to run on the system."`
+	// Only honored by clients created through NewPersistentAsyncClient.
+	Persistence workqueue.PersistenceConfig `json:"persistence" pflag:",Config for persisting the results of the reader and writer workqueues."`
 }
 
 var defaultConfig = &Config{
@@ -25,6 +31,9 @@ var defaultConfig = &Config{
 		Workers:            10,
 		IndexCacheMaxItems: 1000,
 	},
+	Persistence: workqueue.PersistenceConfig{
+		TTL: stdConfig.Duration{Duration: 24 * time.Hour},
+	},
 }
 
 func GetConfig() *Config {
@@ -53,8 +53,20 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "reader.workers"), defaultConfig.ReaderWorkqueueConfig.Workers, "Number of concurrent workers to start processing the queue.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "reader.maxRetries"), defaultConfig.ReaderWorkqueueConfig.MaxRetries, "Maximum number of retries per item.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "reader.maxItems"), defaultConfig.ReaderWorkqueueConfig.IndexCacheMaxItems, "Maximum number of entries to keep in the index.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "reader.backoff.base"), defaultConfig.ReaderWorkqueueConfig.Backoff.Base.String(), "Delay before the first retry of a failed item.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "reader.backoff.max"), defaultConfig.ReaderWorkqueueConfig.Backoff.Max.String(), "Maximum delay between retries of a failed item.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "reader.backoff.jitterPercent"), defaultConfig.ReaderWorkqueueConfig.Backoff.JitterPercent, "Maximum percentage of the delay to randomly add to each retry delay.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writer.workers"), defaultConfig.WriterWorkqueueConfig.Workers, "Number of concurrent workers to start processing the queue.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writer.maxRetries"), defaultConfig.WriterWorkqueueConfig.MaxRetries, "Maximum number of retries per item.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writer.maxItems"), defaultConfig.WriterWorkqueueConfig.IndexCacheMaxItems, "Maximum number of entries to keep in the index.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "writer.backoff.base"), defaultConfig.WriterWorkqueueConfig.Backoff.Base.String(), "Delay before the first retry of a failed item.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "writer.backoff.max"), defaultConfig.WriterWorkqueueConfig.Backoff.Max.String(), "Maximum delay between retries of a failed item.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writer.backoff.jitterPercent"), defaultConfig.WriterWorkqueueConfig.Backoff.JitterPercent, "Maximum percentage of the delay to randomly add to each retry delay.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "persistence.enabled"), defaultConfig.Persistence.Enabled, "Enables persisting terminal item statuses so they survive restarts and evictions.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "persistence.type"), defaultConfig.Persistence.Type, "Where item statuses are persisted. One of storage or boltdb.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "persistence.prefix"), defaultConfig.Persistence.Prefix, "Storage prefix under which item statuses are persisted. Only used by the storage type.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "persistence.path"), defaultConfig.Persistence.Path, "Path of the BoltDB file item statuses are persisted in. Only used by the boltdb type.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "persistence.ttl"), defaultConfig.Persistence.TTL.String(), "Duration after which a persisted item status is discarded and the item is processed again.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "persistence.gcInterval"), defaultConfig.Persistence.GCInterval.String(), "Interval at which expired item statuses are removed from BoltDB files. Defaults to the TTL.")
 	return cmdFlags
 }
@@ -141,6 +141,48 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_reader.backoff.base", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ReaderWorkqueueConfig.Backoff.Base.String()
+
+			cmdFlags.Set("reader.backoff.base", testValue)
+			if vString, err := cmdFlags.GetString("reader.backoff.base"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ReaderWorkqueueConfig.Backoff.Base)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reader.backoff.max", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ReaderWorkqueueConfig.Backoff.Max.String()
+
+			cmdFlags.Set("reader.backoff.max", testValue)
+			if vString, err := cmdFlags.GetString("reader.backoff.max"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ReaderWorkqueueConfig.Backoff.Max)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reader.backoff.jitterPercent", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("reader.backoff.jitterPercent", testValue)
+			if vInt, err := cmdFlags.GetInt("reader.backoff.jitterPercent"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.ReaderWorkqueueConfig.Backoff.JitterPercent)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_writer.workers", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -183,4 +225,130 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_writer.backoff.base", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WriterWorkqueueConfig.Backoff.Base.String()
+
+			cmdFlags.Set("writer.backoff.base", testValue)
+			if vString, err := cmdFlags.GetString("writer.backoff.base"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WriterWorkqueueConfig.Backoff.Base)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_writer.backoff.max", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WriterWorkqueueConfig.Backoff.Max.String()
+
+			cmdFlags.Set("writer.backoff.max", testValue)
+			if vString, err := cmdFlags.GetString("writer.backoff.max"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WriterWorkqueueConfig.Backoff.Max)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_writer.backoff.jitterPercent", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("writer.backoff.jitterPercent", testValue)
+			if vInt, err := cmdFlags.GetInt("writer.backoff.jitterPercent"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WriterWorkqueueConfig.Backoff.JitterPercent)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("persistence.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("persistence.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Persistence.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.type", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("persistence.type", testValue)
+			if vString, err := cmdFlags.GetString("persistence.type"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Persistence.Type)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.prefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("persistence.prefix", testValue)
+			if vString, err := cmdFlags.GetString("persistence.prefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Persistence.Prefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.path", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("persistence.path", testValue)
+			if vString, err := cmdFlags.GetString("persistence.path"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Persistence.Path)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Persistence.TTL.String()
+
+			cmdFlags.Set("persistence.ttl", testValue)
+			if vString, err := cmdFlags.GetString("persistence.ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Persistence.TTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_persistence.gcInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Persistence.GCInterval.String()
+
+			cmdFlags.Set("persistence.gcInterval", testValue)
+			if vString, err := cmdFlags.GetString("persistence.gcInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Persistence.GCInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
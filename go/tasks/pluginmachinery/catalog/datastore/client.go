@@ -0,0 +1,186 @@
+// Package datastore provides a catalog.Client that memoizes task outputs in a storage.DataStore. It requires no
+// services and is meant for local development, tests and offline caching.
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+)
+
+const (
+	outputsFile  = "outputs.pb"
+	metadataFile = "metadata.pb"
+)
+
+// Client is a catalog.Client that stores each artifact under a prefix derived from the task identifier, cache version,
+// a hash of the task interface and a digest of the inputs. The version of the task is left out so that artifacts are
+// shared across versions until the cache version is bumped.
+//
+// Artifacts are laid out as: <prefix>/<project>/<domain>/<name>/<cacheVersion>-<interfaceHash>/<inputsDigest>/
+type Client struct {
+	store  *storage.DataStore
+	prefix storage.DataReference
+}
+
+var _ catalog.Client = Client{}
+
+// Computes a digest that is stable across marshalings of equal messages.
+func hashProto(msg proto.Message) (string, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(msg); err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(h[:]), nil
+}
+
+func inputsDigest(ctx context.Context, key catalog.Key) (string, error) {
+	inputs := &core.LiteralMap{}
+	if key.InputReader != nil {
+		literals, err := key.InputReader.Get(ctx)
+		if err != nil {
+			return "", errors.Wrapf(errors.DownstreamSystemError, err, "failed to read inputs for key [%v]", key)
+		}
+
+		if literals != nil {
+			inputs = literals
+		}
+	}
+
+	return hashProto(inputs)
+}
+
+func (c Client) artifactPrefix(ctx context.Context, key catalog.Key) (ref storage.DataReference, tag string, err error) {
+	interfaceHash, err := hashProto(&key.TypedInterface)
+	if err != nil {
+		return "", "", errors.Wrapf(errors.BadTaskSpecification, err, "failed to hash interface for key [%v]", key)
+	}
+
+	tag, err = inputsDigest(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	ref, err = c.store.ConstructReference(ctx, c.prefix, key.Identifier.Project, key.Identifier.Domain,
+		key.Identifier.Name, key.CacheVersion+"-"+interfaceHash[:16], tag)
+	return ref, tag, err
+}
+
+func (c Client) Get(ctx context.Context, key catalog.Key) (catalog.Entry, error) {
+	lookupFailure := catalog.NewFailedCatalogEntry(catalog.NewStatus(core.CatalogCacheStatus_CACHE_LOOKUP_FAILURE, nil))
+	prefix, _, err := c.artifactPrefix(ctx, key)
+	if err != nil {
+		return lookupFailure, err
+	}
+
+	// Metadata is written last, its presence marks the artifact as complete.
+	md := &core.CatalogMetadata{}
+	metadataRef, err := c.store.ConstructReference(ctx, prefix, metadataFile)
+	if err != nil {
+		return lookupFailure, err
+	}
+
+	if err = c.store.ReadProtobuf(ctx, metadataRef, md); err != nil {
+		if storage.IsNotFound(err) {
+			logger.Debugf(ctx, "Artifact not found in local catalog. Key: %v", key)
+			return catalog.NewFailedCatalogEntry(catalog.NewStatus(core.CatalogCacheStatus_CACHE_MISS, nil)),
+				grpcStatus.Errorf(codes.NotFound, "artifact not found for key [%v]", key)
+		}
+
+		return lookupFailure, errors.Wrapf(errors.DownstreamSystemError, err, "failed to read catalog metadata @[%v]", metadataRef)
+	}
+
+	outputs := &core.LiteralMap{}
+	outputsRef, err := c.store.ConstructReference(ctx, prefix, outputsFile)
+	if err != nil {
+		return lookupFailure, err
+	}
+
+	if err = c.store.ReadProtobuf(ctx, outputsRef, outputs); err != nil {
+		return lookupFailure, errors.Wrapf(errors.DownstreamSystemError, err, "failed to read cached outputs @[%v]", outputsRef)
+	}
+
+	return catalog.NewCatalogEntry(ioutils.NewInMemoryOutputReader(outputs, nil),
+		catalog.NewStatus(core.CatalogCacheStatus_CACHE_HIT, md)), nil
+}
+
+func (c Client) Put(ctx context.Context, key catalog.Key, reader io.OutputReader, metadata catalog.Metadata) (catalog.Status, error) {
+	putFailure := catalog.NewStatus(core.CatalogCacheStatus_CACHE_PUT_FAILURE, nil)
+	prefix, tag, err := c.artifactPrefix(ctx, key)
+	if err != nil {
+		return putFailure, err
+	}
+
+	outputs, execErr, err := reader.Read(ctx)
+	if err != nil {
+		return putFailure, errors.Wrapf(errors.DownstreamSystemError, err, "failed to read outputs for key [%v]", key)
+	}
+
+	if execErr != nil {
+		return putFailure, errors.Errorf(errors.BadTaskSpecification, "refusing to cache failed execution for key [%v]: %v",
+			key, execErr.GetMessage())
+	}
+
+	if outputs == nil {
+		outputs = &core.LiteralMap{}
+	}
+
+	datasetID := key.Identifier
+	md := &core.CatalogMetadata{
+		DatasetId: &datasetID,
+		ArtifactTag: &core.CatalogArtifactTag{
+			ArtifactId: tag,
+			Name:       key.CacheVersion + "-" + tag,
+		},
+	}
+
+	if metadata.TaskExecutionIdentifier != nil {
+		md.SourceExecution = &core.CatalogMetadata_SourceTaskExecution{
+			SourceTaskExecution: metadata.TaskExecutionIdentifier,
+		}
+	}
+
+	outputsRef, err := c.store.ConstructReference(ctx, prefix, outputsFile)
+	if err != nil {
+		return putFailure, err
+	}
+
+	if err = c.store.WriteProtobuf(ctx, outputsRef, storage.Options{}, outputs); err != nil {
+		return putFailure, errors.Wrapf(errors.DownstreamSystemError, err, "failed to write cached outputs @[%v]", outputsRef)
+	}
+
+	metadataRef, err := c.store.ConstructReference(ctx, prefix, metadataFile)
+	if err != nil {
+		return putFailure, err
+	}
+
+	if err = c.store.WriteProtobuf(ctx, metadataRef, storage.Options{}, md); err != nil {
+		return putFailure, errors.Wrapf(errors.DownstreamSystemError, err, "failed to write catalog metadata @[%v]", metadataRef)
+	}
+
+	return catalog.NewStatus(core.CatalogCacheStatus_CACHE_POPULATED, md), nil
+}
+
+// NewClient creates a catalog.Client that stores artifacts under prefix in store.
+func NewClient(store *storage.DataStore, prefix storage.DataReference) Client {
+	return Client{
+		store:  store,
+		prefix: prefix,
+	}
+}
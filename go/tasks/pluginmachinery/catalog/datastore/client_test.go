@@ -0,0 +1,121 @@
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/catalog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
+func newIntegerLiterals(values map[string]int64) *core.LiteralMap {
+	literals := make(map[string]*core.Literal, len(values))
+	for name, v := range values {
+		literals[name] = &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{Value: &core.Scalar_Primitive{
+			Primitive: &core.Primitive{Value: &core.Primitive_Integer{Integer: v}},
+		}}}}
+	}
+
+	return &core.LiteralMap{Literals: literals}
+}
+
+func newKey(cacheVersion string, inputs map[string]int64) catalog.Key {
+	ir := &mocks.InputReader{}
+	ir.OnGetMatch(mock.Anything).Return(newIntegerLiterals(inputs), nil)
+
+	return catalog.Key{
+		Identifier: core.Identifier{
+			ResourceType: core.ResourceType_TASK,
+			Project:      "project",
+			Domain:       "domain",
+			Name:         "name",
+			Version:      "version",
+		},
+		CacheVersion: cacheVersion,
+		TypedInterface: core.TypedInterface{
+			Outputs: &core.VariableMap{Variables: map[string]*core.Variable{
+				"out": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}},
+			}},
+		},
+		InputReader: ir,
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	client := NewClient(store, "s3://bucket/catalog")
+
+	t.Run("Miss", func(t *testing.T) {
+		entry, err := client.Get(ctx, newKey("1.0", map[string]int64{"x": 1}))
+		assert.True(t, catalog.IsNotFound(err))
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_MISS, entry.GetStatus().GetCacheStatus())
+	})
+
+	t.Run("Put then hit", func(t *testing.T) {
+		execID := &core.TaskExecutionIdentifier{RetryAttempt: 1}
+		status, err := client.Put(ctx, newKey("1.0", map[string]int64{"x": 1}),
+			ioutils.NewInMemoryOutputReader(newIntegerLiterals(map[string]int64{"out": 5}), nil),
+			catalog.Metadata{TaskExecutionIdentifier: execID})
+		assert.NoError(t, err)
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_POPULATED, status.GetCacheStatus())
+
+		entry, err := client.Get(ctx, newKey("1.0", map[string]int64{"x": 1}))
+		assert.NoError(t, err)
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_HIT, entry.GetStatus().GetCacheStatus())
+		assert.Equal(t, "name", entry.GetStatus().GetMetadata().GetDatasetId().GetName())
+		assert.Equal(t, uint32(1), entry.GetStatus().GetMetadata().GetSourceTaskExecution().GetRetryAttempt())
+
+		actual, execErr, err := entry.GetOutputs().Read(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, execErr)
+		assert.Equal(t, int64(5), actual.GetLiterals()["out"].GetScalar().GetPrimitive().GetInteger())
+	})
+
+	t.Run("Different inputs or version miss", func(t *testing.T) {
+		_, err := client.Get(ctx, newKey("1.0", map[string]int64{"x": 2}))
+		assert.True(t, catalog.IsNotFound(err))
+
+		_, err = client.Get(ctx, newKey("2.0", map[string]int64{"x": 1}))
+		assert.True(t, catalog.IsNotFound(err))
+	})
+
+	t.Run("Different task version hit", func(t *testing.T) {
+		key := newKey("1.0", map[string]int64{"x": 1})
+		key.Identifier.Version = "other-version"
+		entry, err := client.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_HIT, entry.GetStatus().GetCacheStatus())
+	})
+
+	t.Run("Different interface miss", func(t *testing.T) {
+		key := newKey("1.0", map[string]int64{"x": 1})
+		key.TypedInterface = core.TypedInterface{}
+		_, err := client.Get(ctx, key)
+		assert.True(t, catalog.IsNotFound(err))
+	})
+
+	t.Run("Failed execution isn't cached", func(t *testing.T) {
+		status, err := client.Put(ctx, newKey("1.0", map[string]int64{"x": 3}),
+			ioutils.NewInMemoryOutputReader(nil, &io.ExecutionError{ExecutionError: &core.ExecutionError{Message: "boom"}}),
+			catalog.Metadata{})
+		assert.Error(t, err)
+		assert.Equal(t, core.CatalogCacheStatus_CACHE_PUT_FAILURE, status.GetCacheStatus())
+	})
+}
@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -30,6 +31,26 @@ func (item ReaderWorkItem) IsCached() bool {
 	return item.cached
 }
 
+// MarshalState captures the lookup result so it can be restored if the item is recovered after a restart.
+func (item ReaderWorkItem) MarshalState() ([]byte, error) {
+	return json.Marshal(readerWorkItemState{Cached: item.cached})
+}
+
+// UnmarshalState restores a lookup result captured by MarshalState.
+func (item *ReaderWorkItem) UnmarshalState(state []byte) error {
+	s := readerWorkItemState{}
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+
+	item.cached = s.Cached
+	return nil
+}
+
+type readerWorkItemState struct {
+	Cached bool `json:"cached"`
+}
+
 func NewReaderWorkItem(key Key, outputsWriter io.OutputWriter) *ReaderWorkItem {
 	return &ReaderWorkItem{
 		key:           key,
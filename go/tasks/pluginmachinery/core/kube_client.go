@@ -1,6 +1,7 @@
 package core
 
 import (
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -14,3 +15,10 @@ type KubeClient interface {
 	// GetCache returns a cache.Cache
 	GetCache() cache.Cache
 }
+
+// ClientsetProvider is implemented by KubeClients that also provide a client-go clientset, for the APIs the
+// controller-runtime client doesn't support, such as reading the logs of a pod.
+type ClientsetProvider interface {
+	// GetClientset returns a clientset configured with the same Config as the client
+	GetClientset() kubernetes.Interface
+}
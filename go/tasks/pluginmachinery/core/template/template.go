@@ -39,6 +39,9 @@ type Parameters struct {
 	Inputs           io.InputReader
 	OutputPath       io.OutputFilePaths
 	Task             core.TaskTemplatePath
+
+	// EscapeInput, if set, escapes the values of inputs before they're substituted, e.g. to embed them in a JSON string.
+	EscapeInput func(value string) string
 }
 
 // Evaluates templates in each command with the equivalent value from passed args. Templates are case-insensitive
@@ -123,6 +126,9 @@ func render(ctx context.Context, inputTemplate string, params Parameters, perRet
 			errs.Errors = append(errs.Errors, errors.Wrapf(err, "input template [%s]", s))
 			return ""
 		}
+		if params.EscapeInput != nil {
+			return params.EscapeInput(replaced)
+		}
 		return replaced
 	})
 
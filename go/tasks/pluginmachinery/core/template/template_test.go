@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -216,6 +217,29 @@ func TestReplaceTemplateCommandArgs(t *testing.T) {
 		}, actual)
 	})
 
+	t.Run("escaped input arg", func(t *testing.T) {
+		in := dummyInputReader{inputs: &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"str": coreutils.MustMakeLiteral(`say "hi"`),
+			},
+		}}
+		params := Parameters{
+			TaskExecMetadata: taskMetadata,
+			Inputs:           in,
+			OutputPath:       out,
+			EscapeInput:      strings.NewReplacer(`"`, `\"`).Replace,
+		}
+		actual, err := Render(context.TODO(), []string{
+			`{"value": "{{ .Inputs.str }}"}`,
+			"{{ .OutputPrefix }}",
+		}, params)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`{"value": "say \"hi\""}`,
+			"output/blah",
+		}, actual)
+	})
+
 	t.Run("Date", func(t *testing.T) {
 		in := dummyInputReader{inputs: &core.LiteralMap{
 			Literals: map[string]*core.Literal{
@@ -0,0 +1,82 @@
+package flytek8s
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+// AcceleratorConfigKey is the key of the config of the container or of the task that names the accelerator profile the
+// task runs with.
+const AcceleratorConfigKey = "accelerator"
+
+// AcceleratorAnnotationKey is the annotation of the execution that names the accelerator profile its tasks run with,
+// unless their config names one.
+const AcceleratorAnnotationKey = "flyte.org/accelerator"
+
+// GetAcceleratorProfile returns the accelerator profile a task requests, or nil if it requests none. The profile is
+// named in the config of the container of the task, in the config of the task or in the annotations of the execution,
+// in that order.
+func GetAcceleratorProfile(task *core.TaskTemplate, taskExecutionMetadata pluginsCore.TaskExecutionMetadata) (
+	*config.AcceleratorProfile, error) {
+
+	name := getAcceleratorProfileName(task, taskExecutionMetadata)
+	if len(name) == 0 {
+		return nil, nil
+	}
+
+	profile, found := config.GetK8sPluginConfig().AcceleratorProfiles[name]
+	if !found {
+		return nil, errors.Errorf(errors.BadTaskSpecification, "accelerator profile [%s] is not configured", name)
+	}
+	return &profile, nil
+}
+
+func getAcceleratorProfileName(task *core.TaskTemplate, taskExecutionMetadata pluginsCore.TaskExecutionMetadata) string {
+	for _, kv := range task.GetContainer().GetConfig() {
+		if kv.GetKey() == AcceleratorConfigKey && len(kv.GetValue()) > 0 {
+			return kv.GetValue()
+		}
+	}
+	if name := task.GetConfig()[AcceleratorConfigKey]; len(name) > 0 {
+		return name
+	}
+	return taskExecutionMetadata.GetAnnotations()[AcceleratorAnnotationKey]
+}
+
+// ApplyAcceleratorProfile schedules a pod on the nodes of the accelerator profile the task requests, if any. The GPUs
+// the containers of the pod request are requested as the resource of the profile.
+func ApplyAcceleratorProfile(task *core.TaskTemplate, taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
+	podSpec *v1.PodSpec) error {
+
+	profile, err := GetAcceleratorProfile(task, taskExecutionMetadata)
+	if err != nil || profile == nil {
+		return err
+	}
+
+	podSpec.NodeSelector = utils.UnionMaps(podSpec.NodeSelector, profile.NodeSelector)
+	podSpec.Tolerations = append(podSpec.Tolerations, profile.Tolerations...)
+
+	if len(profile.ResourceName) > 0 && profile.ResourceName != ResourceNvidiaGPU {
+		for i := range podSpec.InitContainers {
+			replaceResource(&podSpec.InitContainers[i].Resources, ResourceNvidiaGPU, profile.ResourceName)
+		}
+		for i := range podSpec.Containers {
+			replaceResource(&podSpec.Containers[i].Resources, ResourceNvidiaGPU, profile.ResourceName)
+		}
+	}
+	return nil
+}
+
+func replaceResource(resources *v1.ResourceRequirements, from, to v1.ResourceName) {
+	for _, list := range []v1.ResourceList{resources.Requests, resources.Limits} {
+		if quantity, found := list[from]; found {
+			list[to] = quantity
+			delete(list, from)
+		}
+	}
+}
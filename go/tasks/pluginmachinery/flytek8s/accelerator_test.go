@@ -0,0 +1,116 @@
+package flytek8s
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func TestApplyAcceleratorProfile(t *testing.T) {
+	a100Toleration := v1.Toleration{
+		Key:      "nvidia.com/gpu",
+		Value:    "a100",
+		Operator: v1.TolerationOpEqual,
+		Effect:   v1.TaintEffectNoSchedule,
+	}
+	previousConfig := config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(previousConfig)) }()
+	cfg := *previousConfig
+	cfg.AcceleratorProfiles = map[string]config.AcceleratorProfile{
+		"t4": {
+			NodeSelector: map[string]string{"accelerator": "nvidia-tesla-t4"},
+		},
+		"a100-mig-1g": {
+			NodeSelector: map[string]string{"accelerator": "nvidia-tesla-a100"},
+			Tolerations:  []v1.Toleration{a100Toleration},
+			ResourceName: "nvidia.com/mig-1g.5gb",
+		},
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	metadata := func(annotations map[string]string) *pluginsCoreMock.TaskExecutionMetadata {
+		m := &pluginsCoreMock.TaskExecutionMetadata{}
+		m.OnGetAnnotations().Return(annotations)
+		return m
+	}
+
+	gpuPodSpec := func() *v1.PodSpec {
+		return &v1.PodSpec{
+			NodeSelector: map[string]string{"team": "ml"},
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("1"),
+							ResourceNvidiaGPU: resource.MustParse("2"),
+						},
+						Limits: v1.ResourceList{
+							ResourceNvidiaGPU: resource.MustParse("2"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("no profile", func(t *testing.T) {
+		podSpec := gpuPodSpec()
+		assert.NoError(t, ApplyAcceleratorProfile(&core.TaskTemplate{}, metadata(nil), podSpec))
+		assert.Equal(t, gpuPodSpec(), podSpec)
+	})
+
+	t.Run("profile from the container config", func(t *testing.T) {
+		task := &core.TaskTemplate{
+			Target: &core.TaskTemplate_Container{
+				Container: &core.Container{
+					Config: []*core.KeyValuePair{
+						{Key: AcceleratorConfigKey, Value: "a100-mig-1g"},
+					},
+				},
+			},
+			Config: map[string]string{AcceleratorConfigKey: "t4"},
+		}
+		podSpec := gpuPodSpec()
+		assert.NoError(t, ApplyAcceleratorProfile(task, metadata(nil), podSpec))
+		assert.Equal(t, map[string]string{"team": "ml", "accelerator": "nvidia-tesla-a100"}, podSpec.NodeSelector)
+		assert.Equal(t, []v1.Toleration{a100Toleration}, podSpec.Tolerations)
+
+		resources := podSpec.Containers[0].Resources
+		assert.NotContains(t, resources.Requests, v1.ResourceName(ResourceNvidiaGPU))
+		assert.NotContains(t, resources.Limits, v1.ResourceName(ResourceNvidiaGPU))
+		migRequest := resources.Requests["nvidia.com/mig-1g.5gb"]
+		migLimit := resources.Limits["nvidia.com/mig-1g.5gb"]
+		assert.Equal(t, int64(2), migRequest.Value())
+		assert.Equal(t, int64(2), migLimit.Value())
+	})
+
+	t.Run("profile from the task config", func(t *testing.T) {
+		task := &core.TaskTemplate{
+			Config: map[string]string{AcceleratorConfigKey: "t4"},
+		}
+		podSpec := gpuPodSpec()
+		assert.NoError(t, ApplyAcceleratorProfile(task, metadata(map[string]string{AcceleratorAnnotationKey: "a100-mig-1g"}), podSpec))
+		assert.Equal(t, "nvidia-tesla-t4", podSpec.NodeSelector["accelerator"])
+		assert.Empty(t, podSpec.Tolerations)
+		assert.Contains(t, podSpec.Containers[0].Resources.Requests, v1.ResourceName(ResourceNvidiaGPU))
+	})
+
+	t.Run("profile from the annotations", func(t *testing.T) {
+		podSpec := gpuPodSpec()
+		assert.NoError(t, ApplyAcceleratorProfile(&core.TaskTemplate{},
+			metadata(map[string]string{AcceleratorAnnotationKey: "t4"}), podSpec))
+		assert.Equal(t, "nvidia-tesla-t4", podSpec.NodeSelector["accelerator"])
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		err := ApplyAcceleratorProfile(&core.TaskTemplate{Config: map[string]string{AcceleratorConfigKey: "v100"}},
+			metadata(nil), gpuPodSpec())
+		assert.Error(t, err)
+	})
+}
@@ -40,6 +40,21 @@ var (
 		},
 		DefaultCPURequest:    defaultCPURequest,
 		DefaultMemoryRequest: defaultMemoryRequest,
+		PendingPod: PendingPodConfig{
+			ImagePullBackoffGracePeriod: config2.Duration{
+				Duration: time.Minute * 3,
+			},
+			CreateContainerConfigErrorGracePeriod: config2.Duration{
+				Duration: time.Minute * 3,
+			},
+		},
+		LogArchive: LogArchiveConfig{
+			MaxSizeBytes: 10 * 1024 * 1024,
+			Workers:      10,
+			Timeout: config2.Duration{
+				Duration: time.Second * 30,
+			},
+		},
 	}
 
 	// K8sPluginConfigSection provides a singular top level config section for all plugins.
@@ -98,6 +113,9 @@ type K8sPluginConfig struct {
 	// Currently we support simple resource based tolerations only
 	ResourceTolerations map[v1.ResourceName][]v1.Toleration `json:"resource-tolerations"  pflag:"-,Default tolerations to be applied for resource of type 'key'"`
 
+	// Named accelerator profiles tasks can request, to run on nodes with a specific type or partition of accelerator.
+	AcceleratorProfiles map[string]AcceleratorProfile `json:"accelerator-profiles" pflag:"-,Named accelerator profiles tasks can request to run on a specific type of accelerator."`
+
 	// Flyte CoPilot Configuration
 	CoPilot FlyteCoPilotConfig `json:"co-pilot" pflag:",Co-Pilot Configuration"`
 
@@ -105,6 +123,55 @@ type K8sPluginConfig struct {
 	// are kept around (potentially consuming cluster resources). This, however, will cause k8s log links to expire as
 	// soon as the resource is finalized.
 	DeleteResourceOnFinalize bool `json:"delete-resource-on-finalize" pflag:",Instructs the system to delete the resource on finalize. This ensures that no resources are kept around (potentially consuming cluster resources). This, however, will cause k8s log links to expire as soon as the resource is finalized."`
+
+	// Log archive configuration, to keep the logs of the primary container available once the pod is deleted.
+	LogArchive LogArchiveConfig `json:"log-archive" pflag:",Configures archiving the logs of primary containers."`
+
+	// Configures how pods that are still pending are analyzed.
+	PendingPod PendingPodConfig `json:"pending-pod" pflag:",Configures how pending pods are analyzed."`
+}
+
+type PendingPodConfig struct {
+	// Time a container may back off pulling its image before the task fails. The image may be pushed in the meantime.
+	ImagePullBackoffGracePeriod config2.Duration `json:"image-pull-backoff-grace-period" pflag:",Time a container may back off pulling its image before the task fails."`
+	// Time a container may fail to be created from its config before the task fails. The config maps or secrets it
+	// references may be created in the meantime.
+	CreateContainerConfigErrorGracePeriod config2.Duration `json:"create-container-config-error-grace-period" pflag:",Time a container may fail to be created from its config before the task fails."`
+	// Shapes of the nodes of the cluster. Pods whose requests do not fit any of them can never be scheduled and fail
+	// right away. No pod is failed if no shape is configured.
+	NodeShapes []NodeShape `json:"node-shapes" pflag:"-,Shapes of the nodes of the cluster used to fail pods that can never be scheduled."`
+}
+
+// NodeShape describes the allocatable resources of a kind of node. Extended resources a shape does not list, e.g. GPUs,
+// are considered unavailable on its nodes while the native ones it does not list, e.g. ephemeral-storage, are not checked.
+type NodeShape struct {
+	Name      string          `json:"name"`
+	Resources v1.ResourceList `json:"resources"`
+}
+
+// AcceleratorProfile describes how to run pods on nodes with a given type of accelerator, e.g. an A100 GPU or a MIG
+// partition of one.
+type AcceleratorProfile struct {
+	// Labels of the nodes with the accelerator, added to the node selector of the pods.
+	NodeSelector map[string]string `json:"node-selector"`
+	// Tolerations of the taints of the nodes with the accelerator.
+	Tolerations []v1.Toleration `json:"tolerations"`
+	// Name of the resource the accelerator is requested with, e.g. nvidia.com/mig-1g.5gb. The GPUs containers request
+	// are requested as this resource instead. GPUs are requested as is if empty.
+	ResourceName v1.ResourceName `json:"resource-name"`
+}
+
+type LogArchiveConfig struct {
+	// Whether to archive the logs of the primary container, compressed, under the raw output prefix of the task once it
+	// finishes.
+	Enabled bool `json:"enabled" pflag:",Archives the logs of the primary container under the raw output prefix of the task once it finishes."`
+	// Logs longer than that are truncated, keeping their beginning and their end.
+	MaxSizeBytes int64 `json:"max-size-bytes" pflag:",Maximum size of the logs to archive. Longer logs are truncated."`
+	// Time allowed to read and write the logs.
+	Timeout config2.Duration `json:"timeout" pflag:",Time allowed to read and archive the logs."`
+	// Number of containers whose logs are archived concurrently, in the background. Containers beyond that are archived
+	// in a later round.
+	Workers int `json:"workers" pflag:",Number of containers whose logs are archived concurrently."`
 }
 
 type FlyteCoPilotConfig struct {
@@ -64,5 +64,11 @@ func (cfg K8sPluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.memory"), defaultK8sConfig.CoPilot.Memory, "Used to set memory for co-pilot containers")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.storage"), defaultK8sConfig.CoPilot.Storage, "Default storage limit for individual inputs / outputs")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "delete-resource-on-finalize"), defaultK8sConfig.DeleteResourceOnFinalize, "Instructs the system to delete the resource on finalize. This ensures that no resources are kept around (potentially consuming cluster resources). This,  however,  will cause k8s log links to expire as soon as the resource is finalized.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "log-archive.enabled"), defaultK8sConfig.LogArchive.Enabled, "Archives the logs of the primary container under the raw output prefix of the task once it finishes.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "log-archive.max-size-bytes"), defaultK8sConfig.LogArchive.MaxSizeBytes, "Maximum size of the logs to archive. Longer logs are truncated.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "log-archive.timeout"), defaultK8sConfig.LogArchive.Timeout.String(), "Time allowed to read and archive the logs.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "log-archive.workers"), defaultK8sConfig.LogArchive.Workers, "Number of containers whose logs are archived concurrently.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pending-pod.image-pull-backoff-grace-period"), defaultK8sConfig.PendingPod.ImagePullBackoffGracePeriod.String(), "Time a container may back off pulling its image before the task fails.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "pending-pod.create-container-config-error-grace-period"), defaultK8sConfig.PendingPod.CreateContainerConfigErrorGracePeriod.String(), "Time a container may fail to be created from its config before the task fails.")
 	return cmdFlags
 }
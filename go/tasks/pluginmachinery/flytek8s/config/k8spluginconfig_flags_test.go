@@ -295,4 +295,88 @@ func TestK8sPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_log-archive.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-archive.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("log-archive.enabled"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vBool), &actual.LogArchive.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-archive.max-size-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-archive.max-size-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("log-archive.max-size-bytes"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt64), &actual.LogArchive.MaxSizeBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-archive.timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.LogArchive.Timeout.String()
+
+			cmdFlags.Set("log-archive.timeout", testValue)
+			if vString, err := cmdFlags.GetString("log-archive.timeout"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.LogArchive.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_log-archive.workers", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("log-archive.workers", testValue)
+			if vInt, err := cmdFlags.GetInt("log-archive.workers"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt), &actual.LogArchive.Workers)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pending-pod.image-pull-backoff-grace-period", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.PendingPod.ImagePullBackoffGracePeriod.String()
+
+			cmdFlags.Set("pending-pod.image-pull-backoff-grace-period", testValue)
+			if vString, err := cmdFlags.GetString("pending-pod.image-pull-backoff-grace-period"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.PendingPod.ImagePullBackoffGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_pending-pod.create-container-config-error-grace-period", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.PendingPod.CreateContainerConfigErrorGracePeriod.String()
+
+			cmdFlags.Set("pending-pod.create-container-config-error-grace-period", testValue)
+			if vString, err := cmdFlags.GetString("pending-pod.create-container-config-error-grace-period"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.PendingPod.CreateContainerConfigErrorGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
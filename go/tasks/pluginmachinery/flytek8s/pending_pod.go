@@ -0,0 +1,150 @@
+package flytek8s
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// Matches the resources the scheduler reports missing in its FailedScheduling messages, e.g.
+// '0/3 nodes are available: 2 Insufficient cpu, 1 Insufficient nvidia.com/gpu.'
+var insufficientResourceRegex = regexp.MustCompile(`Insufficient ([\w./-]*\w)`)
+
+// Matches the taints the scheduler reports the pod does not tolerate, e.g.
+// '1 node(s) had taint {key: value}, that the pod didn't tolerate.' or '1 node(s) had untolerated taint {key: value}.'
+var untoleratedTaintRegex = regexp.MustCompile(`had (untolerated )?taints?`)
+
+// Matches the scheduler reporting that no node matches the node selector or affinity of the pod, e.g.
+// '1 node(s) didn't match node selector.' or '1 node(s) didn't match Pod's node affinity/selector.'
+var nodeSelectorMismatchRegex = regexp.MustCompile(`didn't match (node selector|Pod's node affinity)`)
+
+// DiagnoseSchedulingFailure summarizes why the scheduler could not place a pod from the message of its FailedScheduling
+// event or unschedulable condition. It returns an empty string if the message is not understood.
+func DiagnoseSchedulingFailure(message string) string {
+	var diagnostics []string
+
+	insufficient := map[string]bool{}
+	for _, match := range insufficientResourceRegex.FindAllStringSubmatch(message, -1) {
+		name := match[1]
+		if strings.Contains(name, "gpu") {
+			name = "gpu"
+		}
+		insufficient[name] = true
+	}
+	names := make([]string, 0, len(insufficient))
+	for name := range insufficient {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diagnostics = append(diagnostics, "insufficient "+name)
+	}
+
+	if untoleratedTaintRegex.MatchString(message) {
+		diagnostics = append(diagnostics, "untolerated taint")
+	}
+	if nodeSelectorMismatchRegex.MatchString(message) {
+		diagnostics = append(diagnostics, "node selector mismatch")
+	}
+	return strings.Join(diagnostics, ", ")
+}
+
+// GetPodResourceRequests returns the resources the scheduler reserves for a pod. Like the scheduler, this is the sum of
+// the requests of its containers or the largest request of its init containers, whichever is larger. Containers that
+// only set a limit request that much.
+func GetPodResourceRequests(spec *v1.PodSpec) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, quantity := range getContainerResourceRequests(c) {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, c := range spec.InitContainers {
+		for name, quantity := range getContainerResourceRequests(c) {
+			if total, found := requests[name]; !found || quantity.Cmp(total) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+func getContainerResourceRequests(c v1.Container) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for name, quantity := range c.Resources.Limits {
+		requests[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range c.Resources.Requests {
+		requests[name] = quantity.DeepCopy()
+	}
+	return requests
+}
+
+// FindUnfittableResources returns the resources a pod requests more of than any of the node shapes offers, or nil if
+// the pod fits one of them or no shape is configured.
+func FindUnfittableResources(spec *v1.PodSpec, shapes []config.NodeShape) []v1.ResourceName {
+	if len(shapes) == 0 {
+		return nil
+	}
+
+	requests := GetPodResourceRequests(spec)
+	exceeded := map[v1.ResourceName]bool{}
+	for _, shape := range shapes {
+		exceededInShape := exceededResources(requests, shape.Resources)
+		if len(exceededInShape) == 0 {
+			return nil
+		}
+		for _, name := range exceededInShape {
+			exceeded[name] = true
+		}
+	}
+
+	names := make([]v1.ResourceName, 0, len(exceeded))
+	for name := range exceeded {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// exceededResources returns the requested resources allocatable doesn't offer enough of. Extended resources, e.g. GPUs,
+// that allocatable doesn't list count as zero, native ones aren't checked.
+func exceededResources(requests, allocatable v1.ResourceList) []v1.ResourceName {
+	var exceeded []v1.ResourceName
+	for name, quantity := range requests {
+		available, found := allocatable[name]
+		if !found && !isExtendedResource(name) {
+			continue
+		}
+
+		if quantity.Cmp(available) > 0 {
+			exceeded = append(exceeded, name)
+		}
+	}
+	return exceeded
+}
+
+// isExtendedResource tells whether a resource is advertised by a device plugin or an operator rather than by the
+// kubelet, i.e. whether its name is prefixed with a domain other than kubernetes.io.
+func isExtendedResource(name v1.ResourceName) bool {
+	return strings.Contains(string(name), "/") && !strings.Contains(string(name), v1.ResourceDefaultNamespacePrefix) &&
+		!strings.HasPrefix(string(name), v1.DefaultResourceRequestsPrefix)
+}
+
+func formatResourceRequests(names []v1.ResourceName, requests v1.ResourceList) string {
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		quantity := requests[name]
+		formatted = append(formatted, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	return strings.Join(formatted, ", ")
+}
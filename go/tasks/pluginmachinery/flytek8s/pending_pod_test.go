@@ -0,0 +1,267 @@
+package flytek8s
+
+import (
+	"testing"
+	"time"
+
+	config1 "github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func TestDiagnoseSchedulingFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		diagnostics string
+	}{
+		{"unknown", "0/3 nodes are available: 3 node(s) were unschedulable.", ""},
+		{"insufficient resources", "0/3 nodes are available: 1 Insufficient memory, 2 Insufficient cpu.",
+			"insufficient cpu, insufficient memory"},
+		{"insufficient gpu", "0/2 nodes are available: 2 Insufficient nvidia.com/gpu.", "insufficient gpu"},
+		{"taint", "0/1 nodes are available: 1 node(s) had taint {dedicated: gpu}, that the pod didn't tolerate.",
+			"untolerated taint"},
+		{"untolerated taint", "0/1 nodes are available: 1 node(s) had untolerated taint {dedicated: gpu}.",
+			"untolerated taint"},
+		{"node selector", "0/4 nodes are available: 1 Insufficient cpu, 3 node(s) didn't match node selector.",
+			"insufficient cpu, node selector mismatch"},
+		{"node affinity", "0/4 nodes are available: 4 node(s) didn't match Pod's node affinity/selector.",
+			"node selector mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diagnostics, DiagnoseSchedulingFailure(tt.message))
+		})
+	}
+}
+
+func TestGetPodResourceRequests(t *testing.T) {
+	spec := &v1.PodSpec{
+		InitContainers: []v1.Container{
+			{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("4"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+		Containers: []v1.Container{
+			{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			{
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("500m"),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+						ResourceNvidiaGPU: resource.MustParse("1"),
+					},
+				},
+			},
+		},
+	}
+
+	requests := GetPodResourceRequests(spec)
+	cpu := requests[v1.ResourceCPU]
+	memory := requests[v1.ResourceMemory]
+	gpu := requests[ResourceNvidiaGPU]
+	assert.Equal(t, int64(4000), cpu.MilliValue())
+	assert.Equal(t, int64(3*1024*1024*1024), memory.Value())
+	assert.Equal(t, int64(1), gpu.Value())
+}
+
+func TestFindUnfittableResources(t *testing.T) {
+	shapes := []config.NodeShape{
+		{
+			Name: "cpu",
+			Resources: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("32"),
+				v1.ResourceMemory: resource.MustParse("128Gi"),
+			},
+		},
+		{
+			Name: "gpu",
+			Resources: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("8"),
+				v1.ResourceMemory: resource.MustParse("64Gi"),
+				ResourceNvidiaGPU: resource.MustParse("4"),
+			},
+		},
+	}
+
+	podSpec := func(requests v1.ResourceList) *v1.PodSpec {
+		return &v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: requests,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("no shapes", func(t *testing.T) {
+		assert.Nil(t, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("1000"),
+		}), nil))
+	})
+
+	t.Run("fits", func(t *testing.T) {
+		assert.Nil(t, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU:              resource.MustParse("16"),
+			v1.ResourceEphemeralStorage: resource.MustParse("1Ti"),
+		}), shapes))
+		assert.Nil(t, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("4"),
+			ResourceNvidiaGPU: resource.MustParse("2"),
+		}), shapes))
+	})
+
+	t.Run("does not fit", func(t *testing.T) {
+		assert.Equal(t, []v1.ResourceName{v1.ResourceCPU}, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("64"),
+		}), shapes))
+		assert.Equal(t, []v1.ResourceName{v1.ResourceCPU, ResourceNvidiaGPU}, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("16"),
+			ResourceNvidiaGPU: resource.MustParse("1"),
+		}), shapes))
+		assert.Equal(t, []v1.ResourceName{"example.com/fpga"}, FindUnfittableResources(podSpec(v1.ResourceList{
+			v1.ResourceCPU:     resource.MustParse("4"),
+			"example.com/fpga": resource.MustParse("1"),
+		}), shapes))
+	})
+}
+
+func TestIsExtendedResource(t *testing.T) {
+	assert.True(t, isExtendedResource(ResourceNvidiaGPU))
+	assert.True(t, isExtendedResource("example.com/fpga"))
+	assert.False(t, isExtendedResource(v1.ResourceCPU))
+	assert.False(t, isExtendedResource(v1.ResourceEphemeralStorage))
+	assert.False(t, isExtendedResource("kubernetes.io/batch-cpu"))
+	assert.False(t, isExtendedResource("requests.example.com/fpga"))
+}
+
+func TestDemystifyPendingPod(t *testing.T) {
+	previousConfig := config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(previousConfig)) }()
+	cfg := *previousConfig
+	cfg.PendingPod = config.PendingPodConfig{
+		ImagePullBackoffGracePeriod:           config1.Duration{Duration: time.Minute},
+		CreateContainerConfigErrorGracePeriod: config1.Duration{Duration: time.Minute},
+		NodeShapes: []config.NodeShape{
+			{
+				Name: "default",
+				Resources: v1.ResourceList{
+					v1.ResourceCPU: resource.MustParse("8"),
+				},
+			},
+		},
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	unschedulablePod := func(cpu string) *v1.Pod {
+		return &v1.Pod{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceCPU: resource.MustParse(cpu),
+							},
+						},
+					},
+				},
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{
+					{
+						Type:    v1.PodScheduled,
+						Status:  v1.ConditionFalse,
+						Reason:  v1.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 Insufficient cpu.",
+					},
+				},
+			},
+		}
+	}
+
+	waitingPod := func(reason string, lastTransitionTime metaV1.Time) *v1.Pod {
+		return &v1.Pod{
+			Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{
+					{
+						Type:               v1.PodReady,
+						Status:             v1.ConditionFalse,
+						LastTransitionTime: lastTransitionTime,
+					},
+				},
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						State: v1.ContainerState{
+							Waiting: &v1.ContainerStateWaiting{
+								Reason: reason,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("unschedulable", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(unschedulablePod("4"))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
+		assert.Equal(t, "Unschedulable: insufficient cpu", phaseInfo.Reason())
+	})
+
+	t.Run("never fits", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(unschedulablePod("16"))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "BadTaskSpecification", phaseInfo.Err().Code)
+		assert.Equal(t, "Pod requests [cpu=16] which no node of the cluster can fit", phaseInfo.Err().Message)
+	})
+
+	t.Run("image pull backoff within the grace period", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(waitingPod("ImagePullBackOff", metaV1.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, phaseInfo.Phase())
+	})
+
+	t.Run("image pull backoff after the grace period", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(waitingPod("ImagePullBackOff", metaV1.NewTime(time.Now().Add(-2*time.Minute))))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "ImagePullError", phaseInfo.Err().Code)
+	})
+
+	t.Run("create container config error within the grace period", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(waitingPod("CreateContainerConfigError", metaV1.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, phaseInfo.Phase())
+	})
+
+	t.Run("create container config error after the grace period", func(t *testing.T) {
+		phaseInfo, err := DemystifyPendingPod(waitingPod("CreateContainerConfigError", metaV1.NewTime(time.Now().Add(-2*time.Minute))))
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, "ContainerConfigError", phaseInfo.Err().Code)
+	})
+}
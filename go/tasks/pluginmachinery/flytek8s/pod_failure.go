@@ -0,0 +1,243 @@
+package flytek8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// PodFailureKind is a stable classification of why a pod or one of its containers failed. It is reported as the code of
+// the execution error so that failures can be grouped regardless of the reasons and messages kubernetes reports.
+type PodFailureKind string
+
+const (
+	// The pod failed for a reason that could not be classified.
+	PodFailureKindUnknown PodFailureKind = "UnknownError"
+	// A container exited with a non-zero exit code.
+	PodFailureKindUserError PodFailureKind = "UserError"
+	// A container was killed for exceeding its memory limit.
+	PodFailureKindOOMKilled PodFailureKind = OOMKilled
+	// A container was killed with SIGKILL, which in some setups is what node termination sends to all the containers.
+	PodFailureKindInterrupted PodFailureKind = Interrupted
+	// The kubelet evicted the pod, e.g. because the node ran low on resources.
+	PodFailureKindEvicted PodFailureKind = "Evicted"
+	// The node the pod ran on stopped responding.
+	PodFailureKindNodeLost PodFailureKind = "NodeLost"
+	// The scheduler evicted the pod to make room for pods with a higher priority, or the interruptible node the pod ran
+	// on was reclaimed.
+	PodFailureKindPreempted PodFailureKind = "Preempted"
+	// The pod ran longer than its active deadline.
+	PodFailureKindDeadlineExceeded PodFailureKind = "DeadlineExceeded"
+	// The image of a container could not be pulled.
+	PodFailureKindImagePullError PodFailureKind = "ImagePullError"
+	// A container could not be created from its spec, e.g. because it references a missing config map or secret.
+	PodFailureKindContainerConfigError PodFailureKind = "ContainerConfigError"
+	// A container is stuck waiting to start for a reason that could not be classified.
+	PodFailureKindContainerStartError PodFailureKind = "ContainerStartError"
+)
+
+// InterruptiblePreemptedCustomInfoKey is the key of the flag set in the custom info of interruptible tasks whose node was
+// reclaimed, so that their retries can opt out of interruptible nodes.
+const InterruptiblePreemptedCustomInfoKey = "interruptiblePreempted"
+
+// The condition kubernetes adds to pods that are about to be terminated because of a disruption, e.g. the node being
+// drained or shut down.
+const podConditionDisruptionTarget v1.PodConditionType = "DisruptionTarget"
+
+type podFailureKindTraits struct {
+	system                   bool
+	retryable                bool
+	countsAgainstUserRetries bool
+}
+
+var podFailureKinds = map[PodFailureKind]podFailureKindTraits{
+	PodFailureKindUnknown:              {system: false, retryable: true, countsAgainstUserRetries: true},
+	PodFailureKindUserError:            {system: false, retryable: true, countsAgainstUserRetries: true},
+	PodFailureKindOOMKilled:            {system: false, retryable: true, countsAgainstUserRetries: true},
+	PodFailureKindInterrupted:          {system: true, retryable: true, countsAgainstUserRetries: false},
+	PodFailureKindEvicted:              {system: true, retryable: true, countsAgainstUserRetries: false},
+	PodFailureKindNodeLost:             {system: true, retryable: true, countsAgainstUserRetries: false},
+	PodFailureKindPreempted:            {system: true, retryable: true, countsAgainstUserRetries: false},
+	PodFailureKindDeadlineExceeded:     {system: false, retryable: true, countsAgainstUserRetries: true},
+	PodFailureKindImagePullError:       {system: false, retryable: true, countsAgainstUserRetries: true},
+	PodFailureKindContainerConfigError: {system: false, retryable: false, countsAgainstUserRetries: true},
+	PodFailureKindContainerStartError:  {system: true, retryable: true, countsAgainstUserRetries: false},
+}
+
+func (k PodFailureKind) traits() podFailureKindTraits {
+	if t, ok := podFailureKinds[k]; ok {
+		return t
+	}
+	return podFailureKinds[PodFailureKindUnknown]
+}
+
+// IsSystem returns true if the failure is caused by the platform rather than by the task.
+func (k PodFailureKind) IsSystem() bool {
+	return k.traits().system
+}
+
+// IsRetryable returns true if running the task again may succeed.
+func (k PodFailureKind) IsRetryable() bool {
+	return k.traits().retryable
+}
+
+// CountsAgainstUserRetries returns true if retrying the failure consumes the retries the user configured for the task.
+func (k PodFailureKind) CountsAgainstUserRetries() bool {
+	return k.traits().countsAgainstUserRetries
+}
+
+// ErrorKind returns the kind of the execution error the failure is reported with. System retries are budgeted separately
+// from user retries, so failures that do not count against user retries are reported as system errors.
+func (k PodFailureKind) ErrorKind() core.ExecutionError_ErrorKind {
+	if k.IsSystem() || !k.CountsAgainstUserRetries() {
+		return core.ExecutionError_SYSTEM
+	}
+	return core.ExecutionError_USER
+}
+
+// PhaseInfoPodFailure returns the phase of a task that failed with the given kind of failure. The kind is reported as the
+// code of the execution error.
+func PhaseInfoPodFailure(kind PodFailureKind, message string, info *pluginsCore.TaskInfo) pluginsCore.PhaseInfo {
+	phase := pluginsCore.PhasePermanentFailure
+	if kind.IsRetryable() {
+		phase = pluginsCore.PhaseRetryableFailure
+	}
+	return pluginsCore.PhaseInfoFailed(phase, &core.ExecutionError{
+		Code:    string(kind),
+		Message: message,
+		Kind:    kind.ErrorKind(),
+	}, info)
+}
+
+// ClassifyPodReason classifies the reason kubernetes reports in the status of a failed pod.
+func ClassifyPodReason(reason string) (PodFailureKind, bool) {
+	switch reason {
+	case "Evicted":
+		return PodFailureKindEvicted, true
+	case "NodeLost":
+		return PodFailureKindNodeLost, true
+	case "Preempting", "Preempted":
+		return PodFailureKindPreempted, true
+	case "DeadlineExceeded":
+		return PodFailureKindDeadlineExceeded, true
+	}
+	return PodFailureKindUnknown, false
+}
+
+// ClassifyTerminatedContainer classifies the termination of a container. It returns false if the container exited
+// successfully.
+func ClassifyTerminatedContainer(state *v1.ContainerStateTerminated) (PodFailureKind, bool) {
+	if state == nil {
+		return PodFailureKindUnknown, false
+	}
+	if strings.Contains(state.Reason, OOMKilled) {
+		return PodFailureKindOOMKilled, true
+	}
+	switch state.ExitCode {
+	case 0:
+		return PodFailureKindUnknown, false
+	case SIGKILL:
+		return PodFailureKindInterrupted, true
+	}
+	return PodFailureKindUserError, true
+}
+
+// ClassifyWaitingContainer classifies the reason a container is stuck waiting to start. It returns false if the container
+// is still legitimately being created.
+func ClassifyWaitingContainer(reason string) (PodFailureKind, bool) {
+	switch reason {
+	case "ErrImagePull", "ContainerCreating", "PodInitializing":
+		return PodFailureKindUnknown, false
+	case "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull":
+		return PodFailureKindImagePullError, true
+	case "CreateContainerConfigError", "CreateContainerError":
+		return PodFailureKindContainerConfigError, true
+	}
+	return PodFailureKindContainerStartError, true
+}
+
+// Higher values take precedence when several containers of a pod failed.
+var containerFailurePrecedence = map[PodFailureKind]int{
+	PodFailureKindUserError:   1,
+	PodFailureKindInterrupted: 2,
+	PodFailureKindOOMKilled:   3,
+}
+
+// ClassifyPodFailure classifies a failed pod and builds a message that describes the termination of each of its
+// containers. The reason the pod reports takes precedence over the terminations of the containers.
+func ClassifyPodFailure(status v1.PodStatus) (PodFailureKind, string) {
+	kind, podKindFound := ClassifyPodReason(status.Reason)
+	message := "Pod failed. No message received from kubernetes."
+	if len(status.Message) > 0 {
+		message = status.Message
+	}
+	if len(status.Reason) > 0 && !podKindFound {
+		message = fmt.Sprintf("[%s]: %s", status.Reason, message)
+	}
+
+	for _, c := range append(
+		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
+		var containerState v1.ContainerState
+		if c.LastTerminationState.Terminated != nil {
+			containerState = c.LastTerminationState
+		} else if c.State.Terminated != nil {
+			containerState = c.State
+		}
+		if containerState.Terminated == nil {
+			continue
+		}
+
+		if containerKind, failed := ClassifyTerminatedContainer(containerState.Terminated); failed && !podKindFound &&
+			containerFailurePrecedence[containerKind] > containerFailurePrecedence[kind] {
+			kind = containerKind
+		}
+
+		if containerState.Terminated.ExitCode == 0 {
+			message += fmt.Sprintf("\r\n[%v] terminated with ExitCode 0.", c.Name)
+		} else {
+			message += fmt.Sprintf("\r\n[%v] terminated with exit code (%v). Reason [%v]. Message: \n%v.",
+				c.Name,
+				containerState.Terminated.ExitCode,
+				containerState.Terminated.Reason,
+				containerState.Terminated.Message)
+		}
+	}
+	return kind, message
+}
+
+// IsPodPreempted returns true if the pod failed because the node it ran on was reclaimed, e.g. when a spot or
+// preemptible node is taken back by the cloud provider.
+func IsPodPreempted(status v1.PodStatus) bool {
+	switch status.Reason {
+	case "Shutdown", "NodeShutdown", "NodeLost", "Terminated":
+		return true
+	}
+	for _, c := range status.Conditions {
+		if c.Type == podConditionDisruptionTarget && c.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// DemystifyFailure returns the phase of a task whose pod failed. The failures of interruptible tasks whose node was
+// reclaimed are system retryable and flagged in the custom info of the task.
+func DemystifyFailure(status v1.PodStatus, info pluginsCore.TaskInfo, isInterruptible bool) (pluginsCore.PhaseInfo, error) {
+	kind, message := ClassifyPodFailure(status)
+	if isInterruptible && IsPodPreempted(status) {
+		kind = PodFailureKindPreempted
+		if info.CustomInfo == nil {
+			info.CustomInfo = &structpb.Struct{}
+		}
+		if info.CustomInfo.Fields == nil {
+			info.CustomInfo.Fields = map[string]*structpb.Value{}
+		}
+		info.CustomInfo.Fields[InterruptiblePreemptedCustomInfoKey] = &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: true}}
+	}
+	return PhaseInfoPodFailure(kind, message, &info), nil
+}
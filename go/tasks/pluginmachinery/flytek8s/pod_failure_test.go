@@ -0,0 +1,223 @@
+package flytek8s
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+func TestPodFailureKind(t *testing.T) {
+	t.Run("user error", func(t *testing.T) {
+		assert.False(t, PodFailureKindUserError.IsSystem())
+		assert.True(t, PodFailureKindUserError.IsRetryable())
+		assert.True(t, PodFailureKindUserError.CountsAgainstUserRetries())
+		assert.Equal(t, core.ExecutionError_USER, PodFailureKindUserError.ErrorKind())
+	})
+
+	t.Run("system error", func(t *testing.T) {
+		assert.True(t, PodFailureKindEvicted.IsSystem())
+		assert.True(t, PodFailureKindEvicted.IsRetryable())
+		assert.False(t, PodFailureKindEvicted.CountsAgainstUserRetries())
+		assert.Equal(t, core.ExecutionError_SYSTEM, PodFailureKindEvicted.ErrorKind())
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		assert.False(t, PodFailureKindContainerConfigError.IsRetryable())
+	})
+
+	t.Run("unregistered kind", func(t *testing.T) {
+		kind := PodFailureKind("blah")
+		assert.Equal(t, PodFailureKindUnknown.IsSystem(), kind.IsSystem())
+		assert.Equal(t, PodFailureKindUnknown.IsRetryable(), kind.IsRetryable())
+	})
+}
+
+func TestPhaseInfoPodFailure(t *testing.T) {
+	t.Run("retryable", func(t *testing.T) {
+		phaseInfo := PhaseInfoPodFailure(PodFailureKindNodeLost, "node lost", &pluginsCore.TaskInfo{})
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "NodeLost", phaseInfo.Err().Code)
+		assert.Equal(t, "node lost", phaseInfo.Err().Message)
+		assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.Err().Kind)
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		phaseInfo := PhaseInfoPodFailure(PodFailureKindContainerConfigError, "missing secret", &pluginsCore.TaskInfo{})
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, core.ExecutionError_USER, phaseInfo.Err().Kind)
+	})
+}
+
+func TestClassifyWaitingContainer(t *testing.T) {
+	tests := []struct {
+		reason string
+		kind   PodFailureKind
+		failed bool
+	}{
+		{"ContainerCreating", PodFailureKindUnknown, false},
+		{"ErrImagePull", PodFailureKindUnknown, false},
+		{"ImagePullBackOff", PodFailureKindImagePullError, true},
+		{"InvalidImageName", PodFailureKindImagePullError, true},
+		{"CreateContainerConfigError", PodFailureKindContainerConfigError, true},
+		{"RunContainerError", PodFailureKindContainerStartError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			kind, failed := ClassifyWaitingContainer(tt.reason)
+			assert.Equal(t, tt.failed, failed)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+func TestClassifyPodFailure(t *testing.T) {
+	terminated := func(name string, exitCode int32, reason string) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			Name: name,
+			State: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					ExitCode: exitCode,
+					Reason:   reason,
+				},
+			},
+		}
+	}
+
+	t.Run("non-zero exit", func(t *testing.T) {
+		kind, message := ClassifyPodFailure(v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{terminated("primary", 1, "Error")},
+		})
+		assert.Equal(t, PodFailureKindUserError, kind)
+		assert.Contains(t, message, "[primary] terminated with exit code (1). Reason [Error].")
+	})
+
+	t.Run("sigkill", func(t *testing.T) {
+		kind, _ := ClassifyPodFailure(v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{terminated("primary", SIGKILL, "Error")},
+		})
+		assert.Equal(t, PodFailureKindInterrupted, kind)
+	})
+
+	t.Run("oom takes precedence over other containers", func(t *testing.T) {
+		kind, _ := ClassifyPodFailure(v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{terminated("init", 0, "Completed")},
+			ContainerStatuses: []v1.ContainerStatus{
+				terminated("primary", SIGKILL, OOMKilled),
+				terminated("sidecar", 1, "Error"),
+			},
+		})
+		assert.Equal(t, PodFailureKindOOMKilled, kind)
+	})
+
+	t.Run("last termination state", func(t *testing.T) {
+		kind, _ := ClassifyPodFailure(v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "primary",
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 137,
+							Reason:   OOMKilled,
+						},
+					},
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{},
+					},
+				},
+			},
+		})
+		assert.Equal(t, PodFailureKindOOMKilled, kind)
+	})
+
+	t.Run("pod reason takes precedence over containers", func(t *testing.T) {
+		kind, message := ClassifyPodFailure(v1.PodStatus{
+			Reason:            "Evicted",
+			Message:           "The node was low on resource: memory.",
+			ContainerStatuses: []v1.ContainerStatus{terminated("primary", SIGKILL, "Error")},
+		})
+		assert.Equal(t, PodFailureKindEvicted, kind)
+		assert.True(t, kind.IsSystem())
+		assert.Contains(t, message, "The node was low on resource: memory.")
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		kind, _ := ClassifyPodFailure(v1.PodStatus{Reason: "DeadlineExceeded"})
+		assert.Equal(t, PodFailureKindDeadlineExceeded, kind)
+	})
+}
+
+func TestIsPodPreempted(t *testing.T) {
+	assert.False(t, IsPodPreempted(v1.PodStatus{}))
+	assert.False(t, IsPodPreempted(v1.PodStatus{Reason: "Evicted"}))
+	assert.True(t, IsPodPreempted(v1.PodStatus{Reason: "Shutdown"}))
+	assert.True(t, IsPodPreempted(v1.PodStatus{Reason: "NodeLost"}))
+	assert.True(t, IsPodPreempted(v1.PodStatus{
+		Conditions: []v1.PodCondition{
+			{
+				Type:   "DisruptionTarget",
+				Status: v1.ConditionTrue,
+				Reason: "DeletionByTaintManager",
+			},
+		},
+	}))
+	assert.False(t, IsPodPreempted(v1.PodStatus{
+		Conditions: []v1.PodCondition{
+			{
+				Type:   "DisruptionTarget",
+				Status: v1.ConditionFalse,
+			},
+		},
+	}))
+}
+
+func TestDemystifyFailure(t *testing.T) {
+	t.Run("classified", func(t *testing.T) {
+		phaseInfo, err := DemystifyFailure(v1.PodStatus{Reason: "NodeLost"}, pluginsCore.TaskInfo{}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "NodeLost", phaseInfo.Err().Code)
+		assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.Err().Kind)
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+
+	t.Run("shutdown of a non interruptible node", func(t *testing.T) {
+		phaseInfo, err := DemystifyFailure(v1.PodStatus{Reason: "Shutdown"}, pluginsCore.TaskInfo{}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "UnknownError", phaseInfo.Err().Code)
+		assert.Equal(t, core.ExecutionError_USER, phaseInfo.Err().Kind)
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+
+	t.Run("interruptible node preempted", func(t *testing.T) {
+		info := pluginsCore.TaskInfo{
+			CustomInfo: &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					"foo": {Kind: &structpb.Value_StringValue{StringValue: "bar"}},
+				},
+			},
+		}
+		phaseInfo, err := DemystifyFailure(v1.PodStatus{
+			Reason: "Shutdown",
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "primary",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: 1},
+					},
+				},
+			},
+		}, info, true)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, "Preempted", phaseInfo.Err().Code)
+		assert.Equal(t, core.ExecutionError_SYSTEM, phaseInfo.Err().Kind)
+		assert.True(t, phaseInfo.Info().CustomInfo.Fields[InterruptiblePreemptedCustomInfoKey].GetBoolValue())
+		assert.Equal(t, "bar", phaseInfo.Info().CustomInfo.Fields["foo"].GetStringValue())
+	})
+}
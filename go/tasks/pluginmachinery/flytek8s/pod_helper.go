@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -72,7 +73,11 @@ func ToK8sPodSpec(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (*
 	pod := &v1.PodSpec{
 		Containers: containers,
 	}
-	UpdatePod(tCtx.TaskExecutionMetadata(), []v1.ResourceRequirements{c.Resources}, pod)
+	// The accelerator profile may rename the GPUs the container requests, which the tolerations are derived from.
+	if err := ApplyAcceleratorProfile(task, tCtx.TaskExecutionMetadata(), pod); err != nil {
+		return nil, err
+	}
+	UpdatePod(tCtx.TaskExecutionMetadata(), []v1.ResourceRequirements{pod.Containers[0].Resources}, pod)
 
 	if err := AddCoPilotToPod(ctx, config.GetK8sPluginConfig().CoPilot, pod, task.GetInterface(), tCtx.TaskExecutionMetadata(), tCtx.InputReader(), tCtx.OutputWriter(), task.GetContainer().GetDataConfig()); err != nil {
 		return nil, err
@@ -112,14 +117,40 @@ func BuildIdentityPod() *v1.Pod {
 //          and hence input gates. We should not allow bad requests that request for large number of resource through.
 //          In the case it makes through, we will fail after timeout
 func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
+	return demystifyPending(nil, status)
+}
+
+// DemystifyPendingPod is like DemystifyPending, but also fails pods whose requests do not fit any of the configured node
+// shapes right away, since they can never be scheduled.
+func DemystifyPendingPod(pod *v1.Pod) (pluginsCore.PhaseInfo, error) {
+	return demystifyPending(&pod.Spec, pod.Status)
+}
+
+func demystifyPending(spec *v1.PodSpec, status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
+	pendingPodConfig := config.GetK8sPluginConfig().PendingPod
+
 	// Search over the difference conditions in the status object.  Note that the 'Pending' this function is
 	// demystifying is the 'phase' of the pod status. This is different than the PodReady condition type also used below
 	for _, c := range status.Conditions {
 		switch c.Type {
 		case v1.PodScheduled:
 			if c.Status == v1.ConditionFalse {
+				if spec != nil {
+					if exceeded := FindUnfittableResources(spec, pendingPodConfig.NodeShapes); len(exceeded) > 0 {
+						t := c.LastTransitionTime.Time
+						return pluginsCore.PhaseInfoFailure(string(errors.BadTaskSpecification),
+							fmt.Sprintf("Pod requests [%s] which no node of the cluster can fit",
+								formatResourceRequests(exceeded, GetPodResourceRequests(spec))),
+							&pluginsCore.TaskInfo{OccurredAt: &t}), nil
+					}
+				}
+
 				// Waiting to be scheduled. This usually refers to inability to acquire resources.
-				return pluginsCore.PhaseInfoQueued(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("%s:%s", c.Reason, c.Message)), nil
+				reason := fmt.Sprintf("%s:%s", c.Reason, c.Message)
+				if diagnostics := DiagnoseSchedulingFailure(c.Message); len(diagnostics) > 0 {
+					reason = fmt.Sprintf("%s: %s", c.Reason, diagnostics)
+				}
+				return pluginsCore.PhaseInfoQueued(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, reason), nil
 			}
 
 		case v1.PodReasonUnschedulable:
@@ -166,8 +197,8 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 							reason := containerStatus.State.Waiting.Reason
 							finalReason := fmt.Sprintf("%s|%s", c.Reason, reason)
 							finalMessage := fmt.Sprintf("%s|%s", c.Message, containerStatus.State.Waiting.Message)
-							switch reason {
-							case "ErrImagePull", "ContainerCreating", "PodInitializing":
+							kind, failed := ClassifyWaitingContainer(reason)
+							if !failed {
 								// But, there are only two "reasons" when a pod is successfully being created and hence it is in
 								// waiting state
 								// Refer to https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/kubelet_pods.go
@@ -178,31 +209,32 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 								// ContainerCreating -> Image is being downloaded
 								// PodInitializing -> Init containers are running
 								return pluginsCore.PhaseInfoInitializing(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("[%s]: %s", finalReason, finalMessage), &pluginsCore.TaskInfo{OccurredAt: &c.LastTransitionTime.Time}), nil
+							}
 
-							case "CreateContainerConfigError", "CreateContainerError":
-								// This happens if for instance the command to the container is incorrect, ie doesn't run
-								t := c.LastTransitionTime.Time
-								return pluginsCore.PhaseInfoFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
-									OccurredAt: &t,
-								}), nil
-
+							// Image pulls and the config maps or secrets containers reference may be fixed in the meantime, so
+							// these are given some time before failing the task.
+							gracePeriod := time.Duration(0)
+							switch reason {
 							case "ImagePullBackOff":
-								t := c.LastTransitionTime.Time
-								return pluginsCore.PhaseInfoRetryableFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
-									OccurredAt: &t,
-								}), nil
-							default:
-								// Since we are not checking for all error states, we may end up perpetually
-								// in the queued state returned at the bottom of this function, until the Pod is reaped
-								// by K8s and we get elusive 'pod not found' errors
-								// So be default if the container is not waiting with the PodInitializing/ContainerCreating
-								// reasons, then we will assume a failure reason, and fail instantly
-								t := c.LastTransitionTime.Time
-								return pluginsCore.PhaseInfoSystemRetryableFailure(finalReason, finalMessage, &pluginsCore.TaskInfo{
-									OccurredAt: &t,
-								}), nil
+								gracePeriod = pendingPodConfig.ImagePullBackoffGracePeriod.Duration
+							case "CreateContainerConfigError":
+								gracePeriod = pendingPodConfig.CreateContainerConfigErrorGracePeriod.Duration
+							}
+							if time.Since(c.LastTransitionTime.Time) < gracePeriod {
+								return pluginsCore.PhaseInfoInitializing(c.LastTransitionTime.Time, pluginsCore.DefaultPhaseVersion, fmt.Sprintf("[%s]: %s", finalReason, finalMessage), &pluginsCore.TaskInfo{OccurredAt: &c.LastTransitionTime.Time}), nil
 							}
 
+							// The kind of the failure decides whether it is retried, e.g. CreateContainerConfigError happens
+							// if for instance the command to the container is incorrect, ie doesn't run, and is not retried.
+							// Since we are not checking for all error states, we may end up perpetually
+							// in the queued state returned at the bottom of this function, until the Pod is reaped
+							// by K8s and we get elusive 'pod not found' errors
+							// So be default if the container is not waiting with the PodInitializing/ContainerCreating
+							// reasons, then we will assume a failure reason, and fail instantly
+							t := c.LastTransitionTime.Time
+							return PhaseInfoPodFailure(kind, fmt.Sprintf("[%s]: %s", finalReason, finalMessage), &pluginsCore.TaskInfo{
+								OccurredAt: &t,
+							}), nil
 						}
 					}
 				}
@@ -217,7 +249,7 @@ func DemystifySuccess(status v1.PodStatus, info pluginsCore.TaskInfo) (pluginsCo
 	for _, status := range append(
 		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
 		if status.State.Terminated != nil && strings.Contains(status.State.Terminated.Reason, OOMKilled) {
-			return pluginsCore.PhaseInfoRetryableFailure("OOMKilled",
+			return PhaseInfoPodFailure(PodFailureKindOOMKilled,
 				"Pod reported success despite being OOMKilled", &info), nil
 		}
 	}
@@ -232,9 +264,12 @@ func DeterminePrimaryContainerPhase(primaryContainerName string, statuses []v1.C
 			}
 
 			if s.State.Terminated != nil {
-				if s.State.Terminated.ExitCode != 0 {
-					return pluginsCore.PhaseInfoRetryableFailure(
-						s.State.Terminated.Reason, s.State.Terminated.Message, info)
+				if kind, failed := ClassifyTerminatedContainer(s.State.Terminated); failed {
+					message := s.State.Terminated.Message
+					if len(s.State.Terminated.Reason) > 0 {
+						message = fmt.Sprintf("[%s]: %s", s.State.Terminated.Reason, message)
+					}
+					return PhaseInfoPodFailure(kind, message, info)
 				}
 				return pluginsCore.PhaseInfoSuccess(info)
 			}
@@ -246,46 +281,10 @@ func DeterminePrimaryContainerPhase(primaryContainerName string, statuses []v1.C
 		fmt.Sprintf("Primary container [%s] not found in pod's container statuses", primaryContainerName), info)
 }
 
+// ConvertPodFailureToError returns the code and message of the failure of a pod. The code is the kind of the failure.
 func ConvertPodFailureToError(status v1.PodStatus) (code, message string) {
-	code = "UnknownError"
-	message = "Pod failed. No message received from kubernetes."
-	if len(status.Reason) > 0 {
-		code = status.Reason
-	}
-
-	if len(status.Message) > 0 {
-		message = status.Message
-	}
-
-	for _, c := range append(
-		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
-		var containerState v1.ContainerState
-		if c.LastTerminationState.Terminated != nil {
-			containerState = c.LastTerminationState
-		} else if c.State.Terminated != nil {
-			containerState = c.State
-		}
-		if containerState.Terminated != nil {
-			if strings.Contains(c.State.Terminated.Reason, OOMKilled) {
-				code = OOMKilled
-			} else if containerState.Terminated.ExitCode == SIGKILL {
-				// in some setups, node termination sends SIGKILL to all the containers running on that node. Capturing and
-				// tagging that correctly.
-				code = Interrupted
-			}
-
-			if containerState.Terminated.ExitCode == 0 {
-				message += fmt.Sprintf("\r\n[%v] terminated with ExitCode 0.", c.Name)
-			} else {
-				message += fmt.Sprintf("\r\n[%v] terminated with exit code (%v). Reason [%v]. Message: \n%v.",
-					c.Name,
-					containerState.Terminated.ExitCode,
-					containerState.Terminated.Reason,
-					containerState.Terminated.Message)
-			}
-		}
-	}
-	return code, message
+	kind, message := ClassifyPodFailure(status)
+	return string(kind), message
 }
 
 func GetLastTransitionOccurredAt(pod *v1.Pod) v12.Time {
@@ -240,6 +240,56 @@ func TestToK8sPod(t *testing.T) {
 		assert.Equal(t, "some-acceptable-name", p.Containers[0].Name)
 	})
 
+	t.Run("WithAcceleratorProfile", func(t *testing.T) {
+		tolMIG := v1.Toleration{
+			Key:      "flyte/mig",
+			Value:    "dedicated",
+			Operator: v1.TolerationOpEqual,
+			Effect:   v1.TaintEffectNoSchedule,
+		}
+
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+			ResourceTolerations: map[v1.ResourceName][]v1.Toleration{
+				ResourceNvidiaGPU:       {tolGPU},
+				"nvidia.com/mig-1g.5gb": {tolMIG},
+			},
+			AcceleratorProfiles: map[string]config.AcceleratorProfile{
+				"a100-mig-1g": {ResourceName: "nvidia.com/mig-1g.5gb"},
+			},
+		}))
+
+		taskReader := &pluginsCoreMock.TaskReader{}
+		taskReader.On("Read", mock.Anything).Return(&core.TaskTemplate{
+			Type: "test",
+			Target: &core.TaskTemplate_Container{
+				Container: &core.Container{
+					Command: []string{"command"},
+				},
+			},
+			Config: map[string]string{AcceleratorConfigKey: "a100-mig-1g"},
+		}, nil)
+
+		x := &pluginsCoreMock.TaskExecutionContext{}
+		x.OnTaskExecutionMetadata().Return(dummyTaskExecutionMetadata(&v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1024m"),
+				ResourceNvidiaGPU: resource.MustParse("1"),
+			},
+			Requests: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("1024m"),
+			},
+		}))
+		x.OnInputReader().Return(dummyInputReader())
+		x.OnTaskReader().Return(taskReader)
+		x.OnOutputWriter().Return(dummyExecContext(nil).OutputWriter())
+
+		// The tolerations follow the GPU resource the profile renamed.
+		p, err := ToK8sPodSpec(ctx, x)
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.Toleration{tolMIG}, p.Tolerations)
+		assert.Contains(t, p.Containers[0].Resources.Limits, v1.ResourceName("nvidia.com/mig-1g.5gb"))
+	})
+
 	t.Run("Default toleration, selector, scheduler", func(t *testing.T) {
 		x := dummyExecContext(&v1.ResourceRequirements{
 			Limits: v1.ResourceList{
@@ -557,8 +607,9 @@ func TestConvertPodFailureToError(t *testing.T) {
 	})
 
 	t.Run("known-error", func(t *testing.T) {
-		code, _ := ConvertPodFailureToError(v1.PodStatus{Reason: "hello"})
-		assert.Equal(t, code, "hello")
+		code, message := ConvertPodFailureToError(v1.PodStatus{Reason: "hello"})
+		assert.Equal(t, code, "UnknownError")
+		assert.Equal(t, message, "[hello]: Pod failed. No message received from kubernetes.")
 	})
 
 	t.Run("OOMKilled", func(t *testing.T) {
@@ -587,7 +638,7 @@ func TestDemystifyPending_testcases(t *testing.T) {
 		errCode  string
 		message  string
 	}{
-		{"ImagePullBackOff", "imagepull-failurepod.json", false, "ContainersNotReady|ImagePullBackOff", "containers with unready status: [fdf98e4ed2b524dc3bf7-get-flyte-id-task-0]|Back-off pulling image \"image\""},
+		{"ImagePullBackOff", "imagepull-failurepod.json", false, "ImagePullError", "[ContainersNotReady|ImagePullBackOff]: containers with unready status: [fdf98e4ed2b524dc3bf7-get-flyte-id-task-0]|Back-off pulling image \"image\""},
 	}
 
 	for _, tt := range tests {
@@ -665,8 +716,9 @@ func TestDeterminePrimaryContainerPhase(t *testing.T) {
 			},
 		}, info)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
-		assert.Equal(t, "foo", phaseInfo.Err().Code)
-		assert.Equal(t, "foo failed", phaseInfo.Err().Message)
+		assert.Equal(t, "UserError", phaseInfo.Err().Code)
+		assert.Equal(t, "[foo]: foo failed", phaseInfo.Err().Message)
+		assert.Equal(t, core.ExecutionError_USER, phaseInfo.Err().Kind)
 	})
 	t.Run("primary container succeeded", func(t *testing.T) {
 		phaseInfo := DeterminePrimaryContainerPhase(primaryContainerName, []v1.ContainerStatus{
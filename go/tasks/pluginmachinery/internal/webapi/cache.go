@@ -2,6 +2,7 @@ package webapi
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/promutils"
 	"k8s.io/client-go/util/workqueue"
@@ -39,8 +40,9 @@ type Client interface {
 type ResourceCache struct {
 	// AutoRefresh
 	cache.AutoRefresh
-	client Client
-	cfg    webapi.CachingConfig
+	client  Client
+	tracker *completionTracker
+	cfg     webapi.CachingConfig
 }
 
 // A wrapper for each item in the cache.
@@ -56,6 +58,8 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 	updatedBatch []cache.ItemSyncResponse, err error) {
 
 	resp := make([]cache.ItemSyncResponse, 0, len(batch))
+	toSync := make([]cache.ItemWrapper, 0, len(batch))
+	cacheItems := make([]CacheItem, 0, len(batch))
 	for _, resource := range batch {
 		// Cast the item back to the thing we want to work with.
 		cacheItem, ok := resource.GetItem().(CacheItem)
@@ -97,10 +101,36 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 			continue
 		}
 
-		// Get an updated status
-		logger.Debugf(ctx, "Querying AsyncPlugin for %s", resource.GetID())
-		newResource, err := q.client.Get(ctx, newPluginContext(cacheItem.ResourceMeta, cacheItem.Resource, "", nil))
-		if err != nil {
+		// Resources pushed by the remote service don't need to be retrieved.
+		if pushed, found := q.tracker.take(resource.GetID()); found {
+			cacheItem.Resource = pushed
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     resource.GetID(),
+				Item:   cacheItem,
+				Action: cache.Update,
+			})
+
+			continue
+		}
+
+		toSync = append(toSync, resource)
+		cacheItems = append(cacheItems, cacheItem)
+	}
+
+	latest, errs := q.getLatest(ctx, cacheItems)
+	for i, resource := range toSync {
+		cacheItem := cacheItems[i]
+		if err := errs[i]; IsThrottled(err) {
+			// Throttling isn't a failure of the resource, it'll be picked up again in the next sync.
+			logger.Debugf(ctx, "Sync loop - delaying the sync of resource [%s]. Error: %v", resource.GetID(), err)
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     resource.GetID(),
+				Item:   resource.GetItem(),
+				Action: cache.Unchanged,
+			})
+
+			continue
+		} else if err != nil {
 			logger.Errorf(ctx, "Error retrieving resource [%s]. Error: %v", resource.GetID(), err)
 			cacheItem.SyncFailureCount++
 
@@ -114,7 +144,7 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 			continue
 		}
 
-		cacheItem.Resource = newResource
+		cacheItem.Resource = latest[i]
 
 		resp = append(resp, cache.ItemSyncResponse{
 			ID:     resource.GetID(),
@@ -126,6 +156,50 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 	return resp, nil
 }
 
+// Retrieves the latest version of the resources. If the client implements webapi.BatchGetter, they are retrieved in a
+// single call and only the ones missing from the response are retrieved individually.
+func (q *ResourceCache) getLatest(ctx context.Context, cacheItems []CacheItem) (latest []webapi.Resource, errs []error) {
+	latest = make([]webapi.Resource, len(cacheItems))
+	errs = make([]error, len(cacheItems))
+	if len(cacheItems) == 0 {
+		return latest, errs
+	}
+
+	if batchGetter, ok := q.client.(webapi.BatchGetter); ok {
+		tCtxs := make([]webapi.GetContext, 0, len(cacheItems))
+		for _, cacheItem := range cacheItems {
+			tCtxs = append(tCtxs, newPluginContext(cacheItem.ResourceMeta, cacheItem.Resource, "", nil))
+		}
+
+		logger.Debugf(ctx, "Querying AsyncPlugin for a batch of [%v] resources", len(tCtxs))
+		resources, err := batchGetter.BatchGet(ctx, tCtxs)
+		if err == nil && len(resources) != len(tCtxs) {
+			err = errors.Errorf(BadReturnCodeError, "BatchGet returned [%v] resources for [%v] requested",
+				len(resources), len(tCtxs))
+		}
+
+		if err != nil {
+			for i := range errs {
+				errs[i] = err
+			}
+
+			return latest, errs
+		}
+
+		copy(latest, resources)
+	}
+
+	for i, cacheItem := range cacheItems {
+		if latest[i] != nil {
+			continue
+		}
+
+		latest[i], errs[i] = q.client.Get(ctx, newPluginContext(cacheItem.ResourceMeta, cacheItem.Resource, "", nil))
+	}
+
+	return latest, errs
+}
+
 // ToPluginPhase translates the more granular task phase into the webapi plugin phase.
 func ToPluginPhase(s core.Phase) (Phase, error) {
 	switch s {
@@ -153,16 +227,49 @@ func ToPluginPhase(s core.Phase) (Phase, error) {
 	}
 }
 
-func NewResourceCache(ctx context.Context, name string, client Client, cfg webapi.CachingConfig,
-	scope promutils.Scope) (ResourceCache, error) {
+// Creates a cache.CreateBatchesFunc that splits the snapshot into batches of at most batchSize items.
+func newBatchesFunc(batchSize int) cache.CreateBatchesFunc {
+	return func(_ context.Context, snapshot []cache.ItemWrapper) (batches []cache.Batch, err error) {
+		batches = make([]cache.Batch, 0, len(snapshot)/batchSize+1)
+		for start := 0; start < len(snapshot); start += batchSize {
+			end := start + batchSize
+			if end > len(snapshot) {
+				end = len(snapshot)
+			}
+
+			batches = append(batches, snapshot[start:end])
+		}
+
+		return batches, nil
+	}
+}
+
+// getResyncInterval returns how often to sync resources. Plugins that are notified of updates only sync as a safety net
+// for missed events.
+func getResyncInterval(cfg webapi.CachingConfig, notified bool) time.Duration {
+	if notified && cfg.NotifierResyncInterval.Duration > 0 {
+		return cfg.NotifierResyncInterval.Duration
+	}
+
+	return cfg.ResyncInterval.Duration
+}
+
+func NewResourceCache(ctx context.Context, name string, client Client, tracker *completionTracker,
+	cfg webapi.CachingConfig, scope promutils.Scope) (ResourceCache, error) {
 
 	q := ResourceCache{
-		client: client,
-		cfg:    cfg,
+		client:  client,
+		tracker: tracker,
+		cfg:     cfg,
+	}
+
+	createBatches := cache.SingleItemBatches
+	if _, ok := client.(webapi.BatchGetter); ok && cfg.BatchSize > 1 {
+		createBatches = newBatchesFunc(cfg.BatchSize)
 	}
 
-	autoRefreshCache, err := cache.NewAutoRefreshCache(name, q.SyncResource,
-		workqueue.DefaultControllerRateLimiter(), cfg.ResyncInterval.Duration, cfg.Workers, cfg.Size,
+	autoRefreshCache, err := cache.NewAutoRefreshBatchedCache(name, createBatches, q.SyncResource,
+		workqueue.DefaultControllerRateLimiter(), getResyncInterval(cfg, tracker != nil), cfg.Workers, cfg.Size,
 		scope.NewSubScope("cache"))
 
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -15,12 +16,13 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/cache"
 	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewResourceCache(t *testing.T) {
 	t.Run("Simple", func(t *testing.T) {
-		c, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{
+		c, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, nil, webapi.CachingConfig{
 			Size: 10,
 		}, promutils.NewTestScope())
 		assert.NoError(t, err)
@@ -28,7 +30,7 @@ func TestNewResourceCache(t *testing.T) {
 	})
 
 	t.Run("Error", func(t *testing.T) {
-		_, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{},
+		_, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, nil, webapi.CachingConfig{},
 			promutils.NewTestScope())
 		assert.Error(t, err)
 	})
@@ -165,3 +167,75 @@ func TestToPluginPhase(t *testing.T) {
 		})
 	}
 }
+
+type batchClient struct {
+	*mocks.Client
+
+	resources []webapi.Resource
+	err       error
+}
+
+func (b batchClient) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) (latest []webapi.Resource, err error) {
+	return b.resources, b.err
+}
+
+func TestResourceCache_SyncResourceBatch(t *testing.T) {
+	ctx := context.Background()
+	newItem := func(id, resourceMeta string) cache.ItemWrapper {
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(CacheItem{State: State{ResourceMeta: resourceMeta, Phase: PhaseResourcesCreated}})
+		iw.OnGetID().Return(id)
+		return iw
+	}
+
+	t.Run("Falls back to Get for missing resources", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		mockClient.OnGet(ctx, newPluginContext("meta2", nil, "", nil)).Return("resource2", nil)
+		q := ResourceCache{
+			client: batchClient{Client: mockClient, resources: []webapi.Resource{"resource1", nil}},
+			cfg:    webapi.CachingConfig{MaxSystemFailures: 5},
+		}
+
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("id1", "meta1"), newItem("id2", "meta2")})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		assert.Equal(t, "resource1", resp[0].Item.(CacheItem).Resource)
+		assert.Equal(t, "resource2", resp[1].Item.(CacheItem).Resource)
+		mockClient.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("Batch failure", func(t *testing.T) {
+		q := ResourceCache{
+			client: batchClient{Client: &mocks.Client{}, err: fmt.Errorf("failed")},
+			cfg:    webapi.CachingConfig{MaxSystemFailures: 5},
+		}
+
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("id1", "meta1"), newItem("id2", "meta2")})
+		assert.NoError(t, err)
+		for _, r := range resp {
+			assert.Equal(t, cache.Update, r.Action)
+			assert.Equal(t, 1, r.Item.(CacheItem).SyncFailureCount)
+		}
+	})
+}
+
+func Test_newBatchesFunc(t *testing.T) {
+	snapshot := make([]cache.ItemWrapper, 5)
+	batches, err := newBatchesFunc(2)(context.Background(), snapshot)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[2], 1)
+}
+
+func Test_getResyncInterval(t *testing.T) {
+	cfg := webapi.CachingConfig{
+		ResyncInterval:         config.Duration{Duration: 30 * time.Second},
+		NotifierResyncInterval: config.Duration{Duration: 5 * time.Minute},
+	}
+
+	assert.Equal(t, 30*time.Second, getResyncInterval(cfg, false))
+	assert.Equal(t, 5*time.Minute, getResyncInterval(cfg, true))
+
+	cfg.NotifierResyncInterval = config.Duration{}
+	assert.Equal(t, 30*time.Second, getResyncInterval(cfg, true))
+}
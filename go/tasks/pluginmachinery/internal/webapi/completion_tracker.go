@@ -0,0 +1,184 @@
+package webapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+type trackedResource struct {
+	resourceKey  string
+	resourceMeta webapi.ResourceMeta
+	// Signals the execution that owns the resource that it has to be re-evaluated.
+	refresh core.SignalAsync
+	// The most recent resource pushed by the remote service, until it's written into the resource cache.
+	pushed webapi.Resource
+}
+
+// completionTracker receives events from a webapi.Notifier, keeps the pushed resources until the resource cache picks
+// them up and signals the affected executions so that they are re-evaluated right away. All methods are no-ops on a nil
+// tracker, which is used for plugins that don't implement webapi.Notifier.
+type completionTracker struct {
+	notifier webapi.Notifier
+	client   Client
+	metrics  Metrics
+
+	lock sync.Mutex
+	// Cache ids by resource key.
+	cacheIDs map[string]string
+	// Tracked resources by cache id.
+	tracked map[string]*trackedResource
+}
+
+// track starts routing events for the resource to the execution of the task execution context.
+func (t *completionTracker) track(tCtx core.TaskExecutionContext, resourceMeta webapi.ResourceMeta) {
+	if t == nil {
+		return
+	}
+
+	key := t.notifier.ResourceKey(resourceMeta)
+	cacheID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if tracked, found := t.tracked[cacheID]; found && tracked.resourceKey == key {
+		tracked.refresh = tCtx.TaskRefreshIndicator()
+		return
+	}
+
+	t.cacheIDs[key] = cacheID
+	t.tracked[cacheID] = &trackedResource{
+		resourceKey:  key,
+		resourceMeta: resourceMeta,
+		refresh:      tCtx.TaskRefreshIndicator(),
+	}
+}
+
+// untrack stops routing events to the execution and drops any resource pushed for it.
+func (t *completionTracker) untrack(cacheID string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tracked, found := t.tracked[cacheID]
+	if !found {
+		return
+	}
+
+	if t.cacheIDs[tracked.resourceKey] == cacheID {
+		delete(t.cacheIDs, tracked.resourceKey)
+	}
+
+	delete(t.tracked, cacheID)
+}
+
+func (t *completionTracker) onEvent(ctx context.Context, event webapi.CompletionEvent) {
+	t.lock.Lock()
+	cacheID, found := t.cacheIDs[event.ResourceKey]
+	var resourceMeta webapi.ResourceMeta
+	if found {
+		resourceMeta = t.tracked[cacheID].resourceMeta
+	}
+	t.lock.Unlock()
+
+	if !found {
+		t.metrics.UnmatchedCompletionEvents.Inc(ctx)
+		logger.Debugf(ctx, "Ignoring completion event for untracked resource [%v].", event.ResourceKey)
+		return
+	}
+
+	t.metrics.CompletionEvents.Inc(ctx)
+	if event.Resource != nil {
+		t.push(ctx, cacheID, event.Resource)
+		return
+	}
+
+	// Don't block the notifier while retrieving the resource the event didn't carry.
+	go func() {
+		resource, err := t.client.Get(ctx, newPluginContext(resourceMeta, nil, "", nil))
+		if err != nil {
+			// The background sync of the resource cache will retrieve it.
+			logger.Infof(ctx, "Failed to retrieve pushed resource [%v]. Error: %v", cacheID, err)
+			return
+		}
+
+		t.push(ctx, cacheID, resource)
+	}()
+}
+
+// push records the resource for the cache item and signals its execution.
+func (t *completionTracker) push(ctx context.Context, cacheID string, resource webapi.Resource) {
+	var refresh core.SignalAsync
+	t.lock.Lock()
+	if tracked, found := t.tracked[cacheID]; found {
+		tracked.pushed = resource
+		refresh = tracked.refresh
+	}
+	t.lock.Unlock()
+
+	if refresh != nil {
+		refresh(ctx)
+	}
+}
+
+// latest returns the resource pushed for the cache item, if the resource cache hasn't picked it up yet.
+func (t *completionTracker) latest(cacheID string) (resource webapi.Resource, found bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if tracked, ok := t.tracked[cacheID]; ok && tracked.pushed != nil {
+		return tracked.pushed, true
+	}
+
+	return nil, false
+}
+
+// take returns the resource pushed for the cache item and forgets it. It's called by the resource cache when it writes
+// the resource into the cache item.
+func (t *completionTracker) take(cacheID string) (resource webapi.Resource, found bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tracked, ok := t.tracked[cacheID]
+	if !ok || tracked.pushed == nil {
+		return nil, false
+	}
+
+	resource = tracked.pushed
+	tracked.pushed = nil
+	return resource, true
+}
+
+// Subscribes to the plugin notifications if it implements webapi.Notifier.
+func newCompletionTracker(ctx context.Context, p webapi.AsyncPlugin, client Client, metrics Metrics) (
+	*completionTracker, error) {
+	notifier, ok := p.(webapi.Notifier)
+	if !ok {
+		return nil, nil
+	}
+
+	t := &completionTracker{
+		notifier: notifier,
+		client:   client,
+		metrics:  metrics,
+		cacheIDs: map[string]string{},
+		tracked:  map[string]*trackedResource{},
+	}
+
+	if err := notifier.Subscribe(ctx, t.onEvent); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,138 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+type notifierPlugin struct {
+	webapi.AsyncPlugin
+	*webapi.InMemoryEventBus
+}
+
+func (notifierPlugin) ResourceKey(resourceMeta webapi.ResourceMeta) string {
+	return resourceMeta.(string)
+}
+
+func newTrackedTaskExecutionContext(cacheID string, refresh core.SignalAsync) *coreMocks.TaskExecutionContext {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return(cacheID)
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnTaskRefreshIndicator().Return(refresh)
+	return tCtx
+}
+
+func TestCompletionTracker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Not a notifier", func(t *testing.T) {
+		tracker, err := newCompletionTracker(ctx, newPluginWithProperties(webapi.PluginConfig{}), nil,
+			newMetrics(promutils.NewTestScope()))
+		assert.NoError(t, err)
+		assert.Nil(t, tracker)
+
+		// A nil tracker is a no-op.
+		tracker.track(&coreMocks.TaskExecutionContext{}, "meta")
+		_, found := tracker.latest("id")
+		assert.False(t, found)
+		_, found = tracker.take("id")
+		assert.False(t, found)
+		tracker.untrack("id")
+	})
+
+	t.Run("Pushed resource", func(t *testing.T) {
+		bus := webapi.NewInMemoryEventBus()
+		refreshed := 0
+		tracker, err := newCompletionTracker(ctx, notifierPlugin{InMemoryEventBus: bus}, &mocks.Client{},
+			newMetrics(promutils.NewTestScope()))
+		assert.NoError(t, err)
+
+		tracker.track(newTrackedTaskExecutionContext("id-1", func(ctx context.Context) { refreshed++ }), "query-1")
+		bus.Publish(webapi.CompletionEvent{ResourceKey: "unknown", Resource: "ignored"})
+		bus.Publish(webapi.CompletionEvent{ResourceKey: "query-1", Resource: "done"})
+		assert.Equal(t, 1, refreshed)
+
+		// The pushed resource is served until the resource cache takes it.
+		resource, found := tracker.latest("id-1")
+		assert.True(t, found)
+		assert.Equal(t, "done", resource)
+		resource, found = tracker.take("id-1")
+		assert.True(t, found)
+		assert.Equal(t, "done", resource)
+		_, found = tracker.latest("id-1")
+		assert.False(t, found)
+
+		tracker.untrack("id-1")
+		bus.Publish(webapi.CompletionEvent{ResourceKey: "query-1", Resource: "late"})
+		assert.Equal(t, 1, refreshed)
+		_, found = tracker.latest("id-1")
+		assert.False(t, found)
+	})
+
+	t.Run("Retrieves resource missing from event", func(t *testing.T) {
+		bus := webapi.NewInMemoryEventBus()
+		client := &mocks.Client{}
+		client.OnGetMatch(mock.Anything, mock.Anything).Return("fetched", nil).Once()
+		tracker, err := newCompletionTracker(ctx, notifierPlugin{InMemoryEventBus: bus}, client,
+			newMetrics(promutils.NewTestScope()))
+		assert.NoError(t, err)
+
+		refreshed := make(chan struct{}, 1)
+		tracker.track(newTrackedTaskExecutionContext("id-1", func(ctx context.Context) { refreshed <- struct{}{} }),
+			"query-1")
+		bus.Publish(webapi.CompletionEvent{ResourceKey: "query-1"})
+
+		select {
+		case <-refreshed:
+		case <-time.After(5 * time.Second):
+			assert.FailNow(t, "execution wasn't refreshed")
+		}
+
+		resource, found := tracker.latest("id-1")
+		assert.True(t, found)
+		assert.Equal(t, "fetched", resource)
+	})
+
+	t.Run("Resource cache picks up pushed resources", func(t *testing.T) {
+		bus := webapi.NewInMemoryEventBus()
+		client := &mocks.Client{}
+		client.OnGetMatch(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("must not be called"))
+		tracker, err := newCompletionTracker(ctx, notifierPlugin{InMemoryEventBus: bus}, client,
+			newMetrics(promutils.NewTestScope()))
+		assert.NoError(t, err)
+
+		tracker.track(newTrackedTaskExecutionContext("id-1", func(ctx context.Context) {}), "query-1")
+		bus.Publish(webapi.CompletionEvent{ResourceKey: "query-1", Resource: "done"})
+
+		q := ResourceCache{client: client, tracker: tracker, cfg: webapi.CachingConfig{MaxSystemFailures: 5}}
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(CacheItem{State: State{Phase: PhaseResourcesCreated, ResourceMeta: "query-1"}})
+		iw.OnGetID().Return("id-1")
+		resp, err := q.SyncResource(ctx, cache.Batch{iw})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+		assert.Equal(t, cache.Update, resp[0].Action)
+		assert.Equal(t, "done", resp[0].Item.(CacheItem).Resource)
+		client.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+
+		_, found := tracker.latest("id-1")
+		assert.False(t, found)
+	})
+}
@@ -39,18 +39,25 @@ type CorePlugin struct {
 	cache          cache.AutoRefresh
 	tokenAllocator tokenAllocator
 	metrics        Metrics
+	tracker        *completionTracker
+	// Nil if the plugin doesn't implement webapi.ResourceAdopter.
+	adopter webapi.ResourceAdopter
 }
 
 func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginStateReader) (State, error) {
-	t := c.metrics.SucceededUnmarshalState.Start(ctx)
+	return unmarshalState(ctx, c.GetID(), stateReader, c.metrics)
+}
+
+func unmarshalState(ctx context.Context, pluginID string, stateReader core.PluginStateReader, metrics Metrics) (State, error) {
+	t := metrics.SucceededUnmarshalState.Start(ctx)
 	existingState := State{}
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
 	if _, err := stateReader.Get(&existingState); err != nil {
-		c.metrics.FailedUnmarshalState.Inc(ctx)
+		metrics.FailedUnmarshalState.Inc(ctx)
 		logger.Errorf(ctx, "AsyncPlugin [%v] failed to unmarshal custom state. Error: %v",
-			c.GetID(), err)
+			pluginID, err)
 
 		return State{}, errors.Wrapf(errors.CorruptedPluginState, err,
 			"Failed to unmarshal custom state in Handle")
@@ -81,18 +88,24 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 		if len(c.p.GetConfig().ResourceQuotas) > 0 {
 			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
 		} else {
-			nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+			nextState, phaseInfo, err = launch(ctx, c.p, c.adopter, tCtx, c.cache, &incomingState)
 		}
 	case PhaseAllocationTokenAcquired:
-		nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+		nextState, phaseInfo, err = launch(ctx, c.p, c.adopter, tCtx, c.cache, &incomingState)
 	case PhaseResourcesCreated:
-		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, &incomingState)
+		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, c.tracker, &incomingState)
 	}
 
 	if err != nil {
 		return core.UnknownTransition, err
 	}
 
+	if nextState.Phase == PhaseResourcesCreated {
+		c.tracker.track(tCtx, nextState.ResourceMeta)
+	} else if nextState.Phase.IsTerminal() {
+		c.tracker.untrack(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	}
+
 	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
 		return core.UnknownTransition, err
 	}
@@ -107,6 +120,25 @@ func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) e
 	}
 
 	logger.Infof(ctx, "Attempting to abort resource [%v].", tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID())
+	c.tracker.untrack(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+
+	// A resource may have been created without its meta being persisted (e.g. if propeller restarted right after
+	// creating it). Look it up to make sure it's deleted as well.
+	if incomingState.ResourceMeta == nil && c.adopter != nil {
+		rMeta, _, found, err := c.adopter.Lookup(ctx, tCtx)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to look up resource to abort [%v]. Error: %v",
+				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
+			return err
+		}
+
+		if !found {
+			logger.Infof(ctx, "No resource to abort.")
+			return nil
+		}
+
+		incomingState.ResourceMeta = rMeta
+	}
 
 	err = c.p.Delete(ctx, newPluginContext(incomingState.ResourceMeta, nil, "Aborted", tCtx))
 	if err != nil {
@@ -119,6 +151,8 @@ func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) e
 }
 
 func (c CorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	c.tracker.untrack(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+
 	if len(c.p.GetConfig().ResourceQuotas) == 0 {
 		// If there are no defined quotas, there is nothing to cleanup.
 		return nil
@@ -146,11 +180,25 @@ func validateRangeFloat64(fieldName string, min, max, provided float64) error {
 
 	return nil
 }
+
+func validateSyncConfig(cfg webapi.PluginConfig) error {
+	errs := stdErrs.ErrorCollection{}
+	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
+	errs.Append(validateRangeInt("write qps", minQPS, maxQPS, cfg.WriteRateLimiter.QPS))
+
+	return errs.ErrorOrDefault()
+}
+
 func validateConfig(cfg webapi.PluginConfig) error {
 	errs := stdErrs.ErrorCollection{}
 	errs.Append(validateRangeInt("cache size", minCacheSize, maxCacheSize, cfg.Caching.Size))
 	errs.Append(validateRangeInt("workers count", minWorkers, maxWorkers, cfg.Caching.Workers))
 	errs.Append(validateRangeFloat64("resync interval", minSyncDuration.Seconds(), maxSyncDuration.Seconds(), cfg.Caching.ResyncInterval.Seconds()))
+	if cfg.Caching.NotifierResyncInterval.Duration > 0 {
+		errs.Append(validateRangeFloat64("notifier resync interval", minSyncDuration.Seconds(), maxSyncDuration.Seconds(),
+			cfg.Caching.NotifierResyncInterval.Seconds()))
+	}
+
 	errs.Append(validateRangeInt("read burst", minBurst, maxBurst, cfg.ReadRateLimiter.Burst))
 	errs.Append(validateRangeInt("read qps", minQPS, maxQPS, cfg.ReadRateLimiter.QPS))
 	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
@@ -190,7 +238,14 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				}
 			}
 
-			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, p, p.GetConfig().Caching,
+			metrics := newMetrics(iCtx.MetricsScope())
+			rateLimited, adopter := wrapRateLimited(p, metrics)
+			tracker, err := newCompletionTracker(ctx, p, rateLimited, metrics)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, rateLimited, tracker, p.GetConfig().Caching,
 				iCtx.MetricsScope().NewSubScope("cache"))
 
 			if err != nil {
@@ -204,10 +259,12 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 
 			return CorePlugin{
 				id:             pluginEntry.ID,
-				p:              p,
+				p:              rateLimited,
 				cache:          resourceCache,
-				metrics:        newMetrics(iCtx.MetricsScope()),
+				metrics:        metrics,
 				tokenAllocator: newTokenAllocator(c),
+				tracker:        tracker,
+				adopter:        adopter,
 			}, nil
 		},
 	}
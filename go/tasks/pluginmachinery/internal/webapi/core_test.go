@@ -5,12 +5,18 @@ import (
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
 )
 
 func Test_validateConfig(t *testing.T) {
@@ -77,6 +83,29 @@ func Test_validateConfig(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, "\ncache size is expected to be between 10 and 500000. Provided value is 1000000000\nworkers count is expected to be between 1 and 100. Provided value is 1000000000\nresync interval is expected to be between 5 and 3600. Provided value is 3.6e+07\nread burst is expected to be between 5 and 10000. Provided value is 1000000\nwrite burst is expected to be between 5 and 10000. Provided value is 1000000", err.Error())
 	})
+
+	t.Run("Notifier resync interval out of range", func(t *testing.T) {
+		cfg := webapi.PluginConfig{
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				QPS:   10,
+				Burst: 100,
+			},
+			Caching: webapi.CachingConfig{
+				Size:                   10,
+				ResyncInterval:         config.Duration{Duration: 10 * time.Second},
+				NotifierResyncInterval: config.Duration{Duration: time.Second},
+				Workers:                10,
+			},
+		}
+
+		err := validateConfig(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, "\nnotifier resync interval is expected to be between 5 and 3600. Provided value is 1", err.Error())
+	})
 }
 
 func TestCreateRemotePlugin(t *testing.T) {
@@ -94,3 +123,47 @@ func TestCreateRemotePlugin(t *testing.T) {
 		DefaultForTaskTypes: []core.TaskType{"test-task"},
 	})
 }
+
+func TestCorePlugin_Abort(t *testing.T) {
+	ctx := context.Background()
+
+	newTaskContext := func() *mocks.TaskExecutionContext {
+		tID := &mocks.TaskExecutionID{}
+		tID.OnGetGeneratedName().Return("abc")
+		tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{})
+
+		tMeta := &mocks.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+
+		stateReader := &mocks.PluginStateReader{}
+		stateReader.OnGetMatch(mock.Anything).Return(uint8(0), nil)
+
+		tCtx := &mocks.TaskExecutionContext{}
+		tCtx.OnTaskExecutionMetadata().Return(tMeta)
+		tCtx.OnPluginStateReader().Return(stateReader)
+		return tCtx
+	}
+
+	t.Run("Deletes resource created without persisted state", func(t *testing.T) {
+		tCtx := newTaskContext()
+		p := newPluginWithProperties(webapi.PluginConfig{})
+		p.OnDeleteMatch(ctx, mock.Anything).Return(nil)
+		adopter := &webapiMocks.ResourceAdopter{}
+		adopter.OnLookup(ctx, tCtx).Return("query-1", "", true, nil)
+
+		c := CorePlugin{p: p, adopter: adopter, metrics: newMetrics(promutils.NewTestScope())}
+		assert.NoError(t, c.Abort(ctx, tCtx))
+		p.AssertCalled(t, "Delete", ctx, newPluginContext("query-1", nil, "Aborted", tCtx))
+	})
+
+	t.Run("No resource to delete", func(t *testing.T) {
+		tCtx := newTaskContext()
+		p := newPluginWithProperties(webapi.PluginConfig{})
+		adopter := &webapiMocks.ResourceAdopter{}
+		adopter.OnLookup(ctx, tCtx).Return("", "", false, nil)
+
+		c := CorePlugin{p: p, adopter: adopter, metrics: newMetrics(promutils.NewTestScope())}
+		assert.NoError(t, c.Abort(ctx, tCtx))
+		p.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
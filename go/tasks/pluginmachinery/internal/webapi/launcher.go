@@ -11,9 +11,35 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
-func launch(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, cache cache.AutoRefresh,
-	state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
-	rMeta, r, err := p.Create(ctx, tCtx)
+// Adopts the resource created by a previous attempt if the plugin supports it (adopter is non-nil) and such a resource
+// exists. Creates a new resource otherwise.
+func createOrAdopt(ctx context.Context, p webapi.AsyncPlugin, adopter webapi.ResourceAdopter,
+	tCtx core.TaskExecutionContext) (rMeta webapi.ResourceMeta, r webapi.Resource, err error) {
+	if adopter != nil {
+		rMeta, r, found, err := adopter.Lookup(ctx, tCtx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if found {
+			logger.Infof(ctx, "Adopting existing resource [%v] for [%s]", rMeta,
+				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+			return rMeta, r, nil
+		}
+	}
+
+	return p.Create(ctx, tCtx)
+}
+
+func launch(ctx context.Context, p webapi.AsyncPlugin, adopter webapi.ResourceAdopter, tCtx core.TaskExecutionContext,
+	cache cache.AutoRefresh, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
+	rMeta, r, err := createOrAdopt(ctx, p, adopter, tCtx)
+	if IsThrottled(err) {
+		logger.Infof(ctx, "Delaying resource creation. Error: %v", err)
+		return state, core.PhaseInfoWaitingForResources(time.Now(), core.DefaultPhaseVersion,
+			"Rate limit for calls to the remote service reached."), nil
+	}
+
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create resource. Error: %v", err)
 		return nil, core.PhaseInfo{}, err
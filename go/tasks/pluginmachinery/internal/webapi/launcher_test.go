@@ -9,6 +9,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	mocks2 "github.com/flyteorg/flytestdlib/cache/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,7 +34,7 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", nil, "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
@@ -57,7 +58,7 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("abc", "abc-r", nil)
 		plgn.OnStatus(ctx, newPluginContext("abc", "abc-r", "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
-		newS, phaseInfo, err := launch(ctx, plgn, tCtx, c, &s)
+		newS, phaseInfo, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.NoError(t, err)
 		assert.NotNil(t, newS)
 		assert.NotNil(t, phaseInfo)
@@ -79,7 +80,7 @@ func Test_launch(t *testing.T) {
 
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("", nil, fmt.Errorf("error creating"))
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.Error(t, err)
 	})
 
@@ -102,7 +103,63 @@ func Test_launch(t *testing.T) {
 		plgn := newPluginWithProperties(webapi.PluginConfig{})
 		plgn.OnCreate(ctx, tCtx).Return("my-id", nil, nil)
 		plgn.OnStatus(ctx, newPluginContext("my-id", nil, "", tCtx)).Return(core.PhaseInfoRunning(0, nil), nil)
-		_, _, err := launch(ctx, plgn, tCtx, c, &s)
+		_, _, err := launch(ctx, plgn, nil, tCtx, c, &s)
 		assert.Error(t, err)
 	})
+
+	t.Run("Adopts existing resource", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		meta := &mocks.TaskExecutionMetadata{}
+		taskID := &mocks.TaskExecutionID{}
+		taskID.OnGetGeneratedName().Return("my-id")
+		meta.OnGetTaskExecutionID().Return(taskID)
+		tCtx.OnTaskExecutionMetadata().Return(meta)
+
+		c := &mocks2.AutoRefresh{}
+		s := State{Phase: PhaseAllocationTokenAcquired}
+
+		adopter := &webapiMocks.ResourceAdopter{}
+		adopter.OnLookup(ctx, tCtx).Return("abc", "abc-r", true, nil)
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		plgn.OnStatus(ctx, newPluginContext("abc", "abc-r", "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
+		_, phaseInfo, err := launch(ctx, plgn, adopter, tCtx, c, &s)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phaseInfo.Phase())
+		plgn.AssertNotCalled(t, "Create", ctx, tCtx)
+	})
+
+	t.Run("Creates resource if none to adopt", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+		meta := &mocks.TaskExecutionMetadata{}
+		taskID := &mocks.TaskExecutionID{}
+		taskID.OnGetGeneratedName().Return("my-id")
+		meta.OnGetTaskExecutionID().Return(taskID)
+		tCtx.OnTaskExecutionMetadata().Return(meta)
+
+		c := &mocks2.AutoRefresh{}
+		created := State{Phase: PhaseResourcesCreated, ResourceMeta: "abc"}
+		c.OnGetOrCreate("my-id", CacheItem{State: created}).Return(CacheItem{State: created}, nil)
+
+		adopter := &webapiMocks.ResourceAdopter{}
+		adopter.OnLookup(ctx, tCtx).Return("", "", false, nil)
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		plgn.OnCreate(ctx, tCtx).Return("abc", nil, nil)
+		newS, _, err := launch(ctx, plgn, adopter, tCtx, c, &State{Phase: PhaseAllocationTokenAcquired})
+		assert.NoError(t, err)
+		assert.Equal(t, created, *newS)
+	})
+
+	t.Run("Failed to look up resource", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx := &mocks.TaskExecutionContext{}
+
+		adopter := &webapiMocks.ResourceAdopter{}
+		adopter.OnLookup(ctx, tCtx).Return("", "", false, fmt.Errorf("unavailable"))
+		plgn := newPluginWithProperties(webapi.PluginConfig{})
+		_, _, err := launch(ctx, plgn, adopter, tCtx, &mocks2.AutoRefresh{}, &State{})
+		assert.Error(t, err)
+		plgn.AssertNotCalled(t, "Create", ctx, tCtx)
+	})
 }
@@ -9,14 +9,20 @@ import (
 )
 
 type Metrics struct {
-	Scope                   promutils.Scope
-	ResourceReleased        labeled.Counter
-	ResourceReleaseFailed   labeled.Counter
-	AllocationGranted       labeled.Counter
-	AllocationNotGranted    labeled.Counter
-	ResourceWaitTime        prometheus.Summary
-	SucceededUnmarshalState labeled.StopWatch
-	FailedUnmarshalState    labeled.Counter
+	Scope                     promutils.Scope
+	ResourceReleased          labeled.Counter
+	ResourceReleaseFailed     labeled.Counter
+	AllocationGranted         labeled.Counter
+	AllocationNotGranted      labeled.Counter
+	ResourceWaitTime          prometheus.Summary
+	SucceededUnmarshalState   labeled.StopWatch
+	FailedUnmarshalState      labeled.Counter
+	RetryableFailures         labeled.Counter
+	PermanentFailures         labeled.Counter
+	ReadThrottled             labeled.Counter
+	WriteThrottled            labeled.Counter
+	CompletionEvents          labeled.Counter
+	UnmatchedCompletionEvents labeled.Counter
 }
 
 var (
@@ -40,5 +46,17 @@ func newMetrics(scope promutils.Scope) Metrics {
 			time.Millisecond, scope),
 		FailedUnmarshalState: labeled.NewCounter("unmarshal_state_failed",
 			"Failed to unmarshal state", scope, labeled.EmitUnlabeledMetric),
+		RetryableFailures: labeled.NewCounter("retryable_failures",
+			"Calls to the remote service that failed and will be retried", scope, labeled.EmitUnlabeledMetric),
+		PermanentFailures: labeled.NewCounter("permanent_failures",
+			"Calls to the remote service that failed permanently", scope, labeled.EmitUnlabeledMetric),
+		ReadThrottled: labeled.NewCounter("read_throttled",
+			"Read calls to the remote service delayed by the client side rate limiter", scope, labeled.EmitUnlabeledMetric),
+		WriteThrottled: labeled.NewCounter("write_throttled",
+			"Write calls to the remote service delayed by the client side rate limiter", scope, labeled.EmitUnlabeledMetric),
+		CompletionEvents: labeled.NewCounter("completion_events",
+			"Completion events pushed for tracked resources", scope, labeled.EmitUnlabeledMetric),
+		UnmatchedCompletionEvents: labeled.NewCounter("completion_events_unmatched",
+			"Completion events pushed for resources that aren't tracked", scope, labeled.EmitUnlabeledMetric),
 	}
 }
@@ -10,8 +10,8 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
-func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh, state *State) (
-	newState *State, phaseInfo core.PhaseInfo, err error) {
+func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh,
+	tracker *completionTracker, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
 	newCacheItem := CacheItem{
 		State: *state,
 	}
@@ -29,6 +29,11 @@ func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cach
 			errors.CacheFailed, "Failed to cast [%v]", cacheItem)
 	}
 
+	// A resource pushed by the remote service is more recent than the one in the cache until the cache picks it up.
+	if resource, found := tracker.latest(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()); found {
+		cacheItem.Resource = resource
+	}
+
 	// If the cache has not syncd yet, just return
 	if cacheItem.Resource == nil {
 		return state, core.PhaseInfoRunning(0, nil), nil
@@ -0,0 +1,137 @@
+package webapi
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+const (
+	ThrottledError stdErrors.ErrorCode = "THROTTLED"
+)
+
+func newRateLimiter(cfg webapi.RateLimiterConfig) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(cfg.QPS), cfg.Burst)
+}
+
+// IsThrottled returns true if err was returned because the client side rate limit to the remote service was reached.
+func IsThrottled(err error) bool {
+	return stdErrors.IsCausedBy(err, ThrottledError)
+}
+
+// rateLimitedPlugin wraps an AsyncPlugin to enforce the read (Get) and write (Create/Delete) rate limits in its config.
+// A single instance is shared by all executions handled by the plugin.
+type rateLimitedPlugin struct {
+	webapi.AsyncPlugin
+
+	readLimiter  *rate.Limiter
+	writeLimiter *rate.Limiter
+	metrics      Metrics
+}
+
+// Create returns a ThrottledError without calling the plugin if the write rate limit has been reached.
+func (p rateLimitedPlugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+	resourceMeta webapi.ResourceMeta, optionalResource webapi.Resource, err error) {
+	if !p.writeLimiter.Allow() {
+		p.metrics.WriteThrottled.Inc(ctx)
+		return nil, nil, stdErrors.Errorf(ThrottledError, "write rate limit reached")
+	}
+
+	return p.AsyncPlugin.Create(ctx, tCtx)
+}
+
+// Get returns a ThrottledError without calling the plugin if the read rate limit has been reached.
+func (p rateLimitedPlugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	if !p.readLimiter.Allow() {
+		p.metrics.ReadThrottled.Inc(ctx)
+		return nil, stdErrors.Errorf(ThrottledError, "read rate limit reached")
+	}
+
+	return p.AsyncPlugin.Get(ctx, tCtx)
+}
+
+// Delete blocks until the write rate limiter allows the call since aborts can't be postponed.
+func (p rateLimitedPlugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
+	if !p.writeLimiter.Allow() {
+		p.metrics.WriteThrottled.Inc(ctx)
+		logger.Infof(ctx, "Write rate limit reached, waiting to delete resource.")
+		if err := p.writeLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return p.AsyncPlugin.Delete(ctx, tCtx)
+}
+
+// rateLimitedBatchGetter is a rateLimitedPlugin for AsyncPlugins that implement webapi.BatchGetter. A batch consumes a
+// single read token.
+type rateLimitedBatchGetter struct {
+	rateLimitedPlugin
+
+	batchGetter webapi.BatchGetter
+}
+
+// BatchGet returns a ThrottledError without calling the plugin if the read rate limit has been reached.
+func (p rateLimitedBatchGetter) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) (latest []webapi.Resource, err error) {
+	if !p.readLimiter.Allow() {
+		p.metrics.ReadThrottled.Inc(ctx)
+		return nil, stdErrors.Errorf(ThrottledError, "read rate limit reached")
+	}
+
+	return p.batchGetter.BatchGet(ctx, tCtxs)
+}
+
+// rateLimitedAdopter enforces the read rate limit of a rateLimitedPlugin on lookups of existing resources.
+type rateLimitedAdopter struct {
+	rateLimitedPlugin
+
+	adopter webapi.ResourceAdopter
+}
+
+// Lookup returns a ThrottledError without calling the plugin if the read rate limit has been reached.
+func (p rateLimitedAdopter) Lookup(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+	resourceMeta webapi.ResourceMeta, optionalResource webapi.Resource, found bool, err error) {
+	if !p.readLimiter.Allow() {
+		p.metrics.ReadThrottled.Inc(ctx)
+		return nil, nil, false, stdErrors.Errorf(ThrottledError, "read rate limit reached")
+	}
+
+	return p.adopter.Lookup(ctx, tCtx)
+}
+
+// Wraps p to enforce its rate limits, preserving the optional interfaces it implements. The returned adopter is nil if
+// p doesn't implement webapi.ResourceAdopter.
+func wrapRateLimited(p webapi.AsyncPlugin, metrics Metrics) (webapi.AsyncPlugin, webapi.ResourceAdopter) {
+	rateLimited := newRateLimitedPlugin(p, metrics)
+
+	var adopter webapi.ResourceAdopter
+	if a, ok := p.(webapi.ResourceAdopter); ok {
+		adopter = rateLimitedAdopter{
+			rateLimitedPlugin: rateLimited,
+			adopter:           a,
+		}
+	}
+
+	if batchGetter, ok := p.(webapi.BatchGetter); ok {
+		return rateLimitedBatchGetter{
+			rateLimitedPlugin: rateLimited,
+			batchGetter:       batchGetter,
+		}, adopter
+	}
+
+	return rateLimited, adopter
+}
+
+func newRateLimitedPlugin(p webapi.AsyncPlugin, metrics Metrics) rateLimitedPlugin {
+	return rateLimitedPlugin{
+		AsyncPlugin:  p,
+		readLimiter:  newRateLimiter(p.GetConfig().ReadRateLimiter),
+		writeLimiter: newRateLimiter(p.GetConfig().WriteRateLimiter),
+		metrics:      metrics,
+	}
+}
@@ -0,0 +1,102 @@
+package webapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func newThrottledPlugin() rateLimitedPlugin {
+	limits := webapi.RateLimiterConfig{QPS: 1, Burst: 1}
+	p := newRateLimitedPlugin(newPluginWithProperties(webapi.PluginConfig{
+		ReadRateLimiter:  limits,
+		WriteRateLimiter: limits,
+	}), newMetrics(promutils.NewTestScope()))
+
+	// Exhaust the burst so that the following calls are throttled.
+	p.readLimiter.Allow()
+	p.writeLimiter.Allow()
+	return p
+}
+
+func TestRateLimitedPlugin(t *testing.T) {
+	ctx := context.Background()
+	p := newThrottledPlugin()
+
+	_, _, err := p.Create(ctx, nil)
+	assert.True(t, IsThrottled(err))
+
+	_, err = p.Get(ctx, nil)
+	assert.True(t, IsThrottled(err))
+
+	adopter := rateLimitedAdopter{rateLimitedPlugin: p, adopter: &webapiMocks.ResourceAdopter{}}
+	_, _, _, err = adopter.Lookup(ctx, nil)
+	assert.True(t, IsThrottled(err))
+
+	p.AsyncPlugin.(*webapiMocks.AsyncPlugin).OnDeleteMatch(mock.Anything, mock.Anything).Return(nil)
+	timeout, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	assert.NoError(t, p.Delete(timeout, nil))
+}
+
+func Test_wrapRateLimited(t *testing.T) {
+	metrics := newMetrics(promutils.NewTestScope())
+
+	_, adopter := wrapRateLimited(newPluginWithProperties(webapi.PluginConfig{}), metrics)
+	assert.Nil(t, adopter)
+
+	_, adopter = wrapRateLimited(struct {
+		*webapiMocks.AsyncPlugin
+		*webapiMocks.ResourceAdopter
+	}{newPluginWithProperties(webapi.PluginConfig{}), &webapiMocks.ResourceAdopter{}}, metrics)
+	assert.NotNil(t, adopter)
+}
+
+func Test_launchThrottled(t *testing.T) {
+	ctx := context.Background()
+	tCtx := &mocks.TaskExecutionContext{}
+
+	s := State{Phase: PhaseAllocationTokenAcquired}
+	newS, phaseInfo, err := launch(ctx, newThrottledPlugin(), nil, tCtx, &cacheMocks.AutoRefresh{}, &s)
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseAllocationTokenAcquired, newS.Phase)
+	assert.Equal(t, core.PhaseWaitingForResources, phaseInfo.Phase())
+}
+
+func TestResourceCache_SyncResourceThrottled(t *testing.T) {
+	q := ResourceCache{
+		AutoRefresh: &cacheMocks.AutoRefresh{},
+		client:      newThrottledPlugin(),
+		cfg: webapi.CachingConfig{
+			MaxSystemFailures: 5,
+		},
+	}
+
+	cacheItem := CacheItem{
+		State: State{
+			ResourceMeta: "123456",
+			Phase:        PhaseResourcesCreated,
+		},
+	}
+
+	iw := &cacheMocks.ItemWrapper{}
+	iw.OnGetItem().Return(cacheItem)
+	iw.OnGetID().Return("some-id")
+
+	newCacheItem, err := q.SyncResource(context.Background(), []cache.ItemWrapper{iw})
+	assert.NoError(t, err)
+	assert.Equal(t, cache.Unchanged, newCacheItem[0].Action)
+	assert.Equal(t, 0, newCacheItem[0].Item.(CacheItem).SyncFailureCount)
+}
@@ -0,0 +1,194 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// SyncCorePlugin adapts a webapi.SyncPlugin to a core.Plugin. The remote call is made inline in Handle once the
+// allocation token (if any quota is configured) has been acquired and the write rate limiter allows it.
+type SyncCorePlugin struct {
+	id             string
+	p              webapi.SyncPlugin
+	writeLimiter   *rate.Limiter
+	tokenAllocator tokenAllocator
+	metrics        Metrics
+}
+
+// Overrides the OutputWriter so that outputs are only persisted once the call has succeeded.
+type syncPluginContext struct {
+	webapi.TaskExecutionContext
+	outputWriter io.OutputWriter
+}
+
+func (s syncPluginContext) OutputWriter() io.OutputWriter {
+	return s.outputWriter
+}
+
+func (c SyncCorePlugin) GetID() string {
+	return c.id
+}
+
+func (c SyncCorePlugin) GetProperties() core.PluginProperties {
+	return core.PluginProperties{}
+}
+
+func (c SyncCorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	incomingState, err := unmarshalState(ctx, c.GetID(), tCtx.PluginStateReader(), c.metrics)
+	if err != nil {
+		return core.UnknownTransition, err
+	}
+
+	nextState := &incomingState
+	var phaseInfo core.PhaseInfo
+	switch incomingState.Phase {
+	case PhaseNotStarted:
+		if len(c.p.GetConfig().ResourceQuotas) > 0 {
+			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
+			if err != nil {
+				return core.UnknownTransition, err
+			}
+
+			if nextState.Phase != PhaseAllocationTokenAcquired {
+				break
+			}
+		}
+
+		fallthrough
+	case PhaseAllocationTokenAcquired:
+		phaseInfo, err = c.call(ctx, tCtx, nextState)
+		if err != nil {
+			return core.UnknownTransition, err
+		}
+	default:
+		return core.UnknownTransition, errors.Errorf(errors.CorruptedPluginState,
+			"Unexpected phase [%v] for a sync plugin", incomingState.Phase)
+	}
+
+	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
+		return core.UnknownTransition, err
+	}
+
+	return core.DoTransitionType(core.TransitionTypeBarrier, phaseInfo), nil
+}
+
+// Calls the plugin if the write rate limiter allows it and moves the state to a terminal phase once the call completes.
+func (c SyncCorePlugin) call(ctx context.Context, tCtx core.TaskExecutionContext, state *State) (core.PhaseInfo, error) {
+	if !c.writeLimiter.Allow() {
+		c.metrics.WriteThrottled.Inc(ctx)
+		logger.Infof(ctx, "Write rate limit reached for plugin [%v], delaying the call.", c.GetID())
+		return core.PhaseInfoWaitingForResources(time.Now(), core.DefaultPhaseVersion,
+			"Rate limit for calls to the remote service reached."), nil
+	}
+
+	phaseInfo, err := c.do(ctx, tCtx)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	if phaseInfo.Phase().IsTerminal() {
+		if state.Phase, err = ToPluginPhase(phaseInfo.Phase()); err != nil {
+			return core.PhaseInfoUndefined, err
+		}
+	}
+
+	return phaseInfo, nil
+}
+
+// Calls the plugin and classifies any returned error. Permanent errors fail the task while any other error is returned
+// to be retried by the system.
+func (c SyncCorePlugin) do(ctx context.Context, tCtx core.TaskExecutionContext) (core.PhaseInfo, error) {
+	outputWriter := ioutils.NewBufferedOutputWriter(ctx, tCtx.OutputWriter())
+	phaseInfo, err := c.p.Do(ctx, syncPluginContext{TaskExecutionContext: tCtx, outputWriter: outputWriter})
+	if err != nil {
+		if code, permanent := webapi.IsPermanentError(err); permanent {
+			c.metrics.PermanentFailures.Inc(ctx)
+			logger.Infof(ctx, "Call to the remote service failed permanently. Error: %v", err)
+			return core.PhaseInfoFailure(code, err.Error(), nil), nil
+		}
+
+		c.metrics.RetryableFailures.Inc(ctx)
+		logger.Warnf(ctx, "Call to the remote service failed, it will be retried. Error: %v", err)
+		return core.PhaseInfoUndefined, errors.Wrapf(errors.DownstreamSystemError, err,
+			"failed to call remote service for plugin [%v]", c.GetID())
+	}
+
+	if phaseInfo.Phase() == core.PhaseSuccess && outputWriter.GetReader() != nil {
+		if err = tCtx.OutputWriter().Put(ctx, outputWriter.GetReader()); err != nil {
+			return core.PhaseInfoUndefined, err
+		}
+	}
+
+	return phaseInfo, nil
+}
+
+func (c SyncCorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	// Calls are made synchronously within Handle, there is no remote resource left to abort.
+	return nil
+}
+
+func (c SyncCorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	if len(c.p.GetConfig().ResourceQuotas) == 0 {
+		// If there are no defined quotas, there is nothing to cleanup.
+		return nil
+	}
+
+	logger.Infof(ctx, "Attempting to finalize resource [%v].",
+		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	return c.tokenAllocator.releaseToken(ctx, c.p, tCtx, c.metrics)
+}
+
+func createSyncRemotePlugin(pluginEntry webapi.SyncPluginEntry, c clock.Clock) core.PluginEntry {
+	return core.PluginEntry{
+		ID:                  pluginEntry.ID,
+		RegisteredTaskTypes: pluginEntry.SupportedTaskTypes,
+		IsDefault:           pluginEntry.IsDefault,
+		DefaultForTaskTypes: pluginEntry.DefaultForTaskTypes,
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (
+			core.Plugin, error) {
+			p, err := pluginEntry.PluginLoader(ctx, iCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = validateSyncConfig(p.GetConfig())
+			if err != nil {
+				return nil, fmt.Errorf("config validation failed. Error: %w", err)
+			}
+
+			if quotas := p.GetConfig().ResourceQuotas; len(quotas) > 0 {
+				for ns, quota := range quotas {
+					err := iCtx.ResourceRegistrar().RegisterResourceQuota(ctx, ns, quota)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return SyncCorePlugin{
+				id:             pluginEntry.ID,
+				p:              p,
+				writeLimiter:   newRateLimiter(p.GetConfig().WriteRateLimiter),
+				metrics:        newMetrics(iCtx.MetricsScope()),
+				tokenAllocator: newTokenAllocator(c),
+			}, nil
+		},
+	}
+}
+
+// CreateSyncRemotePlugin creates a core.PluginEntry that adapts the SyncPlugin loaded by pluginEntry.
+func CreateSyncRemotePlugin(pluginEntry webapi.SyncPluginEntry) core.PluginEntry {
+	return createSyncRemotePlugin(pluginEntry, clock.RealClock{})
+}
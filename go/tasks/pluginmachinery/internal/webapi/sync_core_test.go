@@ -0,0 +1,167 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	mocks2 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func newSyncTaskContext(state State) (*mocks2.TaskExecutionContext, *State, *ioMocks.OutputWriter) {
+	tID := &mocks2.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc")
+
+	tMeta := &mocks2.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	stateReader := &mocks2.PluginStateReader{}
+	stateReader.OnGetMatch(mock.Anything).Return(uint8(0), nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*State) = state
+	})
+
+	written := &State{}
+	stateWriter := &mocks2.PluginStateWriter{}
+	stateWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*written = *args.Get(1).(*State)
+	})
+
+	outputWriter := &ioMocks.OutputWriter{}
+
+	tCtx := &mocks2.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnPluginStateReader().Return(stateReader)
+	tCtx.OnPluginStateWriter().Return(stateWriter)
+	tCtx.OnOutputWriter().Return(outputWriter)
+	return tCtx, written, outputWriter
+}
+
+func newSyncCorePlugin(p webapi.SyncPlugin) SyncCorePlugin {
+	return SyncCorePlugin{
+		id:             "test",
+		p:              p,
+		writeLimiter:   rate.NewLimiter(rate.Inf, 1),
+		tokenAllocator: newTokenAllocator(testing2.NewFakeClock(time.Now())),
+		metrics:        newMetrics(promutils.NewTestScope()),
+	}
+}
+
+func TestSyncCorePlugin_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success writes outputs", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{})
+		outputs := ioutils.NewInMemoryOutputReader(nil, nil)
+		p.OnDoMatch(ctx, mock.Anything).Return(core.PhaseInfoSuccess(nil), nil).Run(func(args mock.Arguments) {
+			assert.NoError(t, args.Get(1).(webapi.TaskExecutionContext).OutputWriter().Put(ctx, outputs))
+		})
+
+		tCtx, written, outputWriter := newSyncTaskContext(State{})
+		outputWriter.OnPutMatch(ctx, outputs).Return(nil)
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, trns.Info().Phase())
+		assert.Equal(t, PhaseSucceeded, written.Phase)
+		outputWriter.AssertCalled(t, "Put", ctx, outputs)
+	})
+
+	t.Run("Failure doesn't write outputs", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{})
+		p.OnDoMatch(ctx, mock.Anything).Return(core.PhaseInfoFailure("code", "failed", nil), nil).Run(func(args mock.Arguments) {
+			assert.NoError(t, args.Get(1).(webapi.TaskExecutionContext).OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(nil, nil)))
+		})
+
+		tCtx, written, outputWriter := newSyncTaskContext(State{})
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, trns.Info().Phase())
+		assert.Equal(t, PhaseUserFailure, written.Phase)
+		outputWriter.AssertNotCalled(t, "Put", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Permanent error", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{})
+		p.OnDoMatch(ctx, mock.Anything).Return(core.PhaseInfo{}, webapi.NewPermanentError("BadRequest", fmt.Errorf("bad")))
+
+		tCtx, _, _ := newSyncTaskContext(State{})
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, trns.Info().Phase())
+		assert.Equal(t, "BadRequest", trns.Info().Err().GetCode())
+	})
+
+	t.Run("Retryable error", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{})
+		p.OnDoMatch(ctx, mock.Anything).Return(core.PhaseInfo{}, fmt.Errorf("unavailable"))
+
+		tCtx, _, _ := newSyncTaskContext(State{})
+		_, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{})
+
+		c := newSyncCorePlugin(p)
+		c.writeLimiter = rate.NewLimiter(rate.Limit(1), 0)
+		tCtx, written, _ := newSyncTaskContext(State{})
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseWaitingForResources, trns.Info().Phase())
+		assert.Equal(t, PhaseNotStarted, written.Phase)
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Waits for allocation token", func(t *testing.T) {
+		p := &mocks.SyncPlugin{}
+		p.OnGetConfig().Return(webapi.PluginConfig{ResourceQuotas: map[core.ResourceNamespace]int{"ns": 1}})
+		p.OnResourceRequirementsMatch(ctx, mock.Anything).Return("ns", core.ResourceConstraintsSpec{}, nil)
+
+		tCtx, written, _ := newSyncTaskContext(State{})
+		rm := &mocks2.ResourceManager{}
+		rm.OnAllocateResourceMatch(ctx, core.ResourceNamespace("ns"), "abc", mock.Anything).Return(core.AllocationStatusExhausted, nil)
+		tCtx.OnResourceManager().Return(rm)
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, trns.Info().Phase())
+		assert.Equal(t, PhaseNotStarted, written.Phase)
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateSyncRemotePlugin(t *testing.T) {
+	entry := CreateSyncRemotePlugin(webapi.SyncPluginEntry{
+		ID:                 "MyTestPlugin",
+		SupportedTaskTypes: []core.TaskType{"test-task"},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.SyncPlugin, error) {
+			p := &mocks.SyncPlugin{}
+			p.OnGetConfig().Return(webapi.PluginConfig{})
+			return p, nil
+		},
+	})
+
+	setupCtx := &mocks2.SetupContext{}
+	setupCtx.OnMetricsScope().Return(promutils.NewTestScope())
+	_, err := entry.LoadPlugin(context.Background(), setupCtx)
+	assert.Error(t, err)
+}
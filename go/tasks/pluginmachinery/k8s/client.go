@@ -8,6 +8,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -17,8 +18,9 @@ import (
 )
 
 type kubeClient struct {
-	client client.Client
-	cache  cache.Cache
+	client    client.Client
+	cache     cache.Cache
+	clientset kubernetes.Interface
 }
 
 func (k *kubeClient) GetClient() client.Client {
@@ -29,8 +31,12 @@ func (k *kubeClient) GetCache() cache.Cache {
 	return k.cache
 }
 
-func newKubeClient(c client.Client, cache cache.Cache) core.KubeClient {
-	return &kubeClient{client: c, cache: cache}
+func (k *kubeClient) GetClientset() kubernetes.Interface {
+	return k.clientset
+}
+
+func newKubeClient(c client.Client, cache cache.Cache, clientset kubernetes.Interface) core.KubeClient {
+	return &kubeClient{client: c, cache: cache, clientset: clientset}
 }
 
 type fallbackClientReader struct {
@@ -96,7 +102,7 @@ type Options struct {
 }
 
 // NewKubeClient creates a new KubeClient that caches reads and falls back to
-// make API calls on failure. Write calls are not cached.
+// make API calls on failure. Write calls are not cached. The client is also a core.ClientsetProvider.
 func NewKubeClient(config *rest.Config, options Options) (core.KubeClient, error) {
 	if options.MapperProvider == nil {
 		options.MapperProvider = func(c *rest.Config) (meta.RESTMapper, error) {
@@ -125,7 +131,12 @@ func NewKubeClient(config *rest.Config, options Options) (core.KubeClient, error
 		return nil, err
 	}
 
-	return newKubeClient(fallbackClient, cache), nil
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKubeClient(fallbackClient, cache, clientset), nil
 }
 
 // NewDefaultKubeClient creates a new KubeClient with default options set.
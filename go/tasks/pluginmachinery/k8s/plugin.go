@@ -74,6 +74,12 @@ type PluginContext interface {
 	TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata
 }
 
+// KubeClientProvider can be implemented by PluginContexts that give access to the kubernetes client of the plugin.
+type KubeClientProvider interface {
+	// Returns the kubernetes client of the plugin
+	KubeClient() pluginsCore.KubeClient
+}
+
 // Defines a simplified interface to author plugins for k8s resources.
 type Plugin interface {
 	// Defines a func to create a query object (typically just object and type meta portions) that's used to query k8s
@@ -49,6 +49,30 @@ func CreateRemotePlugin(pluginEntry webapi.PluginEntry) core.PluginEntry {
 	return internalRemote.CreateRemotePlugin(pluginEntry)
 }
 
+// Use this method to register SyncPlugins that call web APIs synchronously
+func (p *taskPluginRegistry) RegisterSyncRemotePlugin(info webapi.SyncPluginEntry) {
+	ctx := context.Background()
+	if info.ID == "" {
+		logger.Panicf(ctx, "ID is required attribute for sync plugin")
+	}
+
+	if len(info.SupportedTaskTypes) == 0 {
+		logger.Panicf(ctx, "SyncPlugin should be registered to handle at least one task type")
+	}
+
+	if info.PluginLoader == nil {
+		logger.Panicf(ctx, "PluginLoader cannot be nil")
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.corePlugin = append(p.corePlugin, internalRemote.CreateSyncRemotePlugin(info))
+}
+
+func CreateSyncRemotePlugin(pluginEntry webapi.SyncPluginEntry) core.PluginEntry {
+	return internalRemote.CreateSyncRemotePlugin(pluginEntry)
+}
+
 // Use this method to register Kubernetes Plugins
 func (p *taskPluginRegistry) RegisterK8sPlugin(info k8s.PluginEntry) {
 	if info.ID == "" {
@@ -107,6 +131,7 @@ type TaskPluginRegistry interface {
 	RegisterK8sPlugin(info k8s.PluginEntry)
 	RegisterCorePlugin(info core.PluginEntry)
 	RegisterRemotePlugin(info webapi.PluginEntry)
+	RegisterSyncRemotePlugin(info webapi.SyncPluginEntry)
 	GetCorePlugins() []core.PluginEntry
 	GetK8sPlugins() []k8s.PluginEntry
 }
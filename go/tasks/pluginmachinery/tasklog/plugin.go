@@ -13,6 +13,13 @@ type Input struct {
 	LogName           string `json:"logName"`
 	PodUnixStartTime  int64  `json:"podUnixStartTime"`
 	PodUnixFinishTime int64  `json:"podUnixFinishTime"`
+
+	// PodLabels and PodAnnotations are the metadata of the pod running the task, if any.
+	PodLabels      map[string]string `json:"podLabels,omitempty"`
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// TaskExecutionIdentifier identifies the execution of the task, if known.
+	TaskExecutionIdentifier *core.TaskExecutionIdentifier `json:"taskExecutionIdentifier,omitempty"`
 }
 
 // Output contains all task logs a plugin generates for a given Input.
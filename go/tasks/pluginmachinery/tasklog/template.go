@@ -1,69 +1,125 @@
 package tasklog
 
 import (
-	"fmt"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
-// A simple log plugin that supports templates in urls to build the final log link. Supported templates are:
+const (
+	labelsPrefix      = "labels."
+	annotationsPrefix = "annotations."
+)
+
+// A simple log plugin that supports templates in urls to build the final log link. Variables are case-insensitive.
+// Supported variables are:
 // {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
 // {{ .namespace }}: K8s namespace where the pod runs,
 // {{ .containerName }}: The container name that generated the log,
 // {{ .containerId }}: The container id docker/crio generated at run time,
 // {{ .logName }}: A deployment specific name where to expect the logs to be.
 // {{ .hostname }}: The hostname where the pod is running and where logs reside.
-// {{ .podUnixStartTime }}: The pod creation time (in unix seconds, not millis)
-// {{ .podUnixFinishTime }}: Don't have a good mechanism for this yet, but approximating with time.Now for now
+// {{ .podUnixStartTime }}: The pod creation time (in unix seconds, not millis), shifted by the start time offset.
+// {{ .podUnixFinishTime }}: The time the container finished, or the current time if it's still running (in unix
+// seconds, not millis), shifted by the finish time offset.
+// The values of the variables below are URL-escaped:
+// {{ .podUnixStartTimeMillis }}, {{ .podUnixFinishTimeMillis }}: The same times, in unix millis.
+// {{ .podRFC3339StartTime }}, {{ .podRFC3339FinishTime }}: The same times, formatted as RFC3339 in UTC.
+// {{ .executionProject }}, {{ .executionDomain }}, {{ .executionName }}: The workflow execution the task is part of.
+// {{ .nodeId }}, {{ .taskRetryAttempt }}: The node running the task and the attempt of the task.
+// {{ .taskProject }}, {{ .taskDomain }}, {{ .taskName }}, {{ .taskVersion }}: The task being executed.
+// {{ .labels.<key> }}, {{ .annotations.<key> }}: The label or annotation of the pod with the given key.
+// Unknown variables are left as they are.
 type TemplateLogPlugin struct {
-	templateUris  []string
-	messageFormat core.TaskLog_MessageFormat
+	templateUris     []string
+	messageFormat    core.TaskLog_MessageFormat
+	startTimeOffset  time.Duration
+	finishTimeOffset time.Duration
 }
 
-type regexValPair struct {
-	regex *regexp.Regexp
-	val   string
-}
+var templateVarRegex = regexp.MustCompile(`{{\s*[\.$]([^}\s]+)\s*}}`)
 
-type templateRegexes struct {
-	PodName           *regexp.Regexp
-	Namespace         *regexp.Regexp
-	ContainerName     *regexp.Regexp
-	ContainerID       *regexp.Regexp
-	LogName           *regexp.Regexp
-	Hostname          *regexp.Regexp
-	PodUnixStartTime  *regexp.Regexp
-	PodUnixFinishTime *regexp.Regexp
-}
+// getTemplateVars maps the lower-cased names of the variables available to templates to their values, escaped if they
+// need to be.
+func (s TemplateLogPlugin) getTemplateVars(input Input) map[string]string {
+	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
+	// stream. Therefore, we must also strip the prefix.
+	containerID := input.ContainerID
+	stripDelimiter := "://"
+	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
+		containerID = split[1]
+	}
 
-func mustInitTemplateRegexes() templateRegexes {
-	return templateRegexes{
-		PodName:           mustCreateRegex("podName"),
-		Namespace:         mustCreateRegex("namespace"),
-		ContainerName:     mustCreateRegex("containerName"),
-		ContainerID:       mustCreateRegex("containerID"),
-		LogName:           mustCreateRegex("logName"),
-		Hostname:          mustCreateRegex("hostname"),
-		PodUnixStartTime:  mustCreateRegex("podUnixStartTime"),
-		PodUnixFinishTime: mustCreateRegex("podUnixFinishTime"),
+	startTime := time.Unix(input.PodUnixStartTime, 0).Add(s.startTimeOffset).UTC()
+	finishTime := time.Unix(input.PodUnixFinishTime, 0).Add(s.finishTimeOffset).UTC()
+
+	vars := map[string]string{
+		"podname":                 input.PodName,
+		"namespace":               input.Namespace,
+		"containername":           input.ContainerName,
+		"containerid":             containerID,
+		"logname":                 input.LogName,
+		"hostname":                input.HostName,
+		"podunixstarttime":        strconv.FormatInt(startTime.Unix(), 10),
+		"podunixfinishtime":       strconv.FormatInt(finishTime.Unix(), 10),
+		"podunixstarttimemillis":  strconv.FormatInt(startTime.UnixNano()/int64(time.Millisecond), 10),
+		"podunixfinishtimemillis": strconv.FormatInt(finishTime.UnixNano()/int64(time.Millisecond), 10),
+		"podrfc3339starttime":     escape(startTime.Format(time.RFC3339)),
+		"podrfc3339finishtime":    escape(finishTime.Format(time.RFC3339)),
 	}
-}
 
-var regexes = mustInitTemplateRegexes()
+	if id := input.TaskExecutionIdentifier; id != nil {
+		vars["executionproject"] = escape(id.GetNodeExecutionId().GetExecutionId().GetProject())
+		vars["executiondomain"] = escape(id.GetNodeExecutionId().GetExecutionId().GetDomain())
+		vars["executionname"] = escape(id.GetNodeExecutionId().GetExecutionId().GetName())
+		vars["nodeid"] = escape(id.GetNodeExecutionId().GetNodeId())
+		vars["taskretryattempt"] = strconv.FormatUint(uint64(id.GetRetryAttempt()), 10)
+		vars["taskproject"] = escape(id.GetTaskId().GetProject())
+		vars["taskdomain"] = escape(id.GetTaskId().GetDomain())
+		vars["taskname"] = escape(id.GetTaskId().GetName())
+		vars["taskversion"] = escape(id.GetTaskId().GetVersion())
+	}
 
-func mustCreateRegex(varName string) *regexp.Regexp {
-	return regexp.MustCompile(fmt.Sprintf(`(?i){{\s*[\.$]%s\s*}}`, varName))
+	return vars
 }
 
-func replaceAll(template string, values []regexValPair) string {
-	for _, v := range values {
-		template = v.regex.ReplaceAllString(template, v.val)
-	}
+// render substitutes the variables of the template with their values. The values of labels and annotations are
+// URL-escaped.
+func render(template string, vars map[string]string, input Input) string {
+	return templateVarRegex.ReplaceAllStringFunc(template, func(match string) string {
+		name := templateVarRegex.FindStringSubmatch(match)[1]
+		lowerName := strings.ToLower(name)
+
+		var value string
+		var found bool
+		switch {
+		// Label and annotation keys are case-sensitive.
+		case strings.HasPrefix(lowerName, labelsPrefix):
+			value, found = input.PodLabels[name[len(labelsPrefix):]]
+			value = escape(value)
+		case strings.HasPrefix(lowerName, annotationsPrefix):
+			value, found = input.PodAnnotations[name[len(annotationsPrefix):]]
+			value = escape(value)
+		default:
+			value, found = vars[lowerName]
+		}
+
+		if !found {
+			return match
+		}
+
+		return value
+	})
+}
 
-	return template
+// escape escapes the value so that it can be placed anywhere in a url, using %20 for spaces as query escaping would
+// use + which isn't valid outside of the query.
+func escape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
 }
 
 func (s TemplateLogPlugin) GetTaskLog(podName, namespace, containerName, containerID, logName string, podUnixStartTime, podUnixFinishTime int64) (core.TaskLog, error) {
@@ -85,54 +141,12 @@ func (s TemplateLogPlugin) GetTaskLog(podName, namespace, containerName, contain
 }
 
 func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
-	// Container IDs are prefixed with docker://, cri-o://, etc. which is stripped by fluentd before pushing to a log
-	// stream. Therefore, we must also strip the prefix.
-	containerID := input.ContainerID
-	stripDelimiter := "://"
-	if split := strings.Split(input.ContainerID, stripDelimiter); len(split) > 1 {
-		containerID = split[1]
-	}
+	vars := s.getTemplateVars(input)
 
 	taskLogs := make([]*core.TaskLog, 0, len(s.templateUris))
 	for _, templateURI := range s.templateUris {
 		taskLogs = append(taskLogs, &core.TaskLog{
-			Uri: replaceAll(
-				templateURI,
-				[]regexValPair{
-					{
-						regex: regexes.PodName,
-						val:   input.PodName,
-					},
-					{
-						regex: regexes.Namespace,
-						val:   input.Namespace,
-					},
-					{
-						regex: regexes.ContainerName,
-						val:   input.ContainerName,
-					},
-					{
-						regex: regexes.ContainerID,
-						val:   containerID,
-					},
-					{
-						regex: regexes.LogName,
-						val:   input.LogName,
-					},
-					{
-						regex: regexes.Hostname,
-						val:   input.HostName,
-					},
-					{
-						regex: regexes.PodUnixStartTime,
-						val:   strconv.FormatInt(input.PodUnixStartTime, 10),
-					},
-					{
-						regex: regexes.PodUnixFinishTime,
-						val:   strconv.FormatInt(input.PodUnixFinishTime, 10),
-					},
-				},
-			),
+			Uri:           render(templateURI, vars, input),
 			Name:          input.LogName,
 			MessageFormat: s.messageFormat,
 		})
@@ -143,17 +157,21 @@ func (s TemplateLogPlugin) GetTaskLogs(input Input) (Output, error) {
 	}, nil
 }
 
-// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uri and message format. Supported
-// templates are:
-// {{ .podName }}: Gets the pod name as it shows in k8s dashboard,
-// {{ .namespace }}: K8s namespace where the pod runs,
-// {{ .containerName }}: The container name that generated the log,
-// {{ .containerId }}: The container id docker/crio generated at run time,
-// {{ .logName }}: A deployment specific name where to expect the logs to be.
-// {{ .hostname }}: The hostname where the pod is running and where logs reside.
+// NewTemplateLogPlugin creates a template-based log plugin with the provided template Uri and message format. See
+// TemplateLogPlugin for the supported variables.
 func NewTemplateLogPlugin(templateUris []string, messageFormat core.TaskLog_MessageFormat) TemplateLogPlugin {
+	return NewTemplateLogPluginWithTimeWindow(templateUris, messageFormat, 0, 0)
+}
+
+// NewTemplateLogPluginWithTimeWindow creates a template-based log plugin that shifts the start and finish times of the
+// pod by the given offsets, e.g. to start the time window of the links 5 minutes before the pod started.
+func NewTemplateLogPluginWithTimeWindow(templateUris []string, messageFormat core.TaskLog_MessageFormat,
+	startTimeOffset, finishTimeOffset time.Duration) TemplateLogPlugin {
+
 	return TemplateLogPlugin{
-		templateUris:  templateUris,
-		messageFormat: messageFormat,
+		templateUris:     templateUris,
+		messageFormat:    messageFormat,
+		startTimeOffset:  startTimeOffset,
+		finishTimeOffset: finishTimeOffset,
 	}
 }
@@ -2,8 +2,8 @@ package tasklog
 
 import (
 	"reflect"
-
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/go-test/deep"
@@ -27,10 +27,19 @@ func TestTemplateLog(t *testing.T) {
 	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.f-uuid-driver_flyteexamples-production_spark-kubernetes-driver-abc.log", tl.Uri)
 }
 
-// Latest Run: Benchmark_mustInitTemplateRegexes-16    	   45960	     26914 ns/op
-func Benchmark_mustInitTemplateRegexes(b *testing.B) {
+// Latest Run: BenchmarkTemplateLogPlugin_GetTaskLogs    	  117814	     10063 ns/op
+func BenchmarkTemplateLogPlugin_GetTaskLogs(b *testing.B) {
+	p := NewTemplateLogPlugin([]string{"https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#logEventViewer:group=/flyte-production/kubernetes;stream=var.log.containers.{{.podName}}_{{.namespace}}_{{.containerName}}-{{.containerId}}.log"}, core.TaskLog_JSON)
+	input := Input{
+		PodName:       "f-uuid-driver",
+		Namespace:     "flyteexamples-production",
+		ContainerName: "spark-kubernetes-driver",
+		ContainerID:   "cri-o://abc",
+		LogName:       "main_logs",
+	}
+
 	for i := 0; i < b.N; i++ {
-		mustInitTemplateRegexes()
+		_, _ = p.GetTaskLogs(input)
 	}
 }
 
@@ -233,3 +242,70 @@ func TestTemplateLogPlugin_NewTaskLog(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateLogPlugin_ExecutionIdentity(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{"https://grafana.net/explore?query={{ .executionProject }}/{{ .executionDomain }}/{{ .executionName }}/{{ .nodeId }}/{{ .taskRetryAttempt }}&task={{ .taskProject }}/{{ .taskDomain }}/{{ .taskName }}/{{ .taskVersion }}"}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		LogName: "main_logs",
+		TaskExecutionIdentifier: &core.TaskExecutionIdentifier{
+			TaskId: &core.Identifier{
+				ResourceType: core.ResourceType_TASK,
+				Project:      "flytesnacks",
+				Domain:       "development",
+				Name:         "my.task",
+				Version:      "v1",
+			},
+			NodeExecutionId: &core.NodeExecutionIdentifier{
+				NodeId: "n0",
+				ExecutionId: &core.WorkflowExecutionIdentifier{
+					Project: "flytesnacks",
+					Domain:  "development",
+					Name:    "abc",
+				},
+			},
+			RetryAttempt: 2,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, o.TaskLogs, 1)
+	assert.Equal(t, "https://grafana.net/explore?query=flytesnacks/development/abc/n0/2&task=flytesnacks/development/my.task/v1", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLogPlugin_LabelsAndAnnotations(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{"https://logs.net/?app={{ .labels.app.kubernetes.io/name }}&team={{ .annotations.Team }}&missing={{ .labels.missing }}&unknown={{ .unknown }}"}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		PodLabels: map[string]string{
+			"app.kubernetes.io/name": "my app",
+		},
+		PodAnnotations: map[string]string{
+			"Team": "a&b=c",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs.net/?app=my%20app&team=a%26b%3Dc&missing={{ .labels.missing }}&unknown={{ .unknown }}", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLogPlugin_TimeWindow(t *testing.T) {
+	p := NewTemplateLogPluginWithTimeWindow([]string{"https://logs.net/?from={{ .podUnixStartTime }}&to={{ .podUnixFinishTime }}&fromMs={{ .podUnixStartTimeMillis }}&toMs={{ .podUnixFinishTimeMillis }}&start={{ .podRFC3339StartTime }}&end={{ .podRFC3339FinishTime }}"},
+		core.TaskLog_JSON, -5*time.Minute, time.Minute)
+	o, err := p.GetTaskLogs(Input{
+		PodUnixStartTime:  1600000300,
+		PodUnixFinishTime: 1600000400,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs.net/?from=1600000000&to=1600000460&fromMs=1600000000000&toMs=1600000460000&start=2020-09-13T12%3A26%3A40Z&end=2020-09-13T12%3A34%3A20Z", o.TaskLogs[0].Uri)
+}
+
+func TestTemplateLogPlugin_UnescapedVariables(t *testing.T) {
+	p := NewTemplateLogPlugin([]string{"https://logs.net/#query={{ .logName }}&host={{ .hostname }}"}, core.TaskLog_JSON)
+	o, err := p.GetTaskLogs(Input{
+		LogName:  "kubernetes/flyte logs",
+		HostName: "node-1:8080",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://logs.net/#query=kubernetes/flyte logs&host=node-1:8080", o.TaskLogs[0].Uri)
+}
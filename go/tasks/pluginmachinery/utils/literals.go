@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// ParseLiteral converts the text representation of a value, as returned by remote systems, to a literal of the given
+// simple type.
+func ParseLiteral(literalType *core.LiteralType, value string) (*core.Literal, error) {
+	var primitive *core.Primitive
+	switch literalType.GetSimple() {
+	case core.SimpleType_INTEGER:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		primitive = &core.Primitive{Value: &core.Primitive_Integer{Integer: i}}
+	case core.SimpleType_FLOAT:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		primitive = &core.Primitive{Value: &core.Primitive_FloatValue{FloatValue: f}}
+	case core.SimpleType_BOOLEAN:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+
+		primitive = &core.Primitive{Value: &core.Primitive_Boolean{Boolean: b}}
+	case core.SimpleType_STRING:
+		primitive = &core.Primitive{Value: &core.Primitive_StringValue{StringValue: value}}
+	default:
+		return nil, fmt.Errorf("unsupported type [%v]", literalType)
+	}
+
+	return &core.Literal{
+		Value: &core.Literal_Scalar{
+			Scalar: &core.Scalar{
+				Value: &core.Scalar_Primitive{Primitive: primitive},
+			},
+		},
+	}, nil
+}
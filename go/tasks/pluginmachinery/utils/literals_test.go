@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLiteral(t *testing.T) {
+	simpleType := func(simple core.SimpleType) *core.LiteralType {
+		return &core.LiteralType{Type: &core.LiteralType_Simple{Simple: simple}}
+	}
+
+	t.Run("integer", func(t *testing.T) {
+		literal, err := ParseLiteral(simpleType(core.SimpleType_INTEGER), "42")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), literal.GetScalar().GetPrimitive().GetInteger())
+	})
+
+	t.Run("float", func(t *testing.T) {
+		literal, err := ParseLiteral(simpleType(core.SimpleType_FLOAT), "1.5")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, literal.GetScalar().GetPrimitive().GetFloatValue())
+	})
+
+	t.Run("boolean", func(t *testing.T) {
+		literal, err := ParseLiteral(simpleType(core.SimpleType_BOOLEAN), "true")
+		assert.NoError(t, err)
+		assert.True(t, literal.GetScalar().GetPrimitive().GetBoolean())
+	})
+
+	t.Run("string", func(t *testing.T) {
+		literal, err := ParseLiteral(simpleType(core.SimpleType_STRING), "abc")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", literal.GetScalar().GetPrimitive().GetStringValue())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := ParseLiteral(simpleType(core.SimpleType_INTEGER), "abc")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := ParseLiteral(simpleType(core.SimpleType_DATETIME), "2021-01-01")
+		assert.Error(t, err)
+	})
+}
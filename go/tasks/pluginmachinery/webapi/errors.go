@@ -0,0 +1,35 @@
+package webapi
+
+import (
+	"errors"
+)
+
+// permanentError marks a failed web API call that retrying won't fix.
+type permanentError struct {
+	code string
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+// NewPermanentError wraps err to indicate that the task should fail with the given error code instead of retrying the
+// call (e.g. the remote service rejected the request as invalid or unauthorized).
+func NewPermanentError(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return permanentError{code: code, error: err}
+}
+
+// IsPermanentError returns the error code and true if err, or any error it wraps, was created by NewPermanentError.
+func IsPermanentError(err error) (code string, permanent bool) {
+	e := permanentError{}
+	if errors.As(err, &e) {
+		return e.code, true
+	}
+
+	return "", false
+}
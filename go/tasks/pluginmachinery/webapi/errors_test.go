@@ -0,0 +1,19 @@
+package webapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPermanentError(t *testing.T) {
+	assert.Nil(t, NewPermanentError("code", nil))
+
+	code, permanent := IsPermanentError(fmt.Errorf("wrapped: %w", NewPermanentError("BadRequest", fmt.Errorf("bad"))))
+	assert.True(t, permanent)
+	assert.Equal(t, "BadRequest", code)
+
+	_, permanent = IsPermanentError(fmt.Errorf("transient"))
+	assert.False(t, permanent)
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BatchGetter is an autogenerated mock type for the BatchGetter type
+type BatchGetter struct {
+	mock.Mock
+}
+
+type BatchGetter_BatchGet struct {
+	*mock.Call
+}
+
+func (_m BatchGetter_BatchGet) Return(latest []interface{}, err error) *BatchGetter_BatchGet {
+	return &BatchGetter_BatchGet{Call: _m.Call.Return(latest, err)}
+}
+
+func (_m *BatchGetter) OnBatchGet(ctx context.Context, tCtxs []webapi.GetContext) *BatchGetter_BatchGet {
+	c := _m.On("BatchGet", ctx, tCtxs)
+	return &BatchGetter_BatchGet{Call: c}
+}
+
+func (_m *BatchGetter) OnBatchGetMatch(matchers ...interface{}) *BatchGetter_BatchGet {
+	c := _m.On("BatchGet", matchers...)
+	return &BatchGetter_BatchGet{Call: c}
+}
+
+// BatchGet provides a mock function with given fields: ctx, tCtxs
+func (_m *BatchGetter) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) ([]interface{}, error) {
+	ret := _m.Called(ctx, tCtxs)
+
+	var r0 []interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, []webapi.GetContext) []interface{}); ok {
+		r0 = rf(ctx, tCtxs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []webapi.GetContext) error); ok {
+		r1 = rf(ctx, tCtxs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
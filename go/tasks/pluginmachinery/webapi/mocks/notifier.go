@@ -0,0 +1,79 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+type Notifier_ResourceKey struct {
+	*mock.Call
+}
+
+func (_m Notifier_ResourceKey) Return(_a0 string) *Notifier_ResourceKey {
+	return &Notifier_ResourceKey{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Notifier) OnResourceKey(resourceMeta interface{}) *Notifier_ResourceKey {
+	c := _m.On("ResourceKey", resourceMeta)
+	return &Notifier_ResourceKey{Call: c}
+}
+
+func (_m *Notifier) OnResourceKeyMatch(matchers ...interface{}) *Notifier_ResourceKey {
+	c := _m.On("ResourceKey", matchers...)
+	return &Notifier_ResourceKey{Call: c}
+}
+
+// ResourceKey provides a mock function with given fields: resourceMeta
+func (_m *Notifier) ResourceKey(resourceMeta interface{}) string {
+	ret := _m.Called(resourceMeta)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(interface{}) string); ok {
+		r0 = rf(resourceMeta)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type Notifier_Subscribe struct {
+	*mock.Call
+}
+
+func (_m Notifier_Subscribe) Return(_a0 error) *Notifier_Subscribe {
+	return &Notifier_Subscribe{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Notifier) OnSubscribe(ctx context.Context, handler webapi.CompletionHandler) *Notifier_Subscribe {
+	c := _m.On("Subscribe", ctx, handler)
+	return &Notifier_Subscribe{Call: c}
+}
+
+func (_m *Notifier) OnSubscribeMatch(matchers ...interface{}) *Notifier_Subscribe {
+	c := _m.On("Subscribe", matchers...)
+	return &Notifier_Subscribe{Call: c}
+}
+
+// Subscribe provides a mock function with given fields: ctx, handler
+func (_m *Notifier) Subscribe(ctx context.Context, handler webapi.CompletionHandler) error {
+	ret := _m.Called(ctx, handler)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webapi.CompletionHandler) error); ok {
+		r0 = rf(ctx, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
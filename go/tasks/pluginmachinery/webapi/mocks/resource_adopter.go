@@ -0,0 +1,68 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResourceAdopter is an autogenerated mock type for the ResourceAdopter type
+type ResourceAdopter struct {
+	mock.Mock
+}
+
+type ResourceAdopter_Lookup struct {
+	*mock.Call
+}
+
+func (_m ResourceAdopter_Lookup) Return(resourceMeta interface{}, optionalResource interface{}, found bool, err error) *ResourceAdopter_Lookup {
+	return &ResourceAdopter_Lookup{Call: _m.Call.Return(resourceMeta, optionalResource, found, err)}
+}
+
+func (_m *ResourceAdopter) OnLookup(ctx context.Context, tCtx webapi.TaskExecutionContextReader) *ResourceAdopter_Lookup {
+	c := _m.On("Lookup", ctx, tCtx)
+	return &ResourceAdopter_Lookup{Call: c}
+}
+
+func (_m *ResourceAdopter) OnLookupMatch(matchers ...interface{}) *ResourceAdopter_Lookup {
+	c := _m.On("Lookup", matchers...)
+	return &ResourceAdopter_Lookup{Call: c}
+}
+
+// Lookup provides a mock function with given fields: ctx, tCtx
+func (_m *ResourceAdopter) Lookup(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (interface{}, interface{}, bool, error) {
+	ret := _m.Called(ctx, tCtx)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, webapi.TaskExecutionContextReader) interface{}); ok {
+		r0 = rf(ctx, tCtx)
+	} else {
+		r0 = ret.Get(0).(interface{})
+	}
+
+	var r1 interface{}
+	if rf, ok := ret.Get(1).(func(context.Context, webapi.TaskExecutionContextReader) interface{}); ok {
+		r1 = rf(ctx, tCtx)
+	} else {
+		r1 = ret.Get(1).(interface{})
+	}
+
+	var r2 bool
+	if rf, ok := ret.Get(2).(func(context.Context, webapi.TaskExecutionContextReader) bool); ok {
+		r2 = rf(ctx, tCtx)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, webapi.TaskExecutionContextReader) error); ok {
+		r3 = rf(ctx, tCtx)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
@@ -6,9 +6,8 @@ import (
 	context "context"
 
 	core "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
-	mock "github.com/stretchr/testify/mock"
-
 	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // SyncPlugin is an autogenerated mock type for the SyncPlugin type
@@ -86,3 +85,49 @@ func (_m *SyncPlugin) GetConfig() webapi.PluginConfig {
 
 	return r0
 }
+
+type SyncPlugin_ResourceRequirements struct {
+	*mock.Call
+}
+
+func (_m SyncPlugin_ResourceRequirements) Return(namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) *SyncPlugin_ResourceRequirements {
+	return &SyncPlugin_ResourceRequirements{Call: _m.Call.Return(namespace, constraints, err)}
+}
+
+func (_m *SyncPlugin) OnResourceRequirements(ctx context.Context, tCtx webapi.TaskExecutionContextReader) *SyncPlugin_ResourceRequirements {
+	c := _m.On("ResourceRequirements", ctx, tCtx)
+	return &SyncPlugin_ResourceRequirements{Call: c}
+}
+
+func (_m *SyncPlugin) OnResourceRequirementsMatch(matchers ...interface{}) *SyncPlugin_ResourceRequirements {
+	c := _m.On("ResourceRequirements", matchers...)
+	return &SyncPlugin_ResourceRequirements{Call: c}
+}
+
+// ResourceRequirements provides a mock function with given fields: ctx, tCtx
+func (_m *SyncPlugin) ResourceRequirements(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (core.ResourceNamespace, core.ResourceConstraintsSpec, error) {
+	ret := _m.Called(ctx, tCtx)
+
+	var r0 core.ResourceNamespace
+	if rf, ok := ret.Get(0).(func(context.Context, webapi.TaskExecutionContextReader) core.ResourceNamespace); ok {
+		r0 = rf(ctx, tCtx)
+	} else {
+		r0 = ret.Get(0).(core.ResourceNamespace)
+	}
+
+	var r1 core.ResourceConstraintsSpec
+	if rf, ok := ret.Get(1).(func(context.Context, webapi.TaskExecutionContextReader) core.ResourceConstraintsSpec); ok {
+		r1 = rf(ctx, tCtx)
+	} else {
+		r1 = ret.Get(1).(core.ResourceConstraintsSpec)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, webapi.TaskExecutionContextReader) error); ok {
+		r2 = rf(ctx, tCtx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,90 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// CompletionEvent notifies that a remote resource has changed state, typically because it has completed.
+type CompletionEvent struct {
+	// ResourceKey identifies the resource. It must match the key returned by Notifier.ResourceKey for the ResourceMeta
+	// of the resource.
+	ResourceKey string `json:"resourceKey"`
+
+	// Resource optionally carries the latest version of the resource. If nil, it'll be retrieved using Get.
+	Resource Resource `json:"-"`
+}
+
+// CompletionHandler is invoked for each event a Notifier receives.
+type CompletionHandler func(ctx context.Context, event CompletionEvent)
+
+// Notifier can optionally be implemented by AsyncPlugins whose remote service can push updates (e.g. through an HTTP
+// callback or a Pub/Sub/SQS subscription). Events cause the affected executions to be re-evaluated right away instead
+// of waiting for the next sync. Polling continues as a safety net for missed events, at Caching.NotifierResyncInterval
+// rather than Caching.ResyncInterval.
+type Notifier interface {
+	// ResourceKey returns the key events about the resource identified by resourceMeta will carry.
+	ResourceKey(resourceMeta ResourceMeta) string
+
+	// Subscribe starts delivering events to handler until ctx is canceled. It's called once when the plugin is loaded.
+	Subscribe(ctx context.Context, handler CompletionHandler) error
+}
+
+// InMemoryEventBus is an in-process stand-in for a Pub/Sub or SQS subscription. Plugins can use it to implement
+// Notifier.Subscribe and publish to it from their own listeners. It also implements http.Handler to accept
+// CompletionEvents, as JSON, from HTTP callbacks.
+type InMemoryEventBus struct {
+	lock     sync.RWMutex
+	handlers []subscription
+}
+
+type subscription struct {
+	ctx     context.Context
+	handler CompletionHandler
+}
+
+// Subscribe registers handler to receive all events published until ctx is canceled.
+func (b *InMemoryEventBus) Subscribe(ctx context.Context, handler CompletionHandler) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.handlers = append(b.handlers, subscription{ctx: ctx, handler: handler})
+	return nil
+}
+
+// Publish synchronously delivers event to all active subscribers.
+func (b *InMemoryEventBus) Publish(event CompletionEvent) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, s := range b.handlers {
+		if s.ctx.Err() == nil {
+			s.handler(s.ctx, event)
+		}
+	}
+}
+
+func (b *InMemoryEventBus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	event := CompletionEvent{}
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil || len(event.ResourceKey) == 0 {
+		logger.Infof(r.Context(), "Rejecting invalid completion event. Error: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	b.Publish(event)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{}
+}
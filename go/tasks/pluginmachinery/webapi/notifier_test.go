@@ -0,0 +1,41 @@
+package webapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryEventBus(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var received []string
+	assert.NoError(t, bus.Subscribe(ctx, func(ctx context.Context, event CompletionEvent) {
+		received = append(received, event.ResourceKey)
+	}))
+
+	bus.Publish(CompletionEvent{ResourceKey: "a"})
+
+	t.Run("HTTP callback", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		bus.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"resourceKey": "b"}`)))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+
+		rec = httptest.NewRecorder()
+		bus.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		bus.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	cancel()
+	bus.Publish(CompletionEvent{ResourceKey: "c"})
+	assert.Equal(t, []string{"a", "b"}, received)
+}
@@ -46,10 +46,38 @@ type PluginEntry struct {
 	DefaultForTaskTypes []pluginsCore.TaskType
 }
 
+// A Lazy loading function, that will load a SyncPlugin. It is guaranteed that the plugin loader will be called before
+// any Handle/Abort/Finalize functions are invoked
+type SyncPluginLoader func(ctx context.Context, iCtx PluginSetupContext) (SyncPlugin, error)
+
+// SyncPluginEntry is a structure that is used to indicate to the system a SyncPlugin
+type SyncPluginEntry struct {
+	// ID/Name of the plugin. This will be used to identify this plugin and has to be unique in the entire system
+	// All functions like enabling and disabling a plugin use this ID
+	ID pluginsCore.TaskType
+
+	// A list of all the task types for which this plugin is applicable.
+	SupportedTaskTypes []pluginsCore.TaskType
+
+	// An instance of the plugin
+	PluginLoader SyncPluginLoader
+
+	// Boolean that indicates if this plugin can be used as the default for unknown task types. There can only be
+	// one default in the system
+	IsDefault bool
+
+	// A list of all task types for which this plugin should be default handler when multiple registered plugins
+	// support the same task type. This must be a subset of RegisteredTaskTypes and at most one default per task type
+	// is supported.
+	DefaultForTaskTypes []pluginsCore.TaskType
+}
+
 // PluginSetupContext is the interface made available to the plugin loader when initializing the plugin.
 type PluginSetupContext interface {
 	// a metrics scope to publish stats under
 	MetricsScope() promutils.Scope
+	// Returns a secret manager that can retrieve configured secrets for this plugin
+	SecretManager() pluginsCore.SecretManager
 }
 
 type TaskExecutionContextReader interface {
@@ -144,11 +172,44 @@ type AsyncPlugin interface {
 	Status(ctx context.Context, tCtx StatusContext) (phase pluginsCore.PhaseInfo, err error)
 }
 
+// BatchGetter can optionally be implemented by AsyncPlugins whose remote service can retrieve many resources in a
+// single call. When implemented, the background sync fetches resources in batches of up to CachingConfig.BatchSize
+// instead of calling Get once per resource.
+type BatchGetter interface {
+	// BatchGet retrieves the resources that match the keys in tCtxs. The returned slice must have the same length as
+	// tCtxs, latest[i] being the resource for tCtxs[i]. Entries left nil are retrieved individually through Get. If a
+	// non-nil error is returned, the entire batch is considered to have failed.
+	BatchGet(ctx context.Context, tCtxs []GetContext) (latest []Resource, err error)
+}
+
+// ResourceAdopter can optionally be implemented by AsyncPlugins whose remote service identifies resources by an
+// idempotency key derived from the task execution (e.g. a client request token or a deterministic job id). Before
+// creating a resource, the system looks up one that may have been created by a previous attempt whose state was never
+// persisted (e.g. because propeller restarted right after Create) and adopts it instead of launching it again.
+type ResourceAdopter interface {
+	// Lookup returns the resource previously created for the task execution, if any. found must be false if no such
+	// resource exists, in which case Create is called. Like Create, it's advisable to also return the retrieved resource
+	// in optionalResource so that the system can terminate early if it has already been executed/failed. A non-nil
+	// error is considered a system error and the lookup will be retried.
+	Lookup(ctx context.Context, tCtx TaskExecutionContextReader) (resourceMeta ResourceMeta, optionalResource Resource,
+		found bool, err error)
+}
+
 // SyncPlugin defines the interface for plugins that call Web APIs synchronously.
 type SyncPlugin interface {
 	// GetConfig gets the loaded plugin config. This will be used to control the interactions with the remote service.
 	GetConfig() PluginConfig
 
-	// Do performs the action associated with this plugin.
+	// ResourceRequirements analyzes the task to execute and determines the ResourceNamespace to be used when allocating
+	// tokens. It's only called if the plugin config defines ResourceQuotas.
+	ResourceRequirements(ctx context.Context, tCtx TaskExecutionContextReader) (
+		namespace pluginsCore.ResourceNamespace, constraints pluginsCore.ResourceConstraintsSpec, err error)
+
+	// Do performs the action associated with this plugin. The call is subject to the WriteRateLimiter in the plugin
+	// config and should complete quickly since it runs on the critical path of the workflow. Outputs written to the
+	// OutputWriter are only persisted if the returned phase is successful.
+	// If the remote API failed with an error that retrying won't fix (e.g. a bad request), the plugin should return an
+	// error created by NewPermanentError to fail the task. Any other error is considered a system error and the call
+	// will be retried.
 	Do(ctx context.Context, tCtx TaskExecutionContext) (phase pluginsCore.PhaseInfo, err error)
 }
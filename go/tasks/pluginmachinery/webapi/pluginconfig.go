@@ -13,9 +13,11 @@ var (
 	DefaultPluginConfig = PluginConfig{
 		Caching: CachingConfig{
 			Size:              100000,
-			ResyncInterval:    config.Duration{Duration: 30 * time.Second},
-			Workers:           10,
+			ResyncInterval:         config.Duration{Duration: 30 * time.Second},
+			NotifierResyncInterval: config.Duration{Duration: 5 * time.Minute},
+			Workers:                10,
 			MaxSystemFailures: 5,
+			BatchSize:         50,
 		},
 		ReadRateLimiter: RateLimiterConfig{
 			QPS:   30,
@@ -44,12 +46,19 @@ type CachingConfig struct {
 	// How often to query for objects in remote service.
 	ResyncInterval config.Duration `json:"resyncInterval" pflag:",Defines the sync interval."`
 
+	// NotifierResyncInterval replaces ResyncInterval for plugins implementing Notifier. Updates are pushed to them, the
+	// sync only catches the events that were missed and can therefore run less often. Unset, ResyncInterval is used.
+	NotifierResyncInterval config.Duration `json:"notifierResyncInterval" pflag:",Defines the sync interval of plugins notified of updates."`
+
 	// Workers control how many parallel workers should start up to retrieve updates
 	// about resources.
 	Workers int `json:"workers" pflag:",Defines the number of workers to start up to process items."`
 
 	// MaxSystemFailures defines the number of failures to fetch a task before failing the task.
 	MaxSystemFailures int `json:"maxSystemFailures" pflag:",Defines the number of failures to fetch a task before failing the task."`
+
+	// BatchSize defines the max number of resources to retrieve in a single call for plugins that implement BatchGetter.
+	BatchSize int `json:"batchSize" pflag:",Defines the max number of resources to retrieve in a single call for plugins that support batching."`
 }
 
 type ResourceQuotas map[core.ResourceNamespace]int
@@ -56,7 +56,9 @@ func (cfg PluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writeRateLimiter.burst"), DefaultPluginConfig.WriteRateLimiter.Burst, "Defines the maximum burst size.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.size"), DefaultPluginConfig.Caching.Size, "Defines the maximum number of items to cache.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.resyncInterval"), DefaultPluginConfig.Caching.ResyncInterval.String(), "Defines the sync interval.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.notifierResyncInterval"), DefaultPluginConfig.Caching.NotifierResyncInterval.String(), "Defines the sync interval of plugins notified of updates.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.workers"), DefaultPluginConfig.Caching.Workers, "Defines the number of workers to start up to process items.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.maxSystemFailures"), DefaultPluginConfig.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.batchSize"), DefaultPluginConfig.Caching.BatchSize, "Defines the max number of resources to retrieve in a single call for plugins that support batching.")
 	return cmdFlags
 }
@@ -183,6 +183,20 @@ func TestPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_caching.notifierResyncInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.Caching.NotifierResyncInterval.String()
+
+			cmdFlags.Set("caching.notifierResyncInterval", testValue)
+			if vString, err := cmdFlags.GetString("caching.notifierResyncInterval"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.Caching.NotifierResyncInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_caching.workers", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -211,4 +225,18 @@ func TestPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_caching.batchSize", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caching.batchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("caching.batchSize"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Caching.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
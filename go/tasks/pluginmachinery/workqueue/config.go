@@ -1,8 +1,41 @@
 package workqueue
 
+import (
+	"github.com/flyteorg/flytestdlib/config"
+)
+
 // Config for the queue
 type Config struct {
-	Workers            int `json:"workers" pflag:",Number of concurrent workers to start processing the queue."`
-	MaxRetries         int `json:"maxRetries" pflag:",Maximum number of retries per item."`
-	IndexCacheMaxItems int `json:"maxItems" pflag:",Maximum number of entries to keep in the index."`
+	Workers            int           `json:"workers" pflag:",Number of concurrent workers to start processing the queue."`
+	MaxRetries         int           `json:"maxRetries" pflag:",Maximum number of retries per item."`
+	IndexCacheMaxItems int           `json:"maxItems" pflag:",Maximum number of entries to keep in the index."`
+	Backoff            BackoffConfig `json:"backoff" pflag:",Config for delaying retries of failed items."`
+}
+
+// BackoffConfig for retrying failed items. Retries are delayed by base * 2^(retries-1), capped at max, plus a random
+// jitter of up to jitterPercent of the delay. A zero base retries items immediately.
+type BackoffConfig struct {
+	Base          config.Duration `json:"base" pflag:",Delay before the first retry of a failed item."`
+	Max           config.Duration `json:"max" pflag:",Maximum delay between retries of a failed item."`
+	JitterPercent int             `json:"jitterPercent" pflag:",Maximum percentage of the delay to randomly add to each retry delay."`
+}
+
+const (
+	// PersistenceTypeStorage keeps item statuses in the data store, under the configured prefix.
+	PersistenceTypeStorage = "storage"
+	// PersistenceTypeBoltDB keeps item statuses in a local BoltDB file, at the configured path.
+	PersistenceTypeBoltDB = "boltdb"
+)
+
+// PersistenceConfig for the optional index that keeps terminal item statuses across restarts. It's only honored by queues
+// created through NewPersistentIndexedWorkQueue.
+type PersistenceConfig struct {
+	Enabled bool            `json:"enabled" pflag:",Enables persisting terminal item statuses so they survive restarts and evictions."`
+	Type    string          `json:"type" pflag:",Where item statuses are persisted. One of storage or boltdb."`
+	Prefix  string          `json:"prefix" pflag:",Storage prefix under which item statuses are persisted. Only used by the storage type."`
+	Path    string          `json:"path" pflag:",Path of the BoltDB file item statuses are persisted in. Only used by the boltdb type."`
+	TTL     config.Duration `json:"ttl" pflag:",Duration after which a persisted item status is discarded and the item is processed again."`
+	// Data stores can't delete objects, so expired statuses are only removed from BoltDB files. Records under a storage
+	// prefix should be expired by a lifecycle rule of the bucket.
+	GCInterval config.Duration `json:"gcInterval" pflag:",Interval at which expired item statuses are removed from BoltDB files. Defaults to the TTL."`
 }
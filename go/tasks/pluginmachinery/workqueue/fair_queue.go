@@ -0,0 +1,221 @@
+package workqueue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+//go:generate enumer --type=Priority --trimprefix=Priority
+
+// Priority determines the lane a work item is queued in. Workers always drain higher priority lanes first.
+type Priority uint8
+
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+)
+
+const priorityLanes = int(PriorityLow) + 1
+
+// QueueOption customizes how a single work item is scheduled.
+type QueueOption func(w *workItemWrapper)
+
+// WithOwner sets the key that items are grouped by for fairness. Workers round-robin across owners within a lane so
+// that an owner queuing many items doesn't delay other owners. Defaults to the execution and node ids in the context.
+func WithOwner(owner string) QueueOption {
+	return func(w *workItemWrapper) {
+		w.owner = owner
+	}
+}
+
+// WithPriority sets the lane the item is queued in. Defaults to PriorityNormal.
+func WithPriority(priority Priority) QueueOption {
+	return func(w *workItemWrapper) {
+		w.priority = priority
+	}
+}
+
+func defaultOwner(ctx context.Context) string {
+	parts := make([]string, 0, 2)
+	for _, key := range []contextutils.Key{contextutils.ExecIDKey, contextutils.NodeIDKey} {
+		if v := ctx.Value(key); v != nil {
+			parts = append(parts, fmt.Sprintf("%v", v))
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+type laneMetrics struct {
+	Depth    *prometheus.GaugeVec
+	WaitTime *prometheus.HistogramVec
+}
+
+// lane holds the pending items of a single priority, in FIFO order per owner.
+type lane struct {
+	owners []string
+	items  map[string][]*workItemWrapper
+	next   int
+}
+
+func (l *lane) push(item *workItemWrapper) {
+	if _, found := l.items[item.owner]; !found {
+		l.owners = append(l.owners, item.owner)
+	}
+
+	l.items[item.owner] = append(l.items[item.owner], item)
+}
+
+// Pops the oldest item of the next owner in round-robin order.
+func (l *lane) pop() *workItemWrapper {
+	if len(l.owners) == 0 {
+		return nil
+	}
+
+	if l.next >= len(l.owners) {
+		l.next = 0
+	}
+
+	owner := l.owners[l.next]
+	items := l.items[owner]
+	item := items[0]
+	if len(items) == 1 {
+		delete(l.items, owner)
+		l.owners = append(l.owners[:l.next], l.owners[l.next+1:]...)
+	} else {
+		l.items[owner] = items[1:]
+		l.next++
+	}
+
+	return item
+}
+
+// fairQueue is a workqueue.Interface that keeps a lane per priority and round-robins across owners within a lane.
+// Unlike the default k8s queue it doesn't dedupe items, workers always queue fresh copies of work items.
+type fairQueue struct {
+	cond         *sync.Cond
+	lanes        [priorityLanes]*lane
+	count        int
+	shuttingDown bool
+	metrics      laneMetrics
+}
+
+func (q *fairQueue) Add(item interface{}) {
+	wrapper := item.(*workItemWrapper)
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+
+	if int(wrapper.priority) >= priorityLanes {
+		wrapper.priority = PriorityLow
+	}
+
+	wrapper.queuedAt = time.Now()
+	q.lanes[wrapper.priority].push(wrapper)
+	q.count++
+	q.metrics.Depth.WithLabelValues(wrapper.priority.String()).Inc()
+	q.cond.Signal()
+}
+
+func (q *fairQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.count
+}
+
+func (q *fairQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for q.count == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+
+	if q.count == 0 {
+		return nil, true
+	}
+
+	for _, l := range q.lanes {
+		if wrapper := l.pop(); wrapper != nil {
+			q.count--
+			q.metrics.Depth.WithLabelValues(wrapper.priority.String()).Dec()
+			q.metrics.WaitTime.WithLabelValues(wrapper.priority.String()).Observe(time.Since(wrapper.queuedAt).Seconds())
+			return wrapper, false
+		}
+	}
+
+	return nil, false
+}
+
+func (q *fairQueue) Done(item interface{}) {}
+
+func (q *fairQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *fairQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shuttingDown
+}
+
+func newFairQueue(scope promutils.Scope) *fairQueue {
+	q := &fairQueue{
+		cond: sync.NewCond(&sync.Mutex{}),
+		metrics: laneMetrics{
+			Depth: scope.MustNewGaugeVec("lane_depth", "Number of items waiting in each priority lane.", "lane"),
+			WaitTime: scope.MustNewHistogramVec("lane_wait_time",
+				"Histogram of the time, in seconds, items spent waiting in each priority lane.", "lane"),
+		},
+	}
+
+	for i := range q.lanes {
+		q.lanes[i] = &lane{items: map[string][]*workItemWrapper{}}
+	}
+
+	return q
+}
+
+// rateLimitedQueue adds the k8s delaying and rate limiting semantics on top of a custom workqueue.Interface.
+type rateLimitedQueue struct {
+	workqueue.DelayingInterface
+	rateLimiter workqueue.RateLimiter
+}
+
+func (q rateLimitedQueue) AddRateLimited(item interface{}) {
+	q.DelayingInterface.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q rateLimitedQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q rateLimitedQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+func newRateLimitedFairQueue(name string, rateLimiter workqueue.RateLimiter, scope promutils.Scope) workqueue.RateLimitingInterface {
+	return rateLimitedQueue{
+		DelayingInterface: workqueue.NewDelayingQueueWithCustomQueue(newFairQueue(scope), name),
+		rateLimiter:       rateLimiter,
+	}
+}
@@ -0,0 +1,72 @@
+package workqueue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFairQueue(t *testing.T) {
+	newItem := func(id, owner string, priority Priority) *workItemWrapper {
+		return &workItemWrapper{id: id, owner: owner, priority: priority}
+	}
+
+	t.Run("Round robin across owners", func(t *testing.T) {
+		q := newFairQueue(promutils.NewTestScope())
+		q.Add(newItem("a1", "a", PriorityNormal))
+		q.Add(newItem("a2", "a", PriorityNormal))
+		q.Add(newItem("a3", "a", PriorityNormal))
+		q.Add(newItem("b1", "b", PriorityNormal))
+		q.Add(newItem("c1", "c", PriorityNormal))
+		q.Add(newItem("b2", "b", PriorityNormal))
+		assert.Equal(t, 6, q.Len())
+
+		var order []string
+		for q.Len() > 0 {
+			item, shutdown := q.Get()
+			assert.False(t, shutdown)
+			order = append(order, item.(*workItemWrapper).id)
+		}
+
+		assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2", "a3"}, order)
+	})
+
+	t.Run("Higher priority first", func(t *testing.T) {
+		q := newFairQueue(promutils.NewTestScope())
+		q.Add(newItem("low", "a", PriorityLow))
+		q.Add(newItem("normal", "a", PriorityNormal))
+		q.Add(newItem("high", "b", PriorityHigh))
+
+		var order []string
+		for q.Len() > 0 {
+			item, _ := q.Get()
+			order = append(order, item.(*workItemWrapper).id)
+		}
+
+		assert.Equal(t, []string{"high", "normal", "low"}, order)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		q := newFairQueue(promutils.NewTestScope())
+		q.ShutDown()
+		assert.True(t, q.ShuttingDown())
+		_, shutdown := q.Get()
+		assert.True(t, shutdown)
+	})
+}
+
+func TestQueueOptions(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextutils.ExecIDKey, "exec")
+	ctx = context.WithValue(ctx, contextutils.NodeIDKey, "node")
+	assert.Equal(t, "exec/node", defaultOwner(ctx))
+	assert.Equal(t, "", defaultOwner(context.Background()))
+
+	w := &workItemWrapper{}
+	WithOwner("owner")(w)
+	WithPriority(PriorityHigh)(w)
+	assert.Equal(t, "owner", w.owner)
+	assert.Equal(t, PriorityHigh, w.priority)
+}
@@ -70,8 +70,8 @@ func (_m IndexedWorkQueue_Queue) Return(_a0 error) *IndexedWorkQueue_Queue {
 	return &IndexedWorkQueue_Queue{Call: _m.Call.Return(_a0)}
 }
 
-func (_m *IndexedWorkQueue) OnQueue(ctx context.Context, id string, once workqueue.WorkItem) *IndexedWorkQueue_Queue {
-	c := _m.On("Queue", ctx, id, once)
+func (_m *IndexedWorkQueue) OnQueue(ctx context.Context, id string, once workqueue.WorkItem, opts ...workqueue.QueueOption) *IndexedWorkQueue_Queue {
+	c := _m.On("Queue", ctx, id, once, opts)
 	return &IndexedWorkQueue_Queue{Call: c}
 }
 
@@ -80,13 +80,20 @@ func (_m *IndexedWorkQueue) OnQueueMatch(matchers ...interface{}) *IndexedWorkQu
 	return &IndexedWorkQueue_Queue{Call: c}
 }
 
-// Queue provides a mock function with given fields: ctx, id, once
-func (_m *IndexedWorkQueue) Queue(ctx context.Context, id string, once workqueue.WorkItem) error {
-	ret := _m.Called(ctx, id, once)
+// Queue provides a mock function with given fields: ctx, id, once, opts
+func (_m *IndexedWorkQueue) Queue(ctx context.Context, id string, once workqueue.WorkItem, opts ...workqueue.QueueOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, once)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, workqueue.WorkItem) error); ok {
-		r0 = rf(ctx, id, once)
+	if rf, ok := ret.Get(0).(func(context.Context, string, workqueue.WorkItem, ...workqueue.QueueOption) error); ok {
+		r0 = rf(ctx, id, once, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,93 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	workqueue "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersistentIndex is an autogenerated mock type for the PersistentIndex type
+type PersistentIndex struct {
+	mock.Mock
+}
+
+type PersistentIndex_Get struct {
+	*mock.Call
+}
+
+func (_m PersistentIndex_Get) Return(item workqueue.PersistedWorkItem, found bool, err error) *PersistentIndex_Get {
+	return &PersistentIndex_Get{Call: _m.Call.Return(item, found, err)}
+}
+
+func (_m *PersistentIndex) OnGet(ctx context.Context, id string) *PersistentIndex_Get {
+	c := _m.On("Get", ctx, id)
+	return &PersistentIndex_Get{Call: c}
+}
+
+func (_m *PersistentIndex) OnGetMatch(matchers ...interface{}) *PersistentIndex_Get {
+	c := _m.On("Get", matchers...)
+	return &PersistentIndex_Get{Call: c}
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *PersistentIndex) Get(ctx context.Context, id string) (workqueue.PersistedWorkItem, bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 workqueue.PersistedWorkItem
+	if rf, ok := ret.Get(0).(func(context.Context, string) workqueue.PersistedWorkItem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(workqueue.PersistedWorkItem)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type PersistentIndex_Put struct {
+	*mock.Call
+}
+
+func (_m PersistentIndex_Put) Return(_a0 error) *PersistentIndex_Put {
+	return &PersistentIndex_Put{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *PersistentIndex) OnPut(ctx context.Context, item workqueue.PersistedWorkItem) *PersistentIndex_Put {
+	c := _m.On("Put", ctx, item)
+	return &PersistentIndex_Put{Call: c}
+}
+
+func (_m *PersistentIndex) OnPutMatch(matchers ...interface{}) *PersistentIndex_Put {
+	c := _m.On("Put", matchers...)
+	return &PersistentIndex_Put{Call: c}
+}
+
+// Put provides a mock function with given fields: ctx, item
+func (_m *PersistentIndex) Put(ctx context.Context, item workqueue.PersistedWorkItem) error {
+	ret := _m.Called(ctx, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, workqueue.PersistedWorkItem) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// StatefulWorkItem is an autogenerated mock type for the StatefulWorkItem type
+type StatefulWorkItem struct {
+	mock.Mock
+}
+
+type StatefulWorkItem_MarshalState struct {
+	*mock.Call
+}
+
+func (_m StatefulWorkItem_MarshalState) Return(_a0 []byte, _a1 error) *StatefulWorkItem_MarshalState {
+	return &StatefulWorkItem_MarshalState{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *StatefulWorkItem) OnMarshalState() *StatefulWorkItem_MarshalState {
+	c := _m.On("MarshalState")
+	return &StatefulWorkItem_MarshalState{Call: c}
+}
+
+func (_m *StatefulWorkItem) OnMarshalStateMatch(matchers ...interface{}) *StatefulWorkItem_MarshalState {
+	c := _m.On("MarshalState", matchers...)
+	return &StatefulWorkItem_MarshalState{Call: c}
+}
+
+// MarshalState provides a mock function with given fields:
+func (_m *StatefulWorkItem) MarshalState() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type StatefulWorkItem_UnmarshalState struct {
+	*mock.Call
+}
+
+func (_m StatefulWorkItem_UnmarshalState) Return(_a0 error) *StatefulWorkItem_UnmarshalState {
+	return &StatefulWorkItem_UnmarshalState{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *StatefulWorkItem) OnUnmarshalState(state []byte) *StatefulWorkItem_UnmarshalState {
+	c := _m.On("UnmarshalState", state)
+	return &StatefulWorkItem_UnmarshalState{Call: c}
+}
+
+func (_m *StatefulWorkItem) OnUnmarshalStateMatch(matchers ...interface{}) *StatefulWorkItem_UnmarshalState {
+	c := _m.On("UnmarshalState", matchers...)
+	return &StatefulWorkItem_UnmarshalState{Call: c}
+}
+
+// UnmarshalState provides a mock function with given fields: state
+func (_m *StatefulWorkItem) UnmarshalState(state []byte) error {
+	ret := _m.Called(state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
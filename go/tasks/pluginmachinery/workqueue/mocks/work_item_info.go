@@ -110,6 +110,134 @@ func (_m *WorkItemInfo) Item() workqueue.WorkItem {
 	return r0
 }
 
+type WorkItemInfo_LastError struct {
+	*mock.Call
+}
+
+func (_m WorkItemInfo_LastError) Return(_a0 error) *WorkItemInfo_LastError {
+	return &WorkItemInfo_LastError{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WorkItemInfo) OnLastError() *WorkItemInfo_LastError {
+	c := _m.On("LastError")
+	return &WorkItemInfo_LastError{Call: c}
+}
+
+func (_m *WorkItemInfo) OnLastErrorMatch(matchers ...interface{}) *WorkItemInfo_LastError {
+	c := _m.On("LastError", matchers...)
+	return &WorkItemInfo_LastError{Call: c}
+}
+
+// LastError provides a mock function with given fields:
+func (_m *WorkItemInfo) LastError() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type WorkItemInfo_Owner struct {
+	*mock.Call
+}
+
+func (_m WorkItemInfo_Owner) Return(_a0 string) *WorkItemInfo_Owner {
+	return &WorkItemInfo_Owner{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WorkItemInfo) OnOwner() *WorkItemInfo_Owner {
+	c := _m.On("Owner")
+	return &WorkItemInfo_Owner{Call: c}
+}
+
+func (_m *WorkItemInfo) OnOwnerMatch(matchers ...interface{}) *WorkItemInfo_Owner {
+	c := _m.On("Owner", matchers...)
+	return &WorkItemInfo_Owner{Call: c}
+}
+
+// Owner provides a mock function with given fields:
+func (_m *WorkItemInfo) Owner() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type WorkItemInfo_Priority struct {
+	*mock.Call
+}
+
+func (_m WorkItemInfo_Priority) Return(_a0 workqueue.Priority) *WorkItemInfo_Priority {
+	return &WorkItemInfo_Priority{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WorkItemInfo) OnPriority() *WorkItemInfo_Priority {
+	c := _m.On("Priority")
+	return &WorkItemInfo_Priority{Call: c}
+}
+
+func (_m *WorkItemInfo) OnPriorityMatch(matchers ...interface{}) *WorkItemInfo_Priority {
+	c := _m.On("Priority", matchers...)
+	return &WorkItemInfo_Priority{Call: c}
+}
+
+// Priority provides a mock function with given fields:
+func (_m *WorkItemInfo) Priority() workqueue.Priority {
+	ret := _m.Called()
+
+	var r0 workqueue.Priority
+	if rf, ok := ret.Get(0).(func() workqueue.Priority); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(workqueue.Priority)
+	}
+
+	return r0
+}
+
+type WorkItemInfo_RetryCount struct {
+	*mock.Call
+}
+
+func (_m WorkItemInfo_RetryCount) Return(_a0 uint) *WorkItemInfo_RetryCount {
+	return &WorkItemInfo_RetryCount{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WorkItemInfo) OnRetryCount() *WorkItemInfo_RetryCount {
+	c := _m.On("RetryCount")
+	return &WorkItemInfo_RetryCount{Call: c}
+}
+
+func (_m *WorkItemInfo) OnRetryCountMatch(matchers ...interface{}) *WorkItemInfo_RetryCount {
+	c := _m.On("RetryCount", matchers...)
+	return &WorkItemInfo_RetryCount{Call: c}
+}
+
+// RetryCount provides a mock function with given fields:
+func (_m *WorkItemInfo) RetryCount() uint {
+	ret := _m.Called()
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	return r0
+}
+
 type WorkItemInfo_Status struct {
 	*mock.Call
 }
@@ -0,0 +1,261 @@
+package workqueue
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+// PersistedWorkItem is the durable record of a work item that reached a terminal status.
+type PersistedWorkItem struct {
+	ID        WorkItemID `json:"id"`
+	Status    WorkStatus `json:"status"`
+	Error     string     `json:"error,omitempty"`
+	State     []byte     `json:"state,omitempty"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// PersistentIndex durably stores the results of work items that reached a terminal status so that a restarted (or
+// evicting) queue doesn't process them again.
+type PersistentIndex interface {
+	// Retrieves the persisted record of a work item. Records older than the index TTL are expired and reported as not
+	// found.
+	Get(ctx context.Context, id WorkItemID) (item PersistedWorkItem, found bool, err error)
+
+	// Persists the record of a work item, overwriting any existing one.
+	Put(ctx context.Context, item PersistedWorkItem) error
+}
+
+// StatefulWorkItem can be implemented by work items whose processing produces results (beyond the work status) that
+// must be restored when the item is recovered from a PersistentIndex.
+type StatefulWorkItem interface {
+	MarshalState() ([]byte, error)
+	UnmarshalState(state []byte) error
+}
+
+// garbageCollectedIndex is implemented by persistent indexes that can remove their expired records.
+type garbageCollectedIndex interface {
+	// Removes the records older than the index TTL and returns how many were removed.
+	RemoveExpired(ctx context.Context) (removed int, err error)
+}
+
+type dataStoreIndex struct {
+	store  *storage.DataStore
+	prefix storage.DataReference
+	ttl    time.Duration
+}
+
+func (d dataStoreIndex) reference(ctx context.Context, id WorkItemID) (storage.DataReference, error) {
+	// Work item ids are free-form strings, hash them to get a safe and uniformly distributed key.
+	h := sha256.Sum256([]byte(id))
+	return d.store.ConstructReference(ctx, d.prefix, hex.EncodeToString(h[:]))
+}
+
+func (d dataStoreIndex) Get(ctx context.Context, id WorkItemID) (item PersistedWorkItem, found bool, err error) {
+	ref, err := d.reference(ctx, id)
+	if err != nil {
+		return PersistedWorkItem{}, false, err
+	}
+
+	reader, err := d.store.ReadRaw(ctx, ref)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return PersistedWorkItem{}, false, nil
+		}
+
+		return PersistedWorkItem{}, false, err
+	}
+
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	raw, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return PersistedWorkItem{}, false, err
+	}
+
+	return unmarshalPersistedWorkItem(raw, id, d.ttl)
+}
+
+// Decodes a persisted record, reporting it as not found if it belongs to another item or has expired.
+func unmarshalPersistedWorkItem(raw []byte, id WorkItemID, ttl time.Duration) (
+	item PersistedWorkItem, found bool, err error) {
+
+	if err = json.Unmarshal(raw, &item); err != nil {
+		return PersistedWorkItem{}, false, err
+	}
+
+	// Guard against hash collisions.
+	if item.ID != id {
+		return PersistedWorkItem{}, false, nil
+	}
+
+	if isExpired(item, ttl) {
+		return PersistedWorkItem{}, false, nil
+	}
+
+	return item, true, nil
+}
+
+func isExpired(item PersistedWorkItem, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(item.UpdatedAt) > ttl
+}
+
+func (d dataStoreIndex) Put(ctx context.Context, item PersistedWorkItem) error {
+	ref, err := d.reference(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	return d.store.WriteRaw(ctx, ref, int64(len(raw)), storage.Options{}, bytes.NewReader(raw))
+}
+
+// NewDataStoreIndex creates a PersistentIndex that keeps one record per work item under the given prefix. Data stores
+// can't delete objects, expired records are ignored and left to a lifecycle rule of the bucket to remove.
+func NewDataStoreIndex(store *storage.DataStore, prefix storage.DataReference, ttl time.Duration) PersistentIndex {
+	return dataStoreIndex{
+		store:  store,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+var (
+	// BoltDB files can only be opened once per process, queues persisting in the same file share the handle.
+	boltDBsLock sync.Mutex
+	boltDBs     = map[string]*bolt.DB{}
+)
+
+func openBoltDB(path string) (*bolt.DB, error) {
+	boltDBsLock.Lock()
+	defer boltDBsLock.Unlock()
+
+	if db, found := boltDBs[path]; found {
+		return db, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	boltDBs[path] = db
+	return db, nil
+}
+
+type boltDBIndex struct {
+	db     *bolt.DB
+	bucket []byte
+	ttl    time.Duration
+}
+
+func (b boltDBIndex) Get(_ context.Context, id WorkItemID) (item PersistedWorkItem, found bool, err error) {
+	var raw []byte
+	err = b.db.View(func(tx *bolt.Tx) error {
+		// Values are only valid for the life of the transaction.
+		if value := tx.Bucket(b.bucket).Get([]byte(id)); value != nil {
+			raw = append([]byte{}, value...)
+		}
+
+		return nil
+	})
+
+	if err != nil || raw == nil {
+		return PersistedWorkItem{}, false, err
+	}
+
+	return unmarshalPersistedWorkItem(raw, id, b.ttl)
+}
+
+func (b boltDBIndex) Put(_ context.Context, item PersistedWorkItem) error {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.bucket).Put([]byte(item.ID), raw)
+	})
+}
+
+func (b boltDBIndex) RemoveExpired(_ context.Context) (removed int, err error) {
+	if b.ttl <= 0 {
+		return 0, nil
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		var expired [][]byte
+		err := bucket.ForEach(func(key, value []byte) error {
+			item := PersistedWorkItem{}
+			// Records that can't be decoded would never be found again, remove them as well.
+			if err := json.Unmarshal(value, &item); err != nil || isExpired(item, b.ttl) {
+				expired = append(expired, append([]byte{}, key...))
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// Keys can't be deleted while iterating over the bucket.
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		removed = len(expired)
+		return nil
+	})
+
+	return removed, err
+}
+
+// NewBoltDBIndex creates a PersistentIndex that keeps one record per work item in a bucket of the BoltDB file at the
+// given path. Expired records are removed by RemoveExpired.
+func NewBoltDBIndex(path, bucket string, ttl time.Duration) (PersistentIndex, error) {
+	db, err := openBoltDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return boltDBIndex{
+		db:     db,
+		bucket: []byte(bucket),
+		ttl:    ttl,
+	}, nil
+}
@@ -0,0 +1,300 @@
+package workqueue
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+}
+
+type statefulItem struct {
+	result string
+}
+
+func (s statefulItem) MarshalState() ([]byte, error) {
+	return []byte(s.result), nil
+}
+
+func (s *statefulItem) UnmarshalState(state []byte) error {
+	s.result = string(state)
+	return nil
+}
+
+type statefulProcessor struct {
+	calls chan WorkItem
+}
+
+func (p statefulProcessor) Process(ctx context.Context, workItem WorkItem) (WorkStatus, error) {
+	p.calls <- workItem
+	item, casted := workItem.(*statefulItem)
+	if !casted {
+		return WorkStatusFailed, fmt.Errorf("unexpected item type")
+	}
+
+	item.result = "processed"
+	return WorkStatusSucceeded, nil
+}
+
+// lockedRawStore serializes access to the in-memory store, which isn't safe for concurrent use, since workers persist
+// items while tests read them.
+type lockedRawStore struct {
+	storage.RawStore
+	lock sync.Mutex
+}
+
+func (s *lockedRawStore) Head(ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.RawStore.Head(ctx, reference)
+}
+
+func (s *lockedRawStore) ReadRaw(ctx context.Context, reference storage.DataReference) (io.ReadCloser, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.RawStore.ReadRaw(ctx, reference)
+}
+
+func (s *lockedRawStore) WriteRaw(ctx context.Context, reference storage.DataReference, size int64,
+	opts storage.Options, raw io.Reader) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.RawStore.WriteRaw(ctx, reference, size, opts, raw)
+}
+
+func newTestDataStore(t testing.TB) *storage.DataStore {
+	rawStore, err := storage.NewInMemoryRawStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return storage.NewCompositeDataStore(storage.URLPathConstructor{},
+		storage.NewDefaultProtobufStore(&lockedRawStore{RawStore: rawStore}, promutils.NewTestScope()))
+}
+
+func waitForTerminal(t testing.TB, q IndexedWorkQueue, id WorkItemID) WorkItemInfo {
+	for i := 0; i < 100; i++ {
+		info, found, err := q.Get(id)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		if info.Status().IsTerminal() {
+			return info
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.FailNow(t, "item didn't reach a terminal status")
+	return nil
+}
+
+func TestDataStoreIndex(t *testing.T) {
+	ctx := context.Background()
+	store := newTestDataStore(t)
+
+	t.Run("Round trip", func(t *testing.T) {
+		index := NewDataStoreIndex(store, "s3://bucket/index", time.Hour)
+		_, found, err := index.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		assert.NoError(t, index.Put(ctx, PersistedWorkItem{
+			ID:        "abc",
+			Status:    WorkStatusFailed,
+			Error:     "boom",
+			UpdatedAt: time.Now(),
+		}))
+
+		item, found, err := index.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, WorkStatusFailed, item.Status)
+		assert.Equal(t, "boom", item.Error)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		index := NewDataStoreIndex(store, "s3://bucket/index", time.Minute)
+		assert.NoError(t, index.Put(ctx, PersistedWorkItem{
+			ID:        "old",
+			Status:    WorkStatusSucceeded,
+			UpdatedAt: time.Now().Add(-time.Hour),
+		}))
+
+		_, found, err := index.Get(ctx, "old")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		// Reading an expired record doesn't write to the store.
+		_, found, err = NewDataStoreIndex(store, "s3://bucket/index", 2*time.Hour).Get(ctx, "old")
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+}
+
+func countBoltDBItems(t testing.TB, path, bucket string) int {
+	db, err := openBoltDB(path)
+	assert.NoError(t, err)
+
+	count := 0
+	assert.NoError(t, db.View(func(tx *bolt.Tx) error {
+		count = tx.Bucket([]byte(bucket)).Stats().KeyN
+		return nil
+	}))
+
+	return count
+}
+
+func TestBoltDBIndex(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "index.db")
+
+	index, err := NewBoltDBIndex(path, "test", time.Minute)
+	assert.NoError(t, err)
+
+	t.Run("Round trip", func(t *testing.T) {
+		_, found, err := index.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		assert.NoError(t, index.Put(ctx, PersistedWorkItem{
+			ID:        "abc",
+			Status:    WorkStatusSucceeded,
+			State:     []byte("processed"),
+			UpdatedAt: time.Now(),
+		}))
+
+		item, found, err := index.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, WorkStatusSucceeded, item.Status)
+		assert.Equal(t, []byte("processed"), item.State)
+	})
+
+	t.Run("Shared file", func(t *testing.T) {
+		other, err := NewBoltDBIndex(path, "other", time.Minute)
+		assert.NoError(t, err)
+		_, found, err := other.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Remove expired", func(t *testing.T) {
+		assert.NoError(t, index.Put(ctx, PersistedWorkItem{
+			ID:        "old",
+			Status:    WorkStatusFailed,
+			UpdatedAt: time.Now().Add(-time.Hour),
+		}))
+
+		_, found, err := index.Get(ctx, "old")
+		assert.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, 2, countBoltDBItems(t, path, "test"))
+
+		removed, err := index.(garbageCollectedIndex).RemoveExpired(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, 1, countBoltDBItems(t, path, "test"))
+
+		_, found, err = index.Get(ctx, "abc")
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+}
+
+func TestNewPersistentIndexedWorkQueue(t *testing.T) {
+	store := newTestDataStore(t)
+	cfg := Config{
+		Workers:            1,
+		MaxRetries:         1,
+		IndexCacheMaxItems: 10,
+	}
+
+	persistenceCfg := PersistenceConfig{
+		Enabled: true,
+		Prefix:  "s3://bucket/queues",
+		TTL:     config.Duration{Duration: time.Hour},
+	}
+
+	t.Run("Invalid config", func(t *testing.T) {
+		for name, invalidCfg := range map[string]PersistenceConfig{
+			"Missing prefix": {Enabled: true, TTL: persistenceCfg.TTL},
+			"Missing ttl":    {Enabled: true, Prefix: persistenceCfg.Prefix},
+			"Missing path":   {Enabled: true, Type: PersistenceTypeBoltDB, TTL: persistenceCfg.TTL},
+			"Unknown type":   {Enabled: true, Type: "redis", Prefix: persistenceCfg.Prefix, TTL: persistenceCfg.TTL},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := NewPersistentIndexedWorkQueue(context.TODO(), "test", statefulProcessor{}, Config{
+					IndexCacheMaxItems: 1,
+				}, invalidCfg, store, promutils.NewTestScope())
+				assert.Error(t, err)
+			})
+		}
+
+		_, err := NewPersistentIndexedWorkQueue(context.TODO(), "test", statefulProcessor{}, Config{
+			IndexCacheMaxItems: 1,
+		}, persistenceCfg, nil, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
+
+	t.Run("Recover after restart", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := make(chan WorkItem, 10)
+		q, err := NewPersistentIndexedWorkQueue(ctx, "test", statefulProcessor{calls: calls}, cfg, persistenceCfg,
+			store, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", &statefulItem{}))
+		info := waitForTerminal(t, q, "abc")
+		assert.Equal(t, WorkStatusSucceeded, info.Status())
+		assert.Len(t, calls, 1)
+
+		// A fresh queue sharing the same store must not process the item again.
+		restarted, err := NewPersistentIndexedWorkQueue(ctx, "test", statefulProcessor{calls: calls}, cfg, persistenceCfg,
+			store, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, restarted.Start(ctx))
+		assert.NoError(t, restarted.Queue(ctx, "abc", &statefulItem{}))
+
+		info, found, err := restarted.Get("abc")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, WorkStatusSucceeded, info.Status())
+		assert.Equal(t, "processed", info.Item().(*statefulItem).result)
+		assert.Len(t, calls, 1)
+	})
+	t.Run("Remove expired items", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		path := filepath.Join(t.TempDir(), "index.db")
+		calls := make(chan WorkItem, 10)
+		q, err := NewPersistentIndexedWorkQueue(ctx, "test", statefulProcessor{calls: calls}, cfg, PersistenceConfig{
+			Enabled:    true,
+			Type:       PersistenceTypeBoltDB,
+			Path:       path,
+			TTL:        config.Duration{Duration: 10 * time.Millisecond},
+			GCInterval: config.Duration{Duration: 10 * time.Millisecond},
+		}, nil, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", &statefulItem{}))
+		waitForTerminal(t, q, "abc")
+
+		assert.Eventually(t, func() bool {
+			return countBoltDBItems(t, path, "test") == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
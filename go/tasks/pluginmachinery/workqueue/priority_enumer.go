@@ -0,0 +1,51 @@
+// Code generated by "enumer --type=Priority --trimprefix=Priority"; DO NOT EDIT.
+
+//
+package workqueue
+
+import (
+	"fmt"
+)
+
+const _PriorityName = "HighNormalLow"
+
+var _PriorityIndex = [...]uint8{0, 4, 10, 13}
+
+func (i Priority) String() string {
+	if i >= Priority(len(_PriorityIndex)-1) {
+		return fmt.Sprintf("Priority(%d)", i)
+	}
+	return _PriorityName[_PriorityIndex[i]:_PriorityIndex[i+1]]
+}
+
+var _PriorityValues = []Priority{0, 1, 2}
+
+var _PriorityNameToValueMap = map[string]Priority{
+	_PriorityName[0:4]:   0,
+	_PriorityName[4:10]:  1,
+	_PriorityName[10:13]: 2,
+}
+
+// PriorityString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func PriorityString(s string) (Priority, error) {
+	if val, ok := _PriorityNameToValueMap[s]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to Priority values", s)
+}
+
+// PriorityValues returns all values of the enum
+func PriorityValues() []Priority {
+	return _PriorityValues
+}
+
+// IsAPriority returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i Priority) IsAPriority() bool {
+	for _, v := range _PriorityValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/errors"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 
 	lru "github.com/hashicorp/golang-lru"
 
@@ -35,6 +37,9 @@ const (
 	ErrNotYetStarted errors.ErrorCode = "NOT_STARTED"
 )
 
+// Status label used in metrics for processing attempts that returned an error.
+const processErrorLabel = "Error"
+
 func (w WorkStatus) IsTerminal() bool {
 	return w == WorkStatusFailed || w == WorkStatusSucceeded
 }
@@ -47,15 +52,24 @@ type WorkItemInfo interface {
 	Item() WorkItem
 	ID() WorkItemID
 	Status() WorkStatus
+	// The error that failed the work item. Nil unless the status is WorkStatusFailed.
 	Error() error
+	// The number of times processing the work item has errored.
+	RetryCount() uint
+	// The most recent processing error, regardless of the status.
+	LastError() error
+	// The key the work item is grouped by for fairness.
+	Owner() string
+	// The lane the work item is queued in.
+	Priority() Priority
 }
 
 // Represents the indexed queue semantics. An indexed work queue is a work queue that additionally keeps track of the
 // final processing results of work items.
 type IndexedWorkQueue interface {
 	// Queues the item to be processed. If the item is already in the cache or has been processed before (and is still
-	// in-memory), it'll not be added again.
-	Queue(ctx context.Context, id WorkItemID, once WorkItem) error
+	// in-memory), it'll not be added again. Options can set the owner and priority the item is scheduled with.
+	Queue(ctx context.Context, id WorkItemID, once WorkItem, opts ...QueueOption) error
 
 	// Retrieves an item by id.
 	Get(id WorkItemID) (info WorkItemInfo, found bool, err error)
@@ -64,7 +78,8 @@ type IndexedWorkQueue interface {
 	Start(ctx context.Context) error
 }
 
-// Represents the processor logic to operate on work items.
+// Represents the processor logic to operate on work items. Errors are retried with backoff up to the configured max
+// retries, unless they are wrapped using NewNonRetryableError in which case the item fails immediately.
 type Processor interface {
 	Process(ctx context.Context, workItem WorkItem) (WorkStatus, error)
 }
@@ -76,6 +91,10 @@ type workItemWrapper struct {
 	status     WorkStatus
 	retryCount uint
 	err        error
+	lastErr    error
+	owner      string
+	priority   Priority
+	queuedAt   time.Time
 }
 
 func (w workItemWrapper) Item() WorkItem {
@@ -94,15 +113,35 @@ func (w workItemWrapper) Error() error {
 	return w.err
 }
 
+func (w workItemWrapper) RetryCount() uint {
+	return w.retryCount
+}
+
+func (w workItemWrapper) LastError() error {
+	return w.lastErr
+}
+
+func (w workItemWrapper) Owner() string {
+	return w.owner
+}
+
+func (w workItemWrapper) Priority() Priority {
+	return w.priority
+}
+
 func (w workItemWrapper) Clone() workItemWrapper {
 	return w
 }
 
 type metrics struct {
-	CacheHit        prometheus.Counter
-	CacheMiss       prometheus.Counter
-	ProcessorErrors prometheus.Counter
-	Scope           promutils.Scope
+	CacheHit          prometheus.Counter
+	CacheMiss         prometheus.Counter
+	ProcessorErrors   prometheus.Counter
+	RecoveredItems    prometheus.Counter
+	ExpiredItems      prometheus.Counter
+	PersistenceErrors prometheus.Counter
+	ProcessDuration   *prometheus.HistogramVec
+	Scope             promutils.Scope
 }
 
 type queue struct {
@@ -113,9 +152,13 @@ type queue struct {
 	workers    int
 	maxRetries int
 	started    bool
-	queue      workqueue.Interface
+	queue      workqueue.RateLimitingInterface
 	index      workItemCache
 	processor  Processor
+	// Optional index that keeps terminal item statuses across restarts. Nil if persistence isn't enabled.
+	persistentIndex PersistentIndex
+	// Interval at which expired records are removed from the persistent index, if it supports it.
+	gcInterval time.Duration
 }
 
 type workItemCache struct {
@@ -141,29 +184,131 @@ func copyAllowedLogFields(ctx context.Context) map[string]interface{} {
 	return logFields
 }
 
-func (q *queue) Queue(ctx context.Context, id WorkItemID, once WorkItem) error {
-	q.wlock.Lock()
-	defer q.wlock.Unlock()
-
-	if !q.started {
-		return errors.Errorf(ErrNotYetStarted, "Queue must be started before enqueuing any item.")
-	}
-
-	if _, found := q.index.Get(id); found {
-		return nil
+func (q *queue) Queue(ctx context.Context, id WorkItemID, once WorkItem, opts ...QueueOption) error {
+	if queued, err := q.isQueued(id); err != nil || queued {
+		return err
 	}
 
 	wrapper := &workItemWrapper{
 		id:        id,
 		logFields: copyAllowedLogFields(ctx),
 		payload:   once,
+		owner:     defaultOwner(ctx),
+		priority:  PriorityNormal,
+	}
+
+	for _, opt := range opts {
+		opt(wrapper)
+	}
+
+	// The persistent index lives in a remote store, don't block other callers while looking the item up.
+	recovered := q.recover(ctx, wrapper)
+
+	q.wlock.Lock()
+	defer q.wlock.Unlock()
+
+	// The item might have been queued by a concurrent caller in the meantime.
+	if _, found := q.index.Get(id); found {
+		return nil
 	}
 
 	q.index.Add(wrapper)
-	q.queue.Add(wrapper)
+	if !recovered {
+		q.queue.Add(wrapper)
+	}
+
 	return nil
 }
 
+// Returns true if the item is already in the index. Fails if the queue hasn't been started.
+func (q *queue) isQueued(id WorkItemID) (bool, error) {
+	q.wlock.Lock()
+	defer q.wlock.Unlock()
+
+	if !q.started {
+		return false, errors.Errorf(ErrNotYetStarted, "Queue must be started before enqueuing any item.")
+	}
+
+	_, found := q.index.Get(id)
+	return found, nil
+}
+
+// Attempts to restore the terminal status of an item from the persistent index. Returns true if the item doesn't
+// need to be processed.
+func (q *queue) recover(ctx context.Context, wrapper *workItemWrapper) bool {
+	if q.persistentIndex == nil {
+		return false
+	}
+
+	persisted, found, err := q.persistentIndex.Get(ctx, wrapper.id)
+	if err != nil {
+		q.metrics.PersistenceErrors.Inc()
+		logger.Warnf(ctx, "Failed to lookup WorkItem [%v] in the persistent index. Error: %v", wrapper.id, err)
+		return false
+	}
+
+	if !found || !persisted.Status.IsTerminal() {
+		return false
+	}
+
+	if len(persisted.State) > 0 {
+		stateful, ok := wrapper.payload.(StatefulWorkItem)
+		if !ok {
+			logger.Warnf(ctx, "WorkItem [%v] has persisted state but doesn't implement StatefulWorkItem.", wrapper.id)
+			return false
+		}
+
+		if err = stateful.UnmarshalState(persisted.State); err != nil {
+			q.metrics.PersistenceErrors.Inc()
+			logger.Warnf(ctx, "Failed to restore the state of WorkItem [%v]. Error: %v", wrapper.id, err)
+			return false
+		}
+	}
+
+	wrapper.status = persisted.Status
+	if len(persisted.Error) > 0 {
+		wrapper.err = fmt.Errorf("%s", persisted.Error)
+	}
+
+	q.metrics.RecoveredItems.Inc()
+	logger.Debugf(ctx, "Recovered WorkItem [%v] with status [%v] from the persistent index.", wrapper.id,
+		wrapper.status)
+	return true
+}
+
+// Records the terminal status of an item in the persistent index, if one is configured.
+func (q *queue) persist(ctx context.Context, wrapper *workItemWrapper) {
+	if q.persistentIndex == nil || !wrapper.status.IsTerminal() {
+		return
+	}
+
+	persisted := PersistedWorkItem{
+		ID:        wrapper.id,
+		Status:    wrapper.status,
+		UpdatedAt: time.Now(),
+	}
+
+	if wrapper.err != nil {
+		persisted.Error = wrapper.err.Error()
+	}
+
+	if stateful, ok := wrapper.payload.(StatefulWorkItem); ok {
+		state, err := stateful.MarshalState()
+		if err != nil {
+			q.metrics.PersistenceErrors.Inc()
+			logger.Warnf(ctx, "Failed to marshal the state of WorkItem [%v]. Error: %v", wrapper.id, err)
+			return
+		}
+
+		persisted.State = state
+	}
+
+	if err := q.persistentIndex.Put(ctx, persisted); err != nil {
+		q.metrics.PersistenceErrors.Inc()
+		logger.Warnf(ctx, "Failed to persist WorkItem [%v]. Error: %v", wrapper.id, err)
+	}
+}
+
 func (q *queue) Get(id WorkItemID) (info WorkItemInfo, found bool, err error) {
 	q.rlock.Lock()
 	defer q.rlock.Unlock()
@@ -209,58 +354,125 @@ func (q *queue) Start(ctx context.Context) error {
 						return
 					}
 
-					wrapperV := item.(*workItemWrapper).Clone()
-					wrapper := &wrapperV
-					ws := wrapper.status
-					var err error
-
-					func() {
-						defer func() {
-							if e, ok := recover().(error); ok {
-								logger.Errorf(ctx, "Worker panic'd while processing item [%v]. Error: %v", wrapper.id, e)
-								err = e
-							}
-						}()
-
-						ctxWithFields := contextWithValues(ctx, wrapper.logFields)
-						ws, err = q.processor.Process(ctxWithFields, wrapper.payload)
-					}()
-
-					if err != nil {
-						q.metrics.ProcessorErrors.Inc()
-
-						wrapper.retryCount++
-						wrapper.err = err
-						if wrapper.retryCount >= uint(q.maxRetries) {
-							logger.Debugf(ctx, "WorkItem [%v] exhausted all retries. Last Error: %v.",
-								wrapper.ID(), err)
-							wrapper.status = WorkStatusFailed
-							ws = WorkStatusFailed
-							q.index.Add(wrapper)
-							continue
-						}
-					}
-
-					wrapper.status = ws
-					q.index.Add(wrapper)
-					if !ws.IsTerminal() {
-						q.queue.Add(wrapper)
-					}
+					q.process(ctx, item.(*workItemWrapper))
 				}
 			}
 		}(contextutils.WithGoroutineLabel(ctx, fmt.Sprintf("%v-worker-%v", q.name, i)))
 	}
 
+	if index, ok := q.persistentIndex.(garbageCollectedIndex); ok && q.gcInterval > 0 {
+		go q.collectGarbage(contextutils.WithGoroutineLabel(ctx, fmt.Sprintf("%v-gc", q.name)), index)
+	}
+
 	q.started = true
 	return nil
 }
 
+// Periodically removes the expired records from the persistent index until the context is cancelled.
+func (q *queue) collectGarbage(ctx context.Context, index garbageCollectedIndex) {
+	ticker := time.NewTicker(q.gcInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := index.RemoveExpired(ctx)
+			if err != nil {
+				q.metrics.PersistenceErrors.Inc()
+				logger.Warnf(ctx, "Failed to remove expired items from the persistent index. Error: %v", err)
+				continue
+			}
+
+			q.metrics.ExpiredItems.Add(float64(removed))
+			logger.Debugf(ctx, "Removed [%v] expired items from the persistent index.", removed)
+		}
+	}
+}
+
+// Processes a single item taken off the queue and either records its terminal status or requeues it.
+func (q *queue) process(ctx context.Context, item *workItemWrapper) {
+	defer q.queue.Done(item)
+
+	wrapperV := item.Clone()
+	wrapper := &wrapperV
+	ws := wrapper.status
+	var err error
+
+	start := time.Now()
+	func() {
+		defer func() {
+			if e, ok := recover().(error); ok {
+				logger.Errorf(ctx, "Worker panic'd while processing item [%v]. Error: %v", wrapper.id, e)
+				err = e
+			}
+		}()
+
+		ctxWithFields := contextWithValues(ctx, wrapper.logFields)
+		ws, err = q.processor.Process(ctxWithFields, wrapper.payload)
+	}()
+
+	if err != nil {
+		q.metrics.ProcessDuration.WithLabelValues(processErrorLabel).Observe(time.Since(start).Seconds())
+		q.metrics.ProcessorErrors.Inc()
+
+		wrapper.retryCount++
+		wrapper.lastErr = err
+		if ws.IsTerminal() || IsNonRetryable(err) || wrapper.retryCount >= uint(q.maxRetries) {
+			logger.Debugf(ctx, "WorkItem [%v] failed permanently after [%v] attempts. Last Error: %v.",
+				wrapper.ID(), wrapper.retryCount, err)
+			wrapper.status = WorkStatusFailed
+			wrapper.err = err
+			q.index.Add(wrapper)
+			q.queue.Forget(item)
+			q.persist(ctx, wrapper)
+			return
+		}
+
+		wrapper.status = ws
+		q.index.Add(wrapper)
+		q.queue.Forget(item)
+		q.queue.AddRateLimited(wrapper)
+		return
+	}
+
+	q.metrics.ProcessDuration.WithLabelValues(ws.String()).Observe(time.Since(start).Seconds())
+	wrapper.status = ws
+	switch ws {
+	case WorkStatusSucceeded:
+		wrapper.err = nil
+	case WorkStatusFailed:
+		// Processors may report a failure without an error, make sure readers of the index always get one.
+		wrapper.err = fmt.Errorf("work item [%v] failed", wrapper.id)
+		if wrapper.lastErr != nil {
+			wrapper.err = wrapper.lastErr
+		}
+	}
+
+	q.index.Add(wrapper)
+	q.queue.Forget(item)
+	if !ws.IsTerminal() {
+		q.queue.Add(wrapper)
+	} else {
+		q.persist(ctx, wrapper)
+	}
+}
+
 func newMetrics(scope promutils.Scope) metrics {
 	return metrics{
 		CacheHit:        scope.MustNewCounter("cache_hit", "Counter for cache hits."),
 		CacheMiss:       scope.MustNewCounter("cache_miss", "Counter for cache misses."),
 		ProcessorErrors: scope.MustNewCounter("proc_errors", "Counter for processor errors."),
-		Scope:           scope,
+		RecoveredItems: scope.MustNewCounter("recovered_items",
+			"Counter for items whose status was recovered from the persistent index."),
+		ExpiredItems: scope.MustNewCounter("expired_items",
+			"Counter for expired items removed from the persistent index."),
+		PersistenceErrors: scope.MustNewCounter("persistence_errors",
+			"Counter for failures to read from or write to the persistent index."),
+		ProcessDuration: scope.MustNewHistogramVec("process_duration",
+			"Histogram of the time, in seconds, taken to process an item, by resulting status.", "status"),
+		Scope: scope,
 	}
 }
 
@@ -278,8 +490,65 @@ func NewIndexedWorkQueue(name string, processor Processor, cfg Config, metricsSc
 		rlock:      sync.RWMutex{},
 		workers:    cfg.Workers,
 		maxRetries: cfg.MaxRetries,
-		queue:      workqueue.NewNamed(metricsScope.CurrentScope()),
+		queue:      newRateLimitedFairQueue(metricsScope.CurrentScope(), newBackoffRateLimiter(cfg.Backoff), metricsScope),
 		index:      workItemCache{Cache: cache},
 		processor:  processor,
 	}, nil
 }
+
+// Instantiates a new Indexed Work queue that, if enabled in the persistence config, persists terminal item statuses in
+// the data store or a BoltDB file so that they survive restarts and evictions from the in-memory index. The data store
+// is only needed by the storage persistence type.
+func NewPersistentIndexedWorkQueue(ctx context.Context, name string, processor Processor, cfg Config,
+	persistenceCfg PersistenceConfig, store *storage.DataStore, metricsScope promutils.Scope) (IndexedWorkQueue, error) {
+
+	q, err := NewIndexedWorkQueue(name, processor, cfg, metricsScope)
+	if err != nil || !persistenceCfg.Enabled {
+		return q, err
+	}
+
+	// Items are identified by keys that may be reused much later, e.g. by a new execution of a cached task. Statuses
+	// must not be kept forever.
+	ttl := persistenceCfg.TTL.Duration
+	if ttl <= 0 {
+		return nil, fmt.Errorf("persistence is enabled for queue [%v] but no ttl is configured", name)
+	}
+
+	var index PersistentIndex
+	switch persistenceCfg.Type {
+	case "", PersistenceTypeStorage:
+		if len(persistenceCfg.Prefix) == 0 {
+			return nil, fmt.Errorf("persistence is enabled for queue [%v] but no prefix is configured", name)
+		}
+
+		if store == nil {
+			return nil, fmt.Errorf("persistence is enabled for queue [%v] but no data store is available", name)
+		}
+
+		prefix, err := store.ConstructReference(ctx, storage.DataReference(persistenceCfg.Prefix), name)
+		if err != nil {
+			return nil, err
+		}
+
+		index = NewDataStoreIndex(store, prefix, ttl)
+	case PersistenceTypeBoltDB:
+		if len(persistenceCfg.Path) == 0 {
+			return nil, fmt.Errorf("persistence is enabled for queue [%v] but no path is configured", name)
+		}
+
+		index, err = NewBoltDBIndex(persistenceCfg.Path, name, ttl)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown persistence type [%v] for queue [%v]", persistenceCfg.Type, name)
+	}
+
+	q.(*queue).persistentIndex = index
+	q.(*queue).gcInterval = ttl
+	if persistenceCfg.GCInterval.Duration > 0 {
+		q.(*queue).gcInterval = persistenceCfg.GCInterval.Duration
+	}
+
+	return q, nil
+}
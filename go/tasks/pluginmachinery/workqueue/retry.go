@@ -0,0 +1,78 @@
+package workqueue
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// nonRetryableError marks a processing error that must fail the work item without consuming further retries.
+type nonRetryableError struct {
+	error
+}
+
+func (e nonRetryableError) Unwrap() error {
+	return e.error
+}
+
+// NewNonRetryableError wraps err so that the queue fails the work item immediately instead of retrying it.
+func NewNonRetryableError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return nonRetryableError{error: err}
+}
+
+// IsNonRetryable returns true if err, or any error it wraps, was created by NewNonRetryableError.
+func IsNonRetryable(err error) bool {
+	var nonRetryable nonRetryableError
+	return errors.As(err, &nonRetryable)
+}
+
+// backoffRateLimiter is a workqueue.RateLimiter that delays a failed work item exponentially in the number of times it
+// has been retried. The retry count is kept on the work item itself because workers requeue copies of items.
+type backoffRateLimiter struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+func (b backoffRateLimiter) When(item interface{}) time.Duration {
+	wrapper, casted := item.(*workItemWrapper)
+	if !casted || b.base <= 0 || wrapper.retryCount == 0 {
+		return 0
+	}
+
+	delay := float64(b.base) * math.Pow(2, float64(wrapper.retryCount-1))
+	if b.jitter > 0 {
+		delay += delay * b.jitter * rand.Float64() // #nosec
+	}
+
+	if b.max > 0 && delay > float64(b.max) {
+		delay = float64(b.max)
+	}
+
+	return time.Duration(delay)
+}
+
+func (b backoffRateLimiter) Forget(item interface{}) {}
+
+func (b backoffRateLimiter) NumRequeues(item interface{}) int {
+	if wrapper, casted := item.(*workItemWrapper); casted {
+		return int(wrapper.retryCount)
+	}
+
+	return 0
+}
+
+func newBackoffRateLimiter(cfg BackoffConfig) workqueue.RateLimiter {
+	return backoffRateLimiter{
+		base:   cfg.Base.Duration,
+		max:    cfg.Max.Duration,
+		jitter: float64(cfg.JitterPercent) / 100,
+	}
+}
@@ -0,0 +1,166 @@
+package workqueue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type nonRetryableProcessor struct{}
+
+func (nonRetryableProcessor) Process(ctx context.Context, workItem WorkItem) (WorkStatus, error) {
+	return WorkStatusNotDone, NewNonRetryableError(fmt.Errorf("bad input"))
+}
+
+type terminalFailureProcessor struct {
+	err error
+}
+
+func (p terminalFailureProcessor) Process(ctx context.Context, workItem WorkItem) (WorkStatus, error) {
+	return WorkStatusFailed, p.err
+}
+
+type flakyProcessor struct {
+	failures int
+}
+
+func (p *flakyProcessor) Process(ctx context.Context, workItem WorkItem) (WorkStatus, error) {
+	if p.failures > 0 {
+		p.failures--
+		return WorkStatusNotDone, fmt.Errorf("transient")
+	}
+
+	return WorkStatusSucceeded, nil
+}
+
+func TestIsNonRetryable(t *testing.T) {
+	assert.Nil(t, NewNonRetryableError(nil))
+	assert.True(t, IsNonRetryable(NewNonRetryableError(fmt.Errorf("err"))))
+	assert.False(t, IsNonRetryable(fmt.Errorf("err")))
+	assert.True(t, IsNonRetryable(fmt.Errorf("wrapped: %w", NewNonRetryableError(fmt.Errorf("err")))))
+}
+
+func TestBackoffRateLimiter_When(t *testing.T) {
+	t.Run("Exponential with max", func(t *testing.T) {
+		r := newBackoffRateLimiter(BackoffConfig{
+			Base: config.Duration{Duration: time.Second},
+			Max:  config.Duration{Duration: 5 * time.Second},
+		})
+
+		assert.Equal(t, time.Duration(0), r.When(&workItemWrapper{}))
+		assert.Equal(t, time.Second, r.When(&workItemWrapper{retryCount: 1}))
+		assert.Equal(t, 4*time.Second, r.When(&workItemWrapper{retryCount: 3}))
+		assert.Equal(t, 5*time.Second, r.When(&workItemWrapper{retryCount: 10}))
+		assert.Equal(t, 10, r.NumRequeues(&workItemWrapper{retryCount: 10}))
+	})
+
+	t.Run("Jitter", func(t *testing.T) {
+		r := newBackoffRateLimiter(BackoffConfig{
+			Base:          config.Duration{Duration: time.Second},
+			JitterPercent: 50,
+		})
+
+		delay := r.When(&workItemWrapper{retryCount: 2})
+		assert.True(t, delay >= 2*time.Second && delay <= 3*time.Second, "delay: %v", delay)
+	})
+
+	t.Run("Jitter doesn't exceed max", func(t *testing.T) {
+		r := newBackoffRateLimiter(BackoffConfig{
+			Base:          config.Duration{Duration: time.Second},
+			Max:           config.Duration{Duration: 5 * time.Second},
+			JitterPercent: 100,
+		})
+
+		for i := 0; i < 10; i++ {
+			assert.True(t, r.When(&workItemWrapper{retryCount: 10}) <= 5*time.Second)
+		}
+	})
+
+	t.Run("No base", func(t *testing.T) {
+		r := newBackoffRateLimiter(BackoffConfig{})
+		assert.Equal(t, time.Duration(0), r.When(&workItemWrapper{retryCount: 3}))
+	})
+}
+
+func TestQueue_Retries(t *testing.T) {
+	t.Run("Non retryable fails fast", func(t *testing.T) {
+		q, err := NewIndexedWorkQueue("test1", nonRetryableProcessor{}, Config{Workers: 1, MaxRetries: 10, IndexCacheMaxItems: 1},
+			promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancelNow := context.WithCancel(context.Background())
+		defer cancelNow()
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+
+		info := waitForTerminal(t, q, "abc")
+		assert.Equal(t, WorkStatusFailed, info.Status())
+		assert.Equal(t, uint(1), info.RetryCount())
+		assert.Error(t, info.Error())
+	})
+
+	t.Run("Succeeds after retries", func(t *testing.T) {
+		q, err := NewIndexedWorkQueue("test1", &flakyProcessor{failures: 2}, Config{
+			Workers:            1,
+			MaxRetries:         5,
+			IndexCacheMaxItems: 1,
+			Backoff: BackoffConfig{
+				Base: config.Duration{Duration: time.Millisecond},
+				Max:  config.Duration{Duration: 5 * time.Millisecond},
+			},
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancelNow := context.WithCancel(context.Background())
+		defer cancelNow()
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+
+		info := waitForTerminal(t, q, "abc")
+		assert.Equal(t, WorkStatusSucceeded, info.Status())
+		assert.Equal(t, uint(2), info.RetryCount())
+		assert.NoError(t, info.Error())
+		assert.EqualError(t, info.LastError(), "transient")
+	})
+	t.Run("Terminal status with error isn't retried", func(t *testing.T) {
+		q, err := NewIndexedWorkQueue("test1", terminalFailureProcessor{err: fmt.Errorf("corrupted outputs")}, Config{
+			Workers:            1,
+			MaxRetries:         10,
+			IndexCacheMaxItems: 1,
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancelNow := context.WithCancel(context.Background())
+		defer cancelNow()
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+
+		info := waitForTerminal(t, q, "abc")
+		assert.Equal(t, WorkStatusFailed, info.Status())
+		assert.Equal(t, uint(1), info.RetryCount())
+		assert.EqualError(t, info.Error(), "corrupted outputs")
+	})
+
+	t.Run("Failed status without error", func(t *testing.T) {
+		q, err := NewIndexedWorkQueue("test1", terminalFailureProcessor{}, Config{
+			Workers:            1,
+			MaxRetries:         10,
+			IndexCacheMaxItems: 1,
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ctx, cancelNow := context.WithCancel(context.Background())
+		defer cancelNow()
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+
+		info := waitForTerminal(t, q, "abc")
+		assert.Equal(t, WorkStatusFailed, info.Status())
+		assert.Error(t, info.Error())
+	})
+}
@@ -26,6 +26,38 @@ type Config struct {
 	RoleAnnotationKey string           `json:"roleAnnotationKey" pflag:",Map key to use to lookup role from task annotations."`
 	OutputAssembler   workqueue.Config `json:"outputAssembler"`
 	ErrorAssembler    workqueue.Config `json:"errorAssembler"`
+	// Config for persisting the statuses of the output and error assemblers.
+	AssemblerPersistence workqueue.PersistenceConfig `json:"assemblerPersistence" pflag:",Config for persisting the statuses of the output and error assemblers."`
+	// Rules to route jobs to AWS Batch job queues. When no rule matches, the queue from the task config is used.
+	JobQueueRouting JobQueueRoutingConfig `json:"jobQueueRouting" pflag:"-,Rules to route jobs to AWS Batch job queues."`
+}
+
+type JobQueueRoutingConfig struct {
+	// Rules are evaluated in order and the first rule with an enabled queue wins.
+	Rules []JobQueueRoutingRule `json:"rules"`
+	// Queues listed here are never submitted to, whether a rule or the task config picked them.
+	DisabledQueues []string `json:"disabledQueues"`
+	// Queue used instead of the queue from the task config when the latter is disabled.
+	DefaultQueue string `json:"defaultQueue"`
+}
+
+// JobQueueRoutingRule matches a job to an ordered list of job queues. Empty or zero-valued criteria match everything.
+type JobQueueRoutingRule struct {
+	// Name of the rule, recorded along with the chosen queue.
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Domain  string `json:"domain"`
+	// Regular expression matched against the full task name.
+	TaskName string `json:"taskName"`
+	// If set, only matches tasks whose interruptibility equals this value.
+	Interruptible *bool `json:"interruptible"`
+	// Bounds (inclusive) on the vCPUs and memory (in MB) the job is submitted with.
+	MinVcpus    int64 `json:"minVcpus"`
+	MaxVcpus    int64 `json:"maxVcpus"`
+	MinMemoryMB int64 `json:"minMemoryMB"`
+	MaxMemoryMB int64 `json:"maxMemoryMB"`
+	// Job queues in order of preference. The first queue that isn't disabled is chosen.
+	Queues []string `json:"queues"`
 }
 
 type JobStoreConfig struct {
@@ -67,6 +99,9 @@ var (
 			MaxRetries:         5,
 			Workers:            10,
 		},
+		AssemblerPersistence: workqueue.PersistenceConfig{
+			TTL: config.Duration{Duration: 24 * time.Hour},
+		},
 	}
 
 	configSection = aws.MustRegisterSubSection("batch", defaultConfig)
@@ -68,8 +68,20 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "outputAssembler.workers"), defaultConfig.OutputAssembler.Workers, "Number of concurrent workers to start processing the queue.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "outputAssembler.maxRetries"), defaultConfig.OutputAssembler.MaxRetries, "Maximum number of retries per item.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "outputAssembler.maxItems"), defaultConfig.OutputAssembler.IndexCacheMaxItems, "Maximum number of entries to keep in the index.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "outputAssembler.backoff.base"), defaultConfig.OutputAssembler.Backoff.Base.String(), "Delay before the first retry of a failed item.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "outputAssembler.backoff.max"), defaultConfig.OutputAssembler.Backoff.Max.String(), "Maximum delay between retries of a failed item.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "outputAssembler.backoff.jitterPercent"), defaultConfig.OutputAssembler.Backoff.JitterPercent, "Maximum percentage of the delay to randomly add to each retry delay.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "errorAssembler.workers"), defaultConfig.ErrorAssembler.Workers, "Number of concurrent workers to start processing the queue.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "errorAssembler.maxRetries"), defaultConfig.ErrorAssembler.MaxRetries, "Maximum number of retries per item.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "errorAssembler.maxItems"), defaultConfig.ErrorAssembler.IndexCacheMaxItems, "Maximum number of entries to keep in the index.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "errorAssembler.backoff.base"), defaultConfig.ErrorAssembler.Backoff.Base.String(), "Delay before the first retry of a failed item.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "errorAssembler.backoff.max"), defaultConfig.ErrorAssembler.Backoff.Max.String(), "Maximum delay between retries of a failed item.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "errorAssembler.backoff.jitterPercent"), defaultConfig.ErrorAssembler.Backoff.JitterPercent, "Maximum percentage of the delay to randomly add to each retry delay.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.enabled"), defaultConfig.AssemblerPersistence.Enabled, "Enables persisting terminal item statuses so they survive restarts and evictions.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.type"), defaultConfig.AssemblerPersistence.Type, "Where item statuses are persisted. One of storage or boltdb.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.prefix"), defaultConfig.AssemblerPersistence.Prefix, "Storage prefix under which item statuses are persisted. Only used by the storage type.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.path"), defaultConfig.AssemblerPersistence.Path, "Path of the BoltDB file item statuses are persisted in. Only used by the boltdb type.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.ttl"), defaultConfig.AssemblerPersistence.TTL.String(), "Duration after which a persisted item status is discarded and the item is processed again.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "assemblerPersistence.gcInterval"), defaultConfig.AssemblerPersistence.GCInterval.String(), "Interval at which expired item statuses are removed from BoltDB files. Defaults to the TTL.")
 	return cmdFlags
 }
@@ -351,6 +351,48 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_outputAssembler.backoff.base", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.OutputAssembler.Backoff.Base.String()
+
+			cmdFlags.Set("outputAssembler.backoff.base", testValue)
+			if vString, err := cmdFlags.GetString("outputAssembler.backoff.base"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.OutputAssembler.Backoff.Base)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_outputAssembler.backoff.max", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.OutputAssembler.Backoff.Max.String()
+
+			cmdFlags.Set("outputAssembler.backoff.max", testValue)
+			if vString, err := cmdFlags.GetString("outputAssembler.backoff.max"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.OutputAssembler.Backoff.Max)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_outputAssembler.backoff.jitterPercent", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("outputAssembler.backoff.jitterPercent", testValue)
+			if vInt, err := cmdFlags.GetInt("outputAssembler.backoff.jitterPercent"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.OutputAssembler.Backoff.JitterPercent)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_errorAssembler.workers", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -393,4 +435,130 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_errorAssembler.backoff.base", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ErrorAssembler.Backoff.Base.String()
+
+			cmdFlags.Set("errorAssembler.backoff.base", testValue)
+			if vString, err := cmdFlags.GetString("errorAssembler.backoff.base"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ErrorAssembler.Backoff.Base)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_errorAssembler.backoff.max", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ErrorAssembler.Backoff.Max.String()
+
+			cmdFlags.Set("errorAssembler.backoff.max", testValue)
+			if vString, err := cmdFlags.GetString("errorAssembler.backoff.max"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ErrorAssembler.Backoff.Max)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_errorAssembler.backoff.jitterPercent", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("errorAssembler.backoff.jitterPercent", testValue)
+			if vInt, err := cmdFlags.GetInt("errorAssembler.backoff.jitterPercent"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.ErrorAssembler.Backoff.JitterPercent)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("assemblerPersistence.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("assemblerPersistence.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.AssemblerPersistence.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.type", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("assemblerPersistence.type", testValue)
+			if vString, err := cmdFlags.GetString("assemblerPersistence.type"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AssemblerPersistence.Type)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.prefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("assemblerPersistence.prefix", testValue)
+			if vString, err := cmdFlags.GetString("assemblerPersistence.prefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AssemblerPersistence.Prefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.path", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("assemblerPersistence.path", testValue)
+			if vString, err := cmdFlags.GetString("assemblerPersistence.path"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AssemblerPersistence.Path)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.AssemblerPersistence.TTL.String()
+
+			cmdFlags.Set("assemblerPersistence.ttl", testValue)
+			if vString, err := cmdFlags.GetString("assemblerPersistence.ttl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AssemblerPersistence.TTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_assemblerPersistence.gcInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.AssemblerPersistence.GCInterval.String()
+
+			cmdFlags.Set("assemblerPersistence.gcInterval", testValue)
+			if vString, err := cmdFlags.GetString("assemblerPersistence.gcInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AssemblerPersistence.GCInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
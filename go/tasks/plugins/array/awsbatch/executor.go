@@ -16,6 +16,8 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/utils"
 
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/aws"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
@@ -31,6 +33,7 @@ const (
 type Executor struct {
 	jobStore           *JobStore
 	jobDefinitionCache definition.Cache
+	jobQueueRouter     *JobQueueRouter
 
 	outputAssembler array.OutputAssembler
 	errorAssembler  array.OutputAssembler
@@ -75,7 +78,7 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 		fallthrough
 
 	case arrayCore.PhaseLaunch:
-		pluginState, err = LaunchSubTasks(ctx, tCtx, e.jobStore, pluginConfig, pluginState, e.metrics)
+		pluginState, err = LaunchSubTasks(ctx, tCtx, e.jobStore, pluginConfig, e.jobQueueRouter, pluginState, e.metrics)
 
 	case arrayCore.PhaseCheckingSubTaskExecutions:
 		pluginState, err = CheckSubTasksState(ctx, tCtx.TaskExecutionMetadata(),
@@ -117,6 +120,17 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 		return core.UnknownTransition, err
 	}
 
+	if info := phaseInfo.Info(); info != nil && len(pluginState.GetJobQueue()) > 0 {
+		info.CustomInfo, err = pluginUtils.MarshalObjToStruct(map[string]string{
+			jobQueueCustomInfoKey:     pluginState.GetJobQueue(),
+			jobQueueRuleCustomInfoKey: pluginState.GetJobQueueRule(),
+		})
+
+		if err != nil {
+			return core.UnknownTransition, errors.Wrapf(errors.RuntimeFailure, err, "Failed to marshal custom info")
+		}
+	}
+
 	return core.DoTransition(phaseInfo), nil
 }
 
@@ -149,13 +163,24 @@ func NewExecutor(ctx context.Context, awsClient aws.Client, cfg *batchConfig.Con
 		return Executor{}, err
 	}
 
-	outputAssembler, err := array.NewOutputAssembler(cfg.OutputAssembler, scope.NewSubScope("output_assembler"))
+	jobQueueRouter, err := NewJobQueueRouter(cfg.JobQueueRouting)
+	if err != nil {
+		return Executor{}, err
+	}
+
+	store, err := array.NewAssemblerDataStore(cfg.AssemblerPersistence, scope.NewSubScope("assembler_store"))
+	if err != nil {
+		return Executor{}, err
+	}
+
+	outputAssembler, err := array.NewOutputAssembler(ctx, cfg.OutputAssembler, cfg.AssemblerPersistence, store,
+		scope.NewSubScope("output_assembler"))
 	if err != nil {
 		return Executor{}, err
 	}
 
-	errorAssembler, err := array.NewErrorAssembler(cfg.MaxErrorStringLength, cfg.ErrorAssembler,
-		scope.NewSubScope("error_assembler"))
+	errorAssembler, err := array.NewErrorAssembler(ctx, cfg.MaxErrorStringLength, cfg.ErrorAssembler,
+		cfg.AssemblerPersistence, store, scope.NewSubScope("error_assembler"))
 	if err != nil {
 		return Executor{}, err
 	}
@@ -163,6 +188,7 @@ func NewExecutor(ctx context.Context, awsClient aws.Client, cfg *batchConfig.Con
 	return Executor{
 		jobStore:           &jobStore,
 		jobDefinitionCache: definition.NewCache(cfg.JobDefCacheSize),
+		jobQueueRouter:     jobQueueRouter,
 		outputAssembler:    outputAssembler,
 		errorAssembler:     errorAssembler,
 		metrics:            getAwsBatchExecutorMetrics(scope.NewSubScope("awsbatch")),
@@ -0,0 +1,163 @@
+package awsbatch
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	jobQueueCustomInfoKey     = "jobQueue"
+	jobQueueRuleCustomInfoKey = "jobQueueRule"
+)
+
+// JobQueueSelection is the result of routing a job through the configured job queue rules.
+type JobQueueSelection struct {
+	// The job queue the job should be submitted to.
+	Queue string
+	// Name of the rule that selected the queue. Empty if no rule matched.
+	Rule string
+}
+
+func matchesBounds(value, min, max int64) bool {
+	if min > 0 && value < min {
+		return false
+	}
+
+	if max > 0 && value > max {
+		return false
+	}
+
+	return true
+}
+
+type jobQueueRule struct {
+	config.JobQueueRoutingRule
+	taskName *regexp.Regexp
+}
+
+// JobQueueRouter routes jobs to AWS Batch job queues according to the job queue routing rules.
+type JobQueueRouter struct {
+	rules          []jobQueueRule
+	disabledQueues sets.String
+	defaultQueue   string
+}
+
+// NewJobQueueRouter compiles the job queue routing rules. It fails if the task name expression of a rule is invalid.
+func NewJobQueueRouter(cfg config.JobQueueRoutingConfig) (*JobQueueRouter, error) {
+	rules := make([]jobQueueRule, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		compiled := jobQueueRule{JobQueueRoutingRule: rule}
+		if len(rule.TaskName) > 0 {
+			var err error
+			compiled.taskName, err = regexp.Compile("^(?:" + rule.TaskName + ")$")
+			if err != nil {
+				return nil, errors.Wrapf(errors.BadTaskSpecification, err,
+					"Invalid task name expression in job queue rule [%v]", rule.Name)
+			}
+		}
+
+		rules = append(rules, compiled)
+	}
+
+	return &JobQueueRouter{
+		rules:          rules,
+		disabledQueues: sets.NewString(cfg.DisabledQueues...),
+		defaultQueue:   cfg.DefaultQueue,
+	}, nil
+}
+
+func (r jobQueueRule) matches(taskExecID pluginCore.TaskExecutionID, interruptible bool, vcpus, memoryMB int64) bool {
+	taskID := taskExecID.GetID().TaskId
+	if len(r.Project) > 0 && r.Project != taskID.GetProject() {
+		return false
+	}
+
+	if len(r.Domain) > 0 && r.Domain != taskID.GetDomain() {
+		return false
+	}
+
+	if r.taskName != nil && !r.taskName.MatchString(taskID.GetName()) {
+		return false
+	}
+
+	if r.Interruptible != nil && *r.Interruptible != interruptible {
+		return false
+	}
+
+	return matchesBounds(vcpus, r.MinVcpus, r.MaxVcpus) &&
+		matchesBounds(memoryMB, r.MinMemoryMB, r.MaxMemoryMB)
+}
+
+// HasRules returns whether any job queue routing rule is configured.
+func (r *JobQueueRouter) HasRules() bool {
+	return r != nil && len(r.rules) > 0
+}
+
+func (r *JobQueueRouter) isDisabled(queue string) bool {
+	return r != nil && r.disabledQueues.Has(queue)
+}
+
+// Route evaluates the job queue routing rules against the task and the resources it's about to be submitted with. If
+// no rule yields an enabled queue, the queue already set on the batch input is kept, or the default queue if that one
+// is disabled. It fails if every candidate queue is disabled.
+func (r *JobQueueRouter) Route(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata,
+	batchInput *batch.SubmitJobInput) (JobQueueSelection, error) {
+
+	if r.HasRules() {
+		var vcpus, memoryMB int64
+		if overrides := batchInput.ContainerOverrides; overrides != nil {
+			if overrides.Vcpus != nil {
+				vcpus = *overrides.Vcpus
+			}
+
+			if overrides.Memory != nil {
+				memoryMB = *overrides.Memory
+			}
+		}
+
+		for _, rule := range r.rules {
+			if !rule.matches(taskMeta.GetTaskExecutionID(), taskMeta.IsInterruptible(), vcpus, memoryMB) {
+				continue
+			}
+
+			for _, queue := range rule.Queues {
+				if r.isDisabled(queue) {
+					logger.Debugf(ctx, "Job queue [%v] of rule [%v] is disabled, trying the next one.", queue, rule.Name)
+					continue
+				}
+
+				return JobQueueSelection{
+					Queue: queue,
+					Rule:  rule.Name,
+				}, nil
+			}
+
+			logger.Infof(ctx, "Job queue rule [%v] matched but all of its queues are disabled.", rule.Name)
+		}
+	}
+
+	selection := JobQueueSelection{}
+	if batchInput.JobQueue != nil {
+		selection.Queue = *batchInput.JobQueue
+	}
+
+	if len(selection.Queue) == 0 || !r.isDisabled(selection.Queue) {
+		return selection, nil
+	}
+
+	logger.Infof(ctx, "Job queue [%v] is disabled, falling back to the default job queue [%v].", selection.Queue,
+		r.defaultQueue)
+	if len(r.defaultQueue) == 0 || r.isDisabled(r.defaultQueue) {
+		return JobQueueSelection{}, errors.Errorf(errors.BadTaskSpecification,
+			"Job queue [%v] is disabled and no enabled default job queue is configured", selection.Queue)
+	}
+
+	return JobQueueSelection{Queue: r.defaultQueue}, nil
+}
@@ -0,0 +1,151 @@
+package awsbatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRoutingTaskMeta(project, domain, name string, interruptible bool) *mocks.TaskExecutionMetadata {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		TaskId: &core.Identifier{
+			ResourceType: core.ResourceType_TASK,
+			Project:      project,
+			Domain:       domain,
+			Name:         name,
+		},
+	})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnIsInterruptible().Return(interruptible)
+	return tMeta
+}
+
+func newRoutingBatchInput(queue string, vcpus, memoryMB int64) *batch.SubmitJobInput {
+	return &batch.SubmitJobInput{
+		JobQueue: refStr(queue),
+		ContainerOverrides: &batch.ContainerOverrides{
+			Vcpus:  refInt(vcpus),
+			Memory: refInt(memoryMB),
+		},
+	}
+}
+
+func TestJobQueueRouter_Route(t *testing.T) {
+	ctx := context.Background()
+	interruptible := true
+	routing := config.JobQueueRoutingConfig{
+		Rules: []config.JobQueueRoutingRule{
+			{
+				Name:          "spot",
+				Project:       "flytesnacks",
+				Interruptible: &interruptible,
+				Queues:        []string{"spot-1", "spot-2"},
+			},
+			{
+				Name:        "large",
+				TaskName:    `.*\.train_.*`,
+				MinVcpus:    16,
+				MinMemoryMB: 64000,
+				Queues:      []string{"large"},
+			},
+			{
+				Name:   "dev",
+				Domain: "development",
+				Queues: []string{"dev"},
+			},
+		},
+		DisabledQueues: []string{"spot-1"},
+	}
+
+	router, err := NewJobQueueRouter(routing)
+	assert.NoError(t, err)
+
+	t.Run("No rules", func(t *testing.T) {
+		emptyRouter, err := NewJobQueueRouter(config.JobQueueRoutingConfig{})
+		assert.NoError(t, err)
+		assert.False(t, emptyRouter.HasRules())
+		selection, err := emptyRouter.Route(ctx, &mocks.TaskExecutionMetadata{}, newRoutingBatchInput("default", 1, 1000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "default"}, selection)
+	})
+
+	t.Run("Fallback when queue is disabled", func(t *testing.T) {
+		selection, err := router.Route(ctx, newRoutingTaskMeta("flytesnacks", "production", "wf.t1", true),
+			newRoutingBatchInput("default", 1, 1000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "spot-2", Rule: "spot"}, selection)
+	})
+
+	t.Run("Resource size and task name", func(t *testing.T) {
+		selection, err := router.Route(ctx, newRoutingTaskMeta("flytesnacks", "development", "wf.train_model", false),
+			newRoutingBatchInput("default", 32, 128000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "large", Rule: "large"}, selection)
+
+		selection, err = router.Route(ctx, newRoutingTaskMeta("flytesnacks", "development", "wf.train_model", false),
+			newRoutingBatchInput("default", 2, 128000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "dev", Rule: "dev"}, selection)
+	})
+
+	t.Run("All queues disabled", func(t *testing.T) {
+		disabledRouter, err := NewJobQueueRouter(config.JobQueueRoutingConfig{
+			Rules:          routing.Rules,
+			DisabledQueues: []string{"spot-1", "spot-2"},
+		})
+		assert.NoError(t, err)
+		selection, err := disabledRouter.Route(ctx, newRoutingTaskMeta("flytesnacks", "production", "wf.t1", true),
+			newRoutingBatchInput("default", 1, 1000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "default"}, selection)
+	})
+
+	t.Run("Disabled task config queue", func(t *testing.T) {
+		disabledRouter, err := NewJobQueueRouter(config.JobQueueRoutingConfig{
+			DisabledQueues: []string{"default"},
+			DefaultQueue:   "fallback",
+		})
+		assert.NoError(t, err)
+		selection, err := disabledRouter.Route(ctx, &mocks.TaskExecutionMetadata{}, newRoutingBatchInput("default", 1, 1000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "fallback"}, selection)
+
+		selection, err = disabledRouter.Route(ctx, &mocks.TaskExecutionMetadata{}, newRoutingBatchInput("other", 1, 1000))
+		assert.NoError(t, err)
+		assert.Equal(t, JobQueueSelection{Queue: "other"}, selection)
+	})
+
+	t.Run("Every candidate queue disabled", func(t *testing.T) {
+		disabledRouter, err := NewJobQueueRouter(config.JobQueueRoutingConfig{
+			Rules:          routing.Rules,
+			DisabledQueues: []string{"spot-1", "spot-2", "default"},
+		})
+		assert.NoError(t, err)
+		_, err = disabledRouter.Route(ctx, newRoutingTaskMeta("flytesnacks", "production", "wf.t1", true),
+			newRoutingBatchInput("default", 1, 1000))
+		assert.Error(t, err)
+
+		disabledRouter, err = NewJobQueueRouter(config.JobQueueRoutingConfig{
+			DisabledQueues: []string{"default", "fallback"},
+			DefaultQueue:   "fallback",
+		})
+		assert.NoError(t, err)
+		_, err = disabledRouter.Route(ctx, &mocks.TaskExecutionMetadata{}, newRoutingBatchInput("default", 1, 1000))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid task name expression", func(t *testing.T) {
+		_, err := NewJobQueueRouter(config.JobQueueRoutingConfig{
+			Rules: []config.JobQueueRoutingRule{{Name: "bad", TaskName: "(", Queues: []string{"q"}}},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -16,7 +16,7 @@ import (
 )
 
 func LaunchSubTasks(ctx context.Context, tCtx core.TaskExecutionContext, batchClient Client, pluginConfig *config.Config,
-	currentState *State, metrics ExecutorMetrics) (nextState *State, err error) {
+	jobQueueRouter *JobQueueRouter, currentState *State, metrics ExecutorMetrics) (nextState *State, err error) {
 	size := currentState.GetExecutionArraySize()
 	if int64(currentState.GetExecutionArraySize()) > pluginConfig.MaxArrayJobSize {
 		ee := fmt.Errorf("array size > max allowed. Requested [%v]. Allowed [%v]", currentState.GetExecutionArraySize(), pluginConfig.MaxArrayJobSize)
@@ -35,6 +35,19 @@ func LaunchSubTasks(ctx context.Context, tCtx core.TaskExecutionContext, batchCl
 		return nil, err
 	}
 
+	queue, err := jobQueueRouter.Route(ctx, tCtx.TaskExecutionMetadata(), batchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(queue.Queue) == 0 {
+		return nil, errors.Errorf(errors.BadTaskSpecification, "No job queue rule matched and config[%v] is missing",
+			DynamicTaskQueueKey)
+	}
+
+	logger.Debugf(ctx, "Submitting job to queue [%v] (rule [%v]).", queue.Queue, queue.Rule)
+	batchInput.JobQueue = refStr(queue.Queue)
+
 	t, err := tCtx.TaskReader().Read(ctx)
 	if err != nil {
 		return nil, err
@@ -63,7 +76,7 @@ func LaunchSubTasks(ctx context.Context, tCtx core.TaskExecutionContext, batchCl
 		}).
 		SetReason("Successfully launched subtasks.")
 
-	nextState = currentState.SetExternalJobID(j)
+	nextState = currentState.SetExternalJobID(j).SetJobQueue(queue.Queue, queue.Rule)
 	nextState.State = parentState
 
 	return nextState, nil
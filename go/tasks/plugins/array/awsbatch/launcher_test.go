@@ -124,9 +124,10 @@ func TestLaunchSubTasks(t *testing.T) {
 
 			ExternalJobID:    refStr("qpxyarq"),
 			JobDefinitionArn: "arn",
+			JobQueue:         "queue1",
 		}
 
-		newState, err := LaunchSubTasks(context.TODO(), tCtx, batchClient, &config.Config{MaxArrayJobSize: 10}, currentState, getAwsBatchExecutorMetrics(promutils.NewTestScope()))
+		newState, err := LaunchSubTasks(context.TODO(), tCtx, batchClient, &config.Config{MaxArrayJobSize: 10}, nil, currentState, getAwsBatchExecutorMetrics(promutils.NewTestScope()))
 		assert.NoError(t, err)
 		assertEqual(t, expectedState, newState)
 	})
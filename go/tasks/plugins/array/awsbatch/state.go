@@ -10,6 +10,8 @@ type State struct {
 
 	ExternalJobID    *string `json:"externalJobID"`
 	JobDefinitionArn definition.JobDefinitionArn
+	JobQueue         string `json:"jobQueue,omitempty"`
+	JobQueueRule     string `json:"jobQueueRule,omitempty"`
 }
 
 func (s State) GetJobDefinitionArn() definition.JobDefinitionArn {
@@ -20,6 +22,14 @@ func (s State) GetExternalJobID() *string {
 	return s.ExternalJobID
 }
 
+func (s State) GetJobQueue() string {
+	return s.JobQueue
+}
+
+func (s State) GetJobQueueRule() string {
+	return s.JobQueueRule
+}
+
 func (s *State) SetJobDefinitionArn(arn definition.JobDefinitionArn) *State {
 	s.JobDefinitionArn = arn
 	return s
@@ -29,3 +39,9 @@ func (s *State) SetExternalJobID(jobID string) *State {
 	s.ExternalJobID = &jobID
 	return s
 }
+
+func (s *State) SetJobQueue(queue, rule string) *State {
+	s.JobQueue = queue
+	s.JobQueueRule = rule
+	return s
+}
@@ -54,11 +54,15 @@ func GetTaskLinks(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata
 		}, nil
 	}
 
-	// TODO: Add tasktemplate container config to job config
-	jobConfig := newJobConfig().
-		MergeFromConfigMap(taskMeta.GetOverrides().GetConfig())
+	queue := state.GetJobQueue()
+	if len(queue) == 0 {
+		// TODO: Add tasktemplate container config to job config
+		queue = newJobConfig().
+			MergeFromConfigMap(taskMeta.GetOverrides().GetConfig()).DynamicTaskQueue
+	}
+
 	logLinks = append(logLinks, GetJobTaskLog(state.GetExecutionArraySize(), jobStore.Client.GetAccountID(),
-		jobStore.Client.GetRegion(), jobConfig.DynamicTaskQueue, *state.GetExternalJobID()))
+		jobStore.Client.GetRegion(), queue, *state.GetExternalJobID()))
 
 	jobName := taskMeta.GetTaskExecutionID().GetGeneratedName()
 	job, err := jobStore.GetOrCreate(jobName, &Job{
@@ -46,7 +46,7 @@ func FlyteTaskToBatchInput(ctx context.Context, tCtx pluginCore.TaskExecutionCon
 	jobConfig := newJobConfig().
 		MergeFromKeyValuePairs(taskTemplate.GetContainer().GetConfig()).
 		MergeFromConfigMap(tCtx.TaskExecutionMetadata().GetOverrides().GetConfig())
-	if len(jobConfig.DynamicTaskQueue) == 0 {
+	if len(jobConfig.DynamicTaskQueue) == 0 && len(cfg.JobQueueRouting.Rules) == 0 {
 		return nil, errors.Errorf(errors.BadTaskSpecification, "config[%v] is missing", DynamicTaskQueueKey)
 	}
 
@@ -103,7 +103,8 @@ func DetermineDiscoverability(ctx context.Context, tCtx core.TaskExecutionContex
 	}
 
 	// build work items from inputs and outputs
-	workItems, err := ConstructCatalogReaderWorkItems(ctx, tCtx.TaskReader(), inputReaders, outputWriters)
+	workItems, err := ConstructCatalogReaderWorkItems(ctx, tCtx.TaskReader(), inputReaders, outputWriters,
+		workQueueOwner(tCtx))
 	if err != nil {
 		return state, err
 	}
@@ -217,7 +218,7 @@ func WriteToDiscovery(ctx context.Context, tCtx core.TaskExecutionContext, state
 	// Create catalog put items, but only put the ones that were not originally cached (as read from the catalog results bitset)
 	catalogWriterItems, err := ConstructCatalogUploadRequests(*tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().TaskId,
 		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(), taskTemplate.Metadata.DiscoveryVersion,
-		iface, &tasksToCache, inputReaders, outputReaders)
+		iface, &tasksToCache, inputReaders, outputReaders, tCtx.OutputWriter().GetOutputPrefixPath(), workQueueOwner(tCtx))
 
 	if err != nil {
 		return nil, err
@@ -269,7 +270,8 @@ func WriteToCatalog(ctx context.Context, ownerSignal core.SignalAsync, catalogCl
 
 func ConstructCatalogUploadRequests(keyID idlCore.Identifier, taskExecID idlCore.TaskExecutionIdentifier,
 	cacheVersion string, taskInterface idlCore.TypedInterface, whichTasksToCache *bitarray.BitSet,
-	inputReaders []io.InputReader, outputReaders []io.OutputReader) ([]catalog.UploadRequest, error) {
+	inputReaders []io.InputReader, outputReaders []io.OutputReader, outputPrefix storage.DataReference, owner string) (
+	[]catalog.UploadRequest, error) {
 
 	writerWorkItems := make([]catalog.UploadRequest, 0, len(inputReaders))
 
@@ -294,6 +296,8 @@ func ConstructCatalogUploadRequests(keyID idlCore.Identifier, taskExecID idlCore
 			ArtifactMetadata: catalog.Metadata{
 				TaskExecutionIdentifier: &taskExecID,
 			},
+			OutputPrefix: outputPrefix,
+			Owner:        owner,
 		}
 
 		writerWorkItems = append(writerWorkItems, wi)
@@ -360,7 +364,7 @@ func makeSingularTaskInterface(varMap *idlCore.VariableMap) *idlCore.VariableMap
 }
 
 func ConstructCatalogReaderWorkItems(ctx context.Context, taskReader core.TaskReader, inputs []io.InputReader,
-	outputs []io.OutputWriter) ([]catalog.DownloadRequest, error) {
+	outputs []io.OutputWriter, owner string) ([]catalog.DownloadRequest, error) {
 
 	t, err := taskReader.Read(ctx)
 	if err != nil {
@@ -382,6 +386,7 @@ func ConstructCatalogReaderWorkItems(ctx context.Context, taskReader core.TaskRe
 				TypedInterface: iface,
 			},
 			Target: outputs[idx],
+			Owner:  owner,
 		}
 		workItems = append(workItems, item)
 	}
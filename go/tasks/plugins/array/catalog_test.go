@@ -66,7 +66,10 @@ func runDetermineDiscoverabilityTest(t testing.TB, taskTemplate *core.TaskTempla
 	assert.NoError(t, err)
 
 	cat := &catalogMocks.AsyncClient{}
-	cat.OnDownloadMatch(mock.Anything, mock.Anything).Return(future, nil)
+	cat.OnDownloadMatch(mock.Anything, mock.MatchedBy(func(request catalog.DownloadRequest) bool {
+		// Lookups are shared fairly between task executions.
+		return request.Owner == "generated-name"
+	})).Return(future, nil)
 
 	ir := &ioMocks.InputReader{}
 	ir.OnGetInputPrefixPath().Return("/prefix/")
@@ -112,11 +115,18 @@ func runDetermineDiscoverabilityTest(t testing.TB, taskTemplate *core.TaskTempla
 		return err
 	})
 
+	tID := &pluginMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("generated-name")
+
+	tMeta := &pluginMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
 	tCtx := &pluginMocks.TaskExecutionContext{}
 	tCtx.OnTaskReader().Return(tr)
 	tCtx.OnInputReader().Return(ir)
 	tCtx.OnDataStore().Return(ds)
 	tCtx.OnCatalog().Return(cat)
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
 	tCtx.OnOutputWriter().Return(ow)
 	tCtx.OnTaskRefreshIndicator().Return(func(ctx context.Context) {
 		t.Log("Refresh called")
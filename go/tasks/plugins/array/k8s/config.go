@@ -5,10 +5,13 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	"github.com/flyteorg/flytestdlib/config"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
@@ -37,9 +40,18 @@ var (
 			MaxRetries:         5,
 			Workers:            10,
 		},
+		AssemblerPersistence: workqueue.PersistenceConfig{
+			TTL: config.Duration{Duration: 24 * time.Hour},
+		},
 	}
 
-	configSection = pluginsConfig.MustRegisterSubSection(configSectionKey, defaultConfig)
+	configSection = pluginsConfig.MustRegisterSubSectionWithUpdates(configSectionKey, defaultConfig,
+		func(ctx context.Context, newValue config.Config) {
+			logResolver.Update(&newValue.(*Config).LogConfig.Config)
+		})
+
+	// Resolves the log plugins of sub tasks, rebuilt whenever the config changes.
+	logResolver = logs.NewCachedResolver(&defaultConfig.LogConfig.Config)
 )
 
 type ResourceConfig struct {
@@ -118,7 +130,8 @@ type Config struct {
 	NamespaceTemplate    string            `json:"namespaceTemplate"  pflag:"-,Namespace pattern to spawn array-jobs in. Defaults to parent namespace if not set"`
 	OutputAssembler      workqueue.Config
 	ErrorAssembler       workqueue.Config
-	LogConfig            LogConfig `json:"logs" pflag:",Config for log links for k8s array jobs."`
+	AssemblerPersistence workqueue.PersistenceConfig `json:"assemblerPersistence" pflag:",Config for persisting the statuses of the output and error assemblers."`
+	LogConfig            LogConfig                   `json:"logs" pflag:",Config for log links for k8s array jobs."`
 }
 
 type LogConfig struct {
@@ -47,13 +47,20 @@ func NewKubeClientObj(c client.Client) core.KubeClient {
 	}
 }
 
-func NewExecutor(kubeClient core.KubeClient, cfg *Config, scope promutils.Scope) (Executor, error) {
-	outputAssembler, err := array.NewOutputAssembler(cfg.OutputAssembler, scope.NewSubScope("output_assembler"))
+func NewExecutor(ctx context.Context, kubeClient core.KubeClient, cfg *Config, scope promutils.Scope) (Executor, error) {
+	store, err := array.NewAssemblerDataStore(cfg.AssemblerPersistence, scope.NewSubScope("assembler_store"))
 	if err != nil {
 		return Executor{}, err
 	}
 
-	errorAssembler, err := array.NewErrorAssembler(cfg.MaxErrorStringLength, cfg.ErrorAssembler, scope.NewSubScope("error_assembler"))
+	outputAssembler, err := array.NewOutputAssembler(ctx, cfg.OutputAssembler, cfg.AssemblerPersistence, store,
+		scope.NewSubScope("output_assembler"))
+	if err != nil {
+		return Executor{}, err
+	}
+
+	errorAssembler, err := array.NewErrorAssembler(ctx, cfg.MaxErrorStringLength, cfg.ErrorAssembler,
+		cfg.AssemblerPersistence, store, scope.NewSubScope("error_assembler"))
 	if err != nil {
 		return Executor{}, err
 	}
@@ -193,7 +200,7 @@ func GetNewExecutorPlugin(ctx context.Context, iCtx core.SetupContext) (core.Plu
 	} else {
 		kubeClient = iCtx.KubeClient()
 	}
-	exec, err := NewExecutor(kubeClient, GetConfig(), iCtx.MetricsScope())
+	exec, err := NewExecutor(ctx, kubeClient, GetConfig(), iCtx.MetricsScope())
 	if err != nil {
 		return nil, err
 	}
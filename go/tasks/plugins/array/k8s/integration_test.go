@@ -38,7 +38,7 @@ func newMockExecutor(ctx context.Context, t testing.TB) (Executor, array.Advance
 	kubeClient := &mocks.KubeClient{}
 	kubeClient.OnGetClient().Return(mocks.NewFakeKubeClient())
 	kubeClient.OnGetCache().Return(mocks.NewFakeKubeCache())
-	e, err := NewExecutor(kubeClient, &Config{
+	e, err := NewExecutor(ctx, kubeClient, &Config{
 		MaxErrorStringLength: 200,
 		OutputAssembler: workqueue.Config{
 			Workers:            2,
@@ -61,7 +61,17 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 		currentState.ArrayStatus = *newArrayStatus
 	}
 
-	logPlugin, err := logs.InitializeLogPlugins(&config.LogConfig.Config)
+	// Check that the taskTemplate is valid
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return currentState, logLinks, subTaskIDs, err
+	} else if taskTemplate == nil {
+		return currentState, logLinks, subTaskIDs, fmt.Errorf("required value not set, taskTemplate is nil")
+	}
+
+	// Sub tasks are labeled like the parent task.
+	taskExecID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	logPlugin, err := logResolver.Resolve(logs.NewSelection(&taskExecID, taskTemplate.GetType(), tCtx.TaskExecutionMetadata().GetLabels()))
 	if err != nil {
 		logger.Errorf(ctx, "Error initializing LogPlugins: [%s]", err)
 		return currentState, logLinks, subTaskIDs, err
@@ -93,7 +103,8 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 				},
 				originalIdx,
 				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt,
-				logPlugin)
+				logPlugin,
+				tCtx.TaskExecutionMetadata().IsInterruptible())
 
 			if err != nil {
 				return currentState, logLinks, subTaskIDs, err
@@ -154,14 +165,6 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 
 	newState = newState.SetArrayStatus(*newArrayStatus)
 
-	// Check that the taskTemplate is valid
-	taskTemplate, err := tCtx.TaskReader().Read(ctx)
-	if err != nil {
-		return currentState, logLinks, subTaskIDs, err
-	} else if taskTemplate == nil {
-		return currentState, logLinks, subTaskIDs, fmt.Errorf("required value not set, taskTemplate is nil")
-	}
-
 	phase := arrayCore.SummaryToPhase(ctx, currentState.GetOriginalMinSuccesses()-currentState.GetOriginalArraySize()+int64(currentState.GetExecutionArraySize()), newArrayStatus.Summary)
 	if phase == arrayCore.PhaseWriteToDiscoveryThenFail {
 		errorMsg := msg.Summary(GetConfig().MaxErrorStringLength)
@@ -184,7 +187,8 @@ func LaunchAndCheckSubTasksState(ctx context.Context, tCtx core.TaskExecutionCon
 	return newState, logLinks, subTaskIDs, nil
 }
 
-func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8sTypes.NamespacedName, index int, retryAttempt uint32, logPlugin tasklog.Plugin) (
+func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8sTypes.NamespacedName, index int, retryAttempt uint32,
+	logPlugin tasklog.Plugin, isInterruptible bool) (
 	info core.PhaseInfo, err error) {
 
 	pod := &v1.Pod{
@@ -242,10 +246,9 @@ func FetchPodStatusAndLogs(ctx context.Context, client core.KubeClient, name k8s
 	case v1.PodSucceeded:
 		phaseInfo, err2 = flytek8s.DemystifySuccess(pod.Status, taskInfo)
 	case v1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		phaseInfo = core.PhaseInfoRetryableFailure(code, message, &taskInfo)
+		phaseInfo, err2 = flytek8s.DemystifyFailure(pod.Status, taskInfo, isInterruptible)
 	case v1.PodPending:
-		phaseInfo, err2 = flytek8s.DemystifyPending(pod.Status)
+		phaseInfo, err2 = flytek8s.DemystifyPendingPod(pod)
 	case v1.PodUnknown:
 		phaseInfo = core.PhaseInfoUndefined
 	default:
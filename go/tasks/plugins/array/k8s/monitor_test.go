@@ -157,6 +157,10 @@ func TestCheckSubTasksState(t *testing.T) {
 		cacheIndexes.Set(3)
 		cacheIndexes.Set(4)
 
+		// The log plugins are built when the config section is updated.
+		configSection.GetConfigUpdatedHandler()(ctx, &config)
+		defer configSection.GetConfigUpdatedHandler()(ctx, GetConfig())
+
 		newState, logLinks, subTaskIDs, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
 			CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
 			ExecutionArraySize:   5,
@@ -169,7 +169,8 @@ func (t *Task) Monitor(ctx context.Context, tCtx core.TaskExecutionContext, kube
 		},
 		originalIdx,
 		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt,
-		logPlugin)
+		logPlugin,
+		tCtx.TaskExecutionMetadata().IsInterruptible())
 	if err != nil {
 		return MonitorError, loglinks, errors2.Wrapf(ErrCheckPodStatus, err, "Failed to check pod status.")
 	}
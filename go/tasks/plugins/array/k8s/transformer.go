@@ -197,6 +197,11 @@ func FlyteArrayJobToK8sPodTemplate(ctx context.Context, tCtx core.TaskExecutionC
 		if err != nil {
 			return v1.Pod{}, nil, err
 		}
+
+		err = flytek8s.ApplyAcceleratorProfile(taskTemplate, tCtx.TaskExecutionMetadata(), &pod.Spec)
+		if err != nil {
+			return v1.Pod{}, nil, err
+		}
 	}
 
 	return pod, arrayJob, nil
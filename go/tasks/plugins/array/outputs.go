@@ -37,10 +37,21 @@ var (
 // Represents an indexed work queue that aggregates outputs of sub tasks.
 type OutputAssembler struct {
 	workqueue.IndexedWorkQueue
+	// The lane items are queued in. Errors are assembled with a lower priority than outputs.
+	priority workqueue.Priority
 }
 
-func (o OutputAssembler) Queue(ctx context.Context, id workqueue.WorkItemID, item *outputAssembleItem) error {
-	return o.IndexedWorkQueue.Queue(ctx, id, item)
+func (o OutputAssembler) Queue(ctx context.Context, id workqueue.WorkItemID, item *outputAssembleItem,
+	opts ...workqueue.QueueOption) error {
+
+	return o.IndexedWorkQueue.Queue(ctx, id, item, append([]workqueue.QueueOption{workqueue.WithPriority(o.priority)},
+		opts...)...)
+}
+
+// workQueueOwner returns the owner the work items of the array task are queued under, so that the workqueues are shared
+// fairly between array tasks regardless of their size.
+func workQueueOwner(tCtx pluginCore.TaskExecutionContext) string {
+	return tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
 }
 
 type outputAssembleItem struct {
@@ -189,7 +200,7 @@ func AssembleFinalOutputs(ctx context.Context, assemblyQueue OutputAssembler, tC
 			finalPhases: finalPhases,
 			outputPaths: tCtx.OutputWriter(),
 			dataStore:   tCtx.DataStore(),
-		})
+		}, workqueue.WithOwner(workQueueOwner(tCtx)))
 
 		if err != nil {
 			return nil, err
@@ -304,21 +315,41 @@ func (a assembleErrorsWorker) Process(ctx context.Context, workItem workqueue.Wo
 	return workqueue.WorkStatusSucceeded, nil
 }
 
-func NewOutputAssembler(workQueueConfig workqueue.Config, scope promutils.Scope) (OutputAssembler, error) {
-	q, err := workqueue.NewIndexedWorkQueue("output", assembleOutputsWorker{}, workQueueConfig, scope)
+// NewAssemblerDataStore creates the data store the output and error assemblers persist item statuses in, from the
+// storage config. It returns nil unless the persistence config needs one.
+func NewAssemblerDataStore(persistenceCfg workqueue.PersistenceConfig, scope promutils.Scope) (*storage.DataStore, error) {
+	if !persistenceCfg.Enabled || persistenceCfg.Type == workqueue.PersistenceTypeBoltDB {
+		return nil, nil
+	}
+
+	return storage.NewDataStore(storage.GetConfig(), scope)
+}
+
+// NewOutputAssembler creates the queue that assembles the outputs of array tasks. If persistence is enabled, the statuses
+// of assembled outputs survive restarts, so outputs aren't assembled again.
+func NewOutputAssembler(ctx context.Context, workQueueConfig workqueue.Config,
+	persistenceCfg workqueue.PersistenceConfig, store *storage.DataStore, scope promutils.Scope) (OutputAssembler, error) {
+
+	q, err := workqueue.NewPersistentIndexedWorkQueue(ctx, "output", assembleOutputsWorker{}, workQueueConfig,
+		persistenceCfg, store, scope)
 	if err != nil {
 		return OutputAssembler{}, err
 	}
 
 	return OutputAssembler{
 		IndexedWorkQueue: q,
+		priority:         workqueue.PriorityNormal,
 	}, nil
 }
 
-func NewErrorAssembler(maxErrorMessageLength int, workQueueConfig workqueue.Config, scope promutils.Scope) (OutputAssembler, error) {
-	q, err := workqueue.NewIndexedWorkQueue("error", assembleErrorsWorker{
+// NewErrorAssembler creates the queue that assembles the errors of array tasks. Like NewOutputAssembler, it persists the
+// statuses of assembled errors if persistence is enabled.
+func NewErrorAssembler(ctx context.Context, maxErrorMessageLength int, workQueueConfig workqueue.Config,
+	persistenceCfg workqueue.PersistenceConfig, store *storage.DataStore, scope promutils.Scope) (OutputAssembler, error) {
+
+	q, err := workqueue.NewPersistentIndexedWorkQueue(ctx, "error", assembleErrorsWorker{
 		maxErrorMessageLength: maxErrorMessageLength,
-	}, workQueueConfig, scope)
+	}, workQueueConfig, persistenceCfg, store, scope)
 
 	if err != nil {
 		return OutputAssembler{}, err
@@ -326,5 +357,6 @@ func NewErrorAssembler(maxErrorMessageLength int, workQueueConfig workqueue.Conf
 
 	return OutputAssembler{
 		IndexedWorkQueue: q,
+		priority:         workqueue.PriorityLow,
 	}, nil
 }
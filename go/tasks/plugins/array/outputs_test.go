@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
 
@@ -14,6 +16,7 @@ import (
 
 	mocks3 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
@@ -52,7 +55,7 @@ func TestOutputAssembler_Queue(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := &mocks.IndexedWorkQueue{}
-			q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			q.OnQueueMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
 			o := OutputAssembler{
 				IndexedWorkQueue: q,
@@ -169,8 +172,8 @@ func TestAssembleFinalOutputs(t *testing.T) {
 	t.Run("Found succeeded", func(t *testing.T) {
 		q := &mocks.IndexedWorkQueue{}
 		called := false
-		q.On("Queue", mock.Anything, mock.Anything, mock.Anything).Return(
-			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem) error {
+		q.On("Queue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem, opts ...workqueue.QueueOption) error {
 				i, casted := workItem.(*outputAssembleItem)
 				assert.True(t, casted)
 				assert.Equal(t, []string{"var1"}, i.varNames)
@@ -216,8 +219,8 @@ func TestAssembleFinalOutputs(t *testing.T) {
 	t.Run("Found failed", func(t *testing.T) {
 		q := &mocks.IndexedWorkQueue{}
 		called := false
-		q.On("Queue", mock.Anything, mock.Anything, mock.Anything).Return(
-			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem) error {
+		q.On("Queue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem, opts ...workqueue.QueueOption) error {
 				i, casted := workItem.(*outputAssembleItem)
 				assert.True(t, casted)
 				assert.Equal(t, []string{"var1"}, i.varNames)
@@ -254,8 +257,8 @@ func TestAssembleFinalOutputs(t *testing.T) {
 	t.Run("Not Found Queued then Succeeded", func(t *testing.T) {
 		q := &mocks.IndexedWorkQueue{}
 		called := false
-		q.On("Queue", mock.Anything, mock.Anything, mock.Anything).Return(
-			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem) error {
+		q.On("Queue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, id workqueue.WorkItemID, workItem workqueue.WorkItem, opts ...workqueue.QueueOption) error {
 				i, casted := workItem.(*outputAssembleItem)
 				assert.True(t, casted)
 				assert.Equal(t, []string{"var1"}, i.varNames)
@@ -400,40 +403,196 @@ func Test_assembleErrorsWorker_Process(t *testing.T) {
 	assert.Equal(t, workqueue.WorkStatusSucceeded, actual)
 }
 
+func TestAssembleFinalOutputs_QueueOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workQueueCfg := workqueue.Config{
+		Workers:            1,
+		IndexCacheMaxItems: 10,
+	}
+
+	outputAssembler, err := NewOutputAssembler(ctx, workQueueCfg, workqueue.PersistenceConfig{}, nil,
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, outputAssembler.Start(ctx))
+
+	errorAssembler, err := NewErrorAssembler(ctx, 0, workQueueCfg, workqueue.PersistenceConfig{}, nil,
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, errorAssembler.Start(ctx))
+
+	for _, tc := range []struct {
+		name      string
+		assembler OutputAssembler
+		priority  workqueue.Priority
+	}{
+		{"outputs", outputAssembler, workqueue.PriorityNormal},
+		{"errors", errorAssembler, workqueue.PriorityLow},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The in-memory store isn't safe for concurrent use, each assembler gets its own.
+			ds, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+			assert.NoError(t, err)
+
+			tID := &mocks3.TaskExecutionID{}
+			tID.OnGetGeneratedName().Return("array-task")
+
+			tMeta := &mocks3.TaskExecutionMetadata{}
+			tMeta.OnGetTaskExecutionID().Return(tID)
+
+			tReader := &mocks3.TaskReader{}
+			tReader.OnReadMatch(mock.Anything).Return(&core.TaskTemplate{
+				Interface: &core.TypedInterface{
+					Outputs: &core.VariableMap{
+						Variables: map[string]*core.Variable{"var1": {Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_INTEGER}}}},
+					},
+				},
+			}, nil)
+
+			ow := &mocks2.OutputWriter{}
+			ow.OnGetOutputPrefixPath().Return("/prefix/")
+			ow.OnGetOutputPath().Return("/prefix/outputs.pb")
+			ow.OnGetRawOutputPrefix().Return("/sandbox/")
+			ow.OnGetErrorPath().Return("/prefix/error.pb")
+
+			tCtx := &mocks3.TaskExecutionContext{}
+			tCtx.OnTaskExecutionMetadata().Return(tMeta)
+			tCtx.OnTaskReader().Return(tReader)
+			tCtx.OnOutputWriter().Return(ow)
+			tCtx.OnDataStore().Return(ds)
+
+			detailedStatus := arrayCore.NewPhasesCompactArray(1)
+			detailedStatus.SetItem(0, bitarray.Item(pluginCore.PhasePermanentFailure))
+
+			_, err = AssembleFinalOutputs(ctx, tc.assembler, tCtx, arrayCore.PhaseSuccess, &arrayCore.State{
+				ArrayStatus: arraystatus.ArrayStatus{
+					Detailed: detailedStatus,
+				},
+				IndexesToCache:       arrayCore.InvertBitSet(bitarray.NewBitSet(1), 1),
+				OriginalArraySize:    1,
+				ExecutionArraySize:   1,
+				OriginalMinSuccesses: 1,
+			})
+			assert.NoError(t, err)
+
+			// The items of an array task are grouped together, whatever the size of the array.
+			info, found, err := tc.assembler.Get("array-task")
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, "array-task", info.Owner())
+			assert.Equal(t, tc.priority, info.Priority())
+
+			assert.Eventually(t, func() bool {
+				info, _, err := tc.assembler.Get("array-task")
+				return err == nil && info.Status().IsTerminal()
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
 func TestNewOutputAssembler(t *testing.T) {
 	t.Run("Invalid Config", func(t *testing.T) {
-		_, err := NewOutputAssembler(workqueue.Config{
+		_, err := NewOutputAssembler(context.TODO(), workqueue.Config{
 			Workers: 1,
-		}, promutils.NewTestScope())
+		}, workqueue.PersistenceConfig{}, nil, promutils.NewTestScope())
 		assert.Error(t, err)
 	})
 
 	t.Run("Valid Config", func(t *testing.T) {
-		o, err := NewOutputAssembler(workqueue.Config{
+		o, err := NewOutputAssembler(context.TODO(), workqueue.Config{
 			Workers:            1,
 			IndexCacheMaxItems: 10,
-		}, promutils.NewTestScope())
+		}, workqueue.PersistenceConfig{}, nil, promutils.NewTestScope())
 		assert.NoError(t, err)
 		assert.NotNil(t, o)
 	})
+
+	t.Run("Recover after restart", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		memStore, err := storage.NewDataStore(&storage.Config{
+			Type: storage.TypeMemory,
+		}, promutils.NewTestScope())
+		assert.NoError(t, err)
+
+		ow := &mocks2.OutputWriter{}
+		ow.OnGetOutputPrefixPath().Return("/bucket/prefix")
+		ow.OnGetOutputPath().Return("/bucket/prefix/outputs.pb")
+		ow.OnGetRawOutputPrefix().Return("/bucket/sandbox/")
+
+		phases := arrayCore.NewPhasesCompactArray(1)
+		phases.SetItem(0, bitarray.Item(pluginCore.PhasePermanentFailure))
+
+		workQueueCfg := workqueue.Config{
+			Workers:            1,
+			IndexCacheMaxItems: 10,
+		}
+
+		persistenceCfg := workqueue.PersistenceConfig{
+			Enabled: true,
+			Type:    workqueue.PersistenceTypeBoltDB,
+			Path:    filepath.Join(t.TempDir(), "assembler.db"),
+			TTL:     config.Duration{Duration: time.Hour},
+		}
+
+		o, err := NewOutputAssembler(ctx, workQueueCfg, persistenceCfg, nil, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, o.Start(ctx))
+		assert.NoError(t, o.Queue(ctx, "abc", &outputAssembleItem{
+			outputPaths: ow,
+			varNames:    []string{"var1"},
+			finalPhases: phases,
+			dataStore:   memStore,
+		}))
+
+		assert.Eventually(t, func() bool {
+			info, found, err := o.Get("abc")
+			return err == nil && found && info.Status() == workqueue.WorkStatusSucceeded
+		}, time.Second, 10*time.Millisecond)
+
+		// A fresh assembler knows the outputs were assembled without processing the item again.
+		restarted, err := NewOutputAssembler(ctx, workQueueCfg, persistenceCfg, nil, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, restarted.Start(ctx))
+		assert.NoError(t, restarted.Queue(ctx, "abc", &outputAssembleItem{}))
+
+		info, found, err := restarted.Get("abc")
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, workqueue.WorkStatusSucceeded, info.Status())
+	})
 }
 
 func TestNewErrorAssembler(t *testing.T) {
 	t.Run("Invalid Config", func(t *testing.T) {
-		_, err := NewErrorAssembler(0, workqueue.Config{
+		_, err := NewErrorAssembler(context.TODO(), 0, workqueue.Config{
 			Workers: 1,
-		}, promutils.NewTestScope())
+		}, workqueue.PersistenceConfig{}, nil, promutils.NewTestScope())
 		assert.Error(t, err)
 	})
 
 	t.Run("Valid Config", func(t *testing.T) {
-		o, err := NewErrorAssembler(0, workqueue.Config{
+		o, err := NewErrorAssembler(context.TODO(), 0, workqueue.Config{
 			Workers:            1,
 			IndexCacheMaxItems: 10,
-		}, promutils.NewTestScope())
+		}, workqueue.PersistenceConfig{}, nil, promutils.NewTestScope())
 		assert.NoError(t, err)
 		assert.NotNil(t, o)
 	})
+
+	t.Run("Persistence without a data store", func(t *testing.T) {
+		_, err := NewErrorAssembler(context.TODO(), 0, workqueue.Config{
+			Workers:            1,
+			IndexCacheMaxItems: 10,
+		}, workqueue.PersistenceConfig{
+			Enabled: true,
+			Prefix:  "s3://bucket/assemblers",
+			TTL:     config.Duration{Duration: time.Hour},
+		}, nil, promutils.NewTestScope())
+		assert.Error(t, err)
+	})
 }
 
 func Test_buildFinalPhases(t *testing.T) {
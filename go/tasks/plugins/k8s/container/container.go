@@ -35,20 +35,32 @@ func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext,
 		OccurredAt: &t,
 	}
 	if pod.Status.Phase != v1.PodPending && pod.Status.Phase != v1.PodUnknown {
-		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, 0, " (User)")
+		taskTemplate, err := pluginContext.TaskReader().Read(ctx)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, err
+		}
+
+		id := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, &id, taskTemplate.GetType(), 0, " (User)")
 		if err != nil {
 			return pluginsCore.PhaseInfoUndefined, err
 		}
 		info.Logs = taskLogs
+
+		if len(pod.Spec.Containers) > 0 {
+			archivedLog := logs.ArchiveLogsForContainerInPod(ctx, pluginContext, pod, pod.Spec.Containers[0].Name, " (User)")
+			if archivedLog != nil {
+				info.Logs = append(info.Logs, archivedLog)
+			}
+		}
 	}
 	switch pod.Status.Phase {
 	case v1.PodSucceeded:
 		return flytek8s.DemystifySuccess(pod.Status, info)
 	case v1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
+		return flytek8s.DemystifyFailure(pod.Status, info, pluginContext.TaskExecutionMetadata().IsInterruptible())
 	case v1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		return flytek8s.DemystifyPendingPod(pod)
 	case v1.PodUnknown:
 		return pluginsCore.PhaseInfoUndefined, nil
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 )
 
 var resourceRequirements = &v1.ResourceRequirements{
@@ -144,23 +145,28 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 	}
 
 	ctx := context.TODO()
+	pluginContext := &k8smocks.PluginContext{}
+	pluginContext.OnTaskExecutionMetadata().Return(dummyContainerTaskMetadata(resourceRequirements))
+	taskReader := &pluginsCoreMock.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(&core.TaskTemplate{Type: "test"}, nil)
+	pluginContext.OnTaskReader().Return(taskReader)
 	t.Run("running", func(t *testing.T) {
 		j.Status.Phase = v1.PodRunning
-		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContext, j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRunning, phaseInfo.Phase())
 	})
 
 	t.Run("queued", func(t *testing.T) {
 		j.Status.Phase = v1.PodPending
-		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContext, j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
 	})
 
 	t.Run("failNoCondition", func(t *testing.T) {
 		j.Status.Phase = v1.PodFailed
-		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContext, j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
 		ec := phaseInfo.Err().GetCode()
@@ -176,16 +182,17 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 				Type: v1.PodReasonUnschedulable,
 			},
 		}
-		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContext, j)
 		assert.NoError(t, err)
 		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
 		ec := phaseInfo.Err().GetCode()
-		assert.Equal(t, "Unschedulable", ec)
+		assert.Equal(t, "UnknownError", ec)
+		assert.Equal(t, "[Unschedulable]: some message", phaseInfo.Err().GetMessage())
 	})
 
 	t.Run("success", func(t *testing.T) {
 		j.Status.Phase = v1.PodSucceeded
-		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContext, j)
 		assert.NoError(t, err)
 		assert.NotNil(t, phaseInfo)
 		assert.Equal(t, pluginsCore.PhaseSuccess, phaseInfo.Phase())
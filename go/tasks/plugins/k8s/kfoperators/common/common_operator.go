@@ -55,11 +55,11 @@ func GetPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Time,
 	return pluginsCore.PhaseInfoUndefined, nil
 }
 
-func GetLogs(taskType string, name string, namespace string,
-	workersCount int32, psReplicasCount int32, chiefReplicasCount int32) ([]*core.TaskLog, error) {
+func GetLogs(taskType string, taskExecID *core.TaskExecutionIdentifier, name string, namespace string,
+	labels map[string]string, workersCount int32, psReplicasCount int32, chiefReplicasCount int32) ([]*core.TaskLog, error) {
 	taskLogs := make([]*core.TaskLog, 0, 10)
 
-	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	logPlugin, err := logs.ResolveLogPlugin(logs.NewSelection(taskExecID, taskType, labels))
 
 	if err != nil {
 		return nil, err
@@ -72,9 +72,10 @@ func GetLogs(taskType string, name string, namespace string,
 	if taskType == PytorchTaskType {
 		masterTaskLog, masterErr := logPlugin.GetTaskLogs(
 			tasklog.Input{
-				PodName:   name + "-master-0",
-				Namespace: namespace,
-				LogName:   "master",
+				PodName:                 name + "-master-0",
+				Namespace:               namespace,
+				LogName:                 "master",
+				TaskExecutionIdentifier: taskExecID,
 			},
 		)
 		if masterErr != nil {
@@ -86,8 +87,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get all workers log
 	for workerIndex := int32(0); workerIndex < workersCount; workerIndex++ {
 		workerLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-worker-%d", workerIndex),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-worker-%d", workerIndex),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
@@ -97,8 +99,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get all parameter servers logs
 	for psReplicaIndex := int32(0); psReplicaIndex < psReplicasCount; psReplicaIndex++ {
 		psReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-psReplica-%d", psReplicaIndex),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-psReplica-%d", psReplicaIndex),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
@@ -108,8 +111,9 @@ func GetLogs(taskType string, name string, namespace string,
 	// get chief worker log, and the max number of chief worker is 1
 	if chiefReplicasCount != 0 {
 		chiefReplicaLog, err := logPlugin.GetTaskLogs(tasklog.Input{
-			PodName:   name + fmt.Sprintf("-chiefReplica-%d", 0),
-			Namespace: namespace,
+			PodName:                 name + fmt.Sprintf("-chiefReplica-%d", 0),
+			Namespace:               namespace,
+			TaskExecutionIdentifier: taskExecID,
 		})
 		if err != nil {
 			return nil, err
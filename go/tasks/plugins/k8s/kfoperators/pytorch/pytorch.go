@@ -109,7 +109,9 @@ func (pytorchOperatorResourceHandler) GetTaskPhase(_ context.Context, pluginCont
 
 	workersCount := app.Spec.PyTorchReplicaSpecs[ptOp.PyTorchReplicaTypeWorker].Replicas
 
-	taskLogs, err := common.GetLogs(common.PytorchTaskType, app.Name, app.Namespace, *workersCount, 0, 0)
+	id := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	taskLogs, err := common.GetLogs(common.PytorchTaskType, &id, app.Name, app.Namespace, app.Labels,
+		*workersCount, 0, 0)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
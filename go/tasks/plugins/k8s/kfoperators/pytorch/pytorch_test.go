@@ -10,7 +10,9 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -300,39 +302,74 @@ func TestBuildResourcePytorch(t *testing.T) {
 	}
 }
 
+func TestBuildResourcePytorch_AcceleratorProfile(t *testing.T) {
+	previousConfig := config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(previousConfig)) }()
+	cfg := *previousConfig
+	cfg.AcceleratorProfiles = map[string]config.AcceleratorProfile{
+		"a100-mig-1g": {
+			NodeSelector: map[string]string{"accelerator": "nvidia-tesla-a100"},
+			ResourceName: "nvidia.com/mig-1g.5gb",
+		},
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	pytorchResourceHandler := pytorchOperatorResourceHandler{}
+
+	taskTemplate := dummySparkTaskTemplate("the job", dummyPytorchCustomObj(100))
+	taskTemplate.Config = map[string]string{flytek8s.AcceleratorConfigKey: "a100-mig-1g"}
+
+	res, err := pytorchResourceHandler.BuildResource(context.TODO(), dummyPytorchTaskContext(taskTemplate))
+	assert.NoError(t, err)
+
+	// Every replica runs on the nodes of the profile and requests its GPU resource.
+	pytorchJob, ok := res.(*ptOp.PyTorchJob)
+	assert.True(t, ok)
+	assert.Len(t, pytorchJob.Spec.PyTorchReplicaSpecs, 2)
+	for _, replicaSpec := range pytorchJob.Spec.PyTorchReplicaSpecs {
+		assert.Equal(t, "nvidia-tesla-a100", replicaSpec.Template.Spec.NodeSelector["accelerator"])
+		for _, container := range replicaSpec.Template.Spec.Containers {
+			assert.Equal(t, resource.MustParse("1"), container.Resources.Limits["nvidia.com/mig-1g.5gb"])
+			assert.NotContains(t, container.Resources.Limits, corev1.ResourceName(flytek8s.ResourceNvidiaGPU))
+		}
+	}
+}
+
 func TestGetTaskPhase(t *testing.T) {
 	pytorchResourceHandler := pytorchOperatorResourceHandler{}
 	ctx := context.TODO()
+	pluginContext := &k8smocks.PluginContext{}
+	pluginContext.OnTaskExecutionMetadata().Return(dummyPytorchTaskContext(dummySparkTaskTemplate("the job", dummyPytorchCustomObj(2))).TaskExecutionMetadata())
 
 	dummyPytorchJobResourceCreator := func(conditionType commonOp.JobConditionType) *ptOp.PyTorchJob {
 		return dummyPytorchJobResource(pytorchResourceHandler, 2, conditionType)
 	}
 
-	taskPhase, err := pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobCreated))
+	taskPhase, err := pytorchResourceHandler.GetTaskPhase(ctx, pluginContext, dummyPytorchJobResourceCreator(commonOp.JobCreated))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseQueued, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobRunning))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, pluginContext, dummyPytorchJobResourceCreator(commonOp.JobRunning))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobSucceeded))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, pluginContext, dummyPytorchJobResourceCreator(commonOp.JobSucceeded))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseSuccess, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobFailed))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, pluginContext, dummyPytorchJobResourceCreator(commonOp.JobFailed))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, nil, dummyPytorchJobResourceCreator(commonOp.JobRestarting))
+	taskPhase, err = pytorchResourceHandler.GetTaskPhase(ctx, pluginContext, dummyPytorchJobResourceCreator(commonOp.JobRestarting))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -349,7 +386,8 @@ func TestGetLogs(t *testing.T) {
 
 	pytorchResourceHandler := pytorchOperatorResourceHandler{}
 	pytorchJob := dummyPytorchJobResource(pytorchResourceHandler, workers, commonOp.JobRunning)
-	jobLogs, err := common.GetLogs(common.PytorchTaskType, pytorchJob.Name, pytorchJob.Namespace, workers, 0, 0)
+	jobLogs, err := common.GetLogs(common.PytorchTaskType, nil, pytorchJob.Name, pytorchJob.Namespace, pytorchJob.Labels,
+		workers, 0, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-master-0/pod?namespace=pytorch-namespace", jobNamespace, jobName), jobLogs[0].Uri)
@@ -10,7 +10,9 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8smocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -305,39 +307,74 @@ func TestBuildResourceTensorFlow(t *testing.T) {
 	}
 }
 
+func TestBuildResourceTensorFlow_AcceleratorProfile(t *testing.T) {
+	previousConfig := config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(previousConfig)) }()
+	cfg := *previousConfig
+	cfg.AcceleratorProfiles = map[string]config.AcceleratorProfile{
+		"a100-mig-1g": {
+			NodeSelector: map[string]string{"accelerator": "nvidia-tesla-a100"},
+			ResourceName: "nvidia.com/mig-1g.5gb",
+		},
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	tensorflowResourceHandler := tensorflowOperatorResourceHandler{}
+
+	taskTemplate := dummySparkTaskTemplate("the job", dummyTensorFlowCustomObj(100, 50, 1))
+	taskTemplate.Config = map[string]string{flytek8s.AcceleratorConfigKey: "a100-mig-1g"}
+
+	res, err := tensorflowResourceHandler.BuildResource(context.TODO(), dummyTensorFlowTaskContext(taskTemplate))
+	assert.NoError(t, err)
+
+	// Every replica runs on the nodes of the profile and requests its GPU resource.
+	tensorflowJob, ok := res.(*tfOp.TFJob)
+	assert.True(t, ok)
+	assert.Len(t, tensorflowJob.Spec.TFReplicaSpecs, 3)
+	for _, replicaSpec := range tensorflowJob.Spec.TFReplicaSpecs {
+		assert.Equal(t, "nvidia-tesla-a100", replicaSpec.Template.Spec.NodeSelector["accelerator"])
+		for _, container := range replicaSpec.Template.Spec.Containers {
+			assert.Equal(t, resource.MustParse("1"), container.Resources.Limits["nvidia.com/mig-1g.5gb"])
+			assert.NotContains(t, container.Resources.Limits, corev1.ResourceName(flytek8s.ResourceNvidiaGPU))
+		}
+	}
+}
+
 func TestGetTaskPhase(t *testing.T) {
 	tensorflowResourceHandler := tensorflowOperatorResourceHandler{}
 	ctx := context.TODO()
+	pluginContext := &k8smocks.PluginContext{}
+	pluginContext.OnTaskExecutionMetadata().Return(dummyTensorFlowTaskContext(dummySparkTaskTemplate("the job", dummyTensorFlowCustomObj(2, 1, 1))).TaskExecutionMetadata())
 
 	dummyTensorFlowJobResourceCreator := func(conditionType commonOp.JobConditionType) *tfOp.TFJob {
 		return dummyTensorFlowJobResource(tensorflowResourceHandler, 2, 1, 1, conditionType)
 	}
 
-	taskPhase, err := tensorflowResourceHandler.GetTaskPhase(ctx, nil, dummyTensorFlowJobResourceCreator(commonOp.JobCreated))
+	taskPhase, err := tensorflowResourceHandler.GetTaskPhase(ctx, pluginContext, dummyTensorFlowJobResourceCreator(commonOp.JobCreated))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseQueued, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, nil, dummyTensorFlowJobResourceCreator(commonOp.JobRunning))
+	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, pluginContext, dummyTensorFlowJobResourceCreator(commonOp.JobRunning))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, nil, dummyTensorFlowJobResourceCreator(commonOp.JobSucceeded))
+	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, pluginContext, dummyTensorFlowJobResourceCreator(commonOp.JobSucceeded))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseSuccess, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, nil, dummyTensorFlowJobResourceCreator(commonOp.JobFailed))
+	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, pluginContext, dummyTensorFlowJobResourceCreator(commonOp.JobFailed))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRetryableFailure, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 
-	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, nil, dummyTensorFlowJobResourceCreator(commonOp.JobRestarting))
+	taskPhase, err = tensorflowResourceHandler.GetTaskPhase(ctx, pluginContext, dummyTensorFlowJobResourceCreator(commonOp.JobRestarting))
 	assert.NoError(t, err)
 	assert.Equal(t, pluginsCore.PhaseRunning, taskPhase.Phase())
 	assert.NotNil(t, taskPhase.Info())
@@ -356,8 +393,8 @@ func TestGetLogs(t *testing.T) {
 
 	tensorflowResourceHandler := tensorflowOperatorResourceHandler{}
 	tensorFlowJob := dummyTensorFlowJobResource(tensorflowResourceHandler, workers, psReplicas, chiefReplicas, commonOp.JobRunning)
-	jobLogs, err := common.GetLogs(common.TensorflowTaskType, tensorFlowJob.Name, tensorFlowJob.Namespace,
-		workers, psReplicas, chiefReplicas)
+	jobLogs, err := common.GetLogs(common.TensorflowTaskType, nil, tensorFlowJob.Name, tensorFlowJob.Namespace,
+		tensorFlowJob.Labels, workers, psReplicas, chiefReplicas)
 	assert.NoError(t, err)
 	assert.Equal(t, 4, len(jobLogs))
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-worker-0/pod?namespace=tensorflow-namespace", jobNamespace, jobName), jobLogs[0].Uri)
@@ -0,0 +1,269 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	batchtransformjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/batchtransformjob"
+	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	awsUtils "github.com/flyteorg/flyteplugins/go/tasks/plugins/awsutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+// BatchTransformJobConfig is the custom field of batch transform tasks. The model the job runs is created from the model
+// input, e.g. the model output of a training task, served by the image.
+type BatchTransformJobConfig struct {
+	Image                   string `json:"image"`
+	InstanceType            string `json:"instanceType"`
+	InstanceCount           int64  `json:"instanceCount"`
+	ContentType             string `json:"contentType,omitempty"`
+	CompressionType         string `json:"compressionType,omitempty"`
+	SplitType               string `json:"splitType,omitempty"`
+	BatchStrategy           string `json:"batchStrategy,omitempty"`
+	Accept                  string `json:"accept,omitempty"`
+	AssembleWith            string `json:"assembleWith,omitempty"`
+	MaxConcurrentTransforms int64  `json:"maxConcurrentTransforms,omitempty"`
+	MaxPayloadInMB          int64  `json:"maxPayloadInMB,omitempty"`
+}
+
+func (m awsSagemakerPlugin) buildResourceForBatchTransformJob(
+	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
+
+	logger.Infof(ctx, "Building a batch transform job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	taskTemplate, err := getTaskTemplate(ctx, taskCtx)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Failed to get the task template of the batch transform job task")
+	}
+
+	jobConfig := BatchTransformJobConfig{}
+	err = utils.UnmarshalStructToObj(taskTemplate.GetCustom(), &jobConfig)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid BatchTransformJob task specification: not able to unmarshal the custom field to [%s]", m.TaskType)
+	}
+	if len(jobConfig.Image) == 0 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "Required field [Image] of the BatchTransformJob does not exist")
+	}
+	if len(jobConfig.InstanceType) == 0 || jobConfig.InstanceCount < 1 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "The BatchTransformJob requires an instance type and a positive instance count")
+	}
+	if len(taskTemplate.GetInterface().GetOutputs().GetVariables()) > 1 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "The BatchTransformJob produces a single output but [%v] are declared",
+			len(taskTemplate.GetInterface().GetOutputs().GetVariables()))
+	}
+
+	taskInput, err := taskCtx.InputReader().Get(ctx)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "unable to fetch task inputs")
+	}
+
+	inputLiterals := taskInput.GetLiterals()
+	err = checkIfRequiredInputLiteralsExist(inputLiterals, []string{BatchTransformDataPredefinedInputVariable,
+		BatchTransformModelPredefinedInputVariable})
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Error occurred when checking if all the required inputs exist")
+	}
+
+	dataPathLiteral := inputLiterals[BatchTransformDataPredefinedInputVariable]
+	if dataPathLiteral.GetScalar() == nil || dataPathLiteral.GetScalar().GetBlob() == nil {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "[data] Input is required and should be of Type [Scalar.Blob]")
+	}
+
+	modelPathLiteral := inputLiterals[BatchTransformModelPredefinedInputVariable]
+	if modelPathLiteral.GetScalar() == nil || modelPathLiteral.GetScalar().GetBlob() == nil {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "[model] Input is required and should be of Type [Scalar.Blob]")
+	}
+
+	jobName := taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+
+	// SageMaker writes a file per input file, named after it, under the output path
+	outputPath := createTransformOutputPath(taskCtx.OutputWriter().GetRawOutputPrefix().String(), jobName)
+
+	cfg := config.GetSagemakerConfig()
+
+	role := awsUtils.GetRoleFromSecurityContext(cfg.RoleAnnotationKey, taskCtx.TaskExecutionMetadata())
+
+	if len(role) == 0 {
+		role = cfg.RoleArn
+	}
+
+	// The model is named after the job so that building the resource again finds the model created the first time
+	modelClient, err := m.getModelClient()
+	if err != nil {
+		return nil, err
+	}
+
+	err = createModel(ctx, modelClient, jobName, jobConfig.Image, modelPathLiteral.GetScalar().GetBlob().GetUri(), role)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(getModelErrorCode(err), err, "Failed to create the model of the BatchTransformJob")
+	}
+
+	var contentType, accept *string
+	if len(jobConfig.ContentType) > 0 {
+		contentType = ToStringPtr(jobConfig.ContentType)
+	}
+	if len(jobConfig.Accept) > 0 {
+		accept = ToStringPtr(jobConfig.Accept)
+	}
+
+	var maxConcurrentTransforms, maxPayloadInMB *int64
+	if jobConfig.MaxConcurrentTransforms > 0 {
+		maxConcurrentTransforms = ToInt64Ptr(jobConfig.MaxConcurrentTransforms)
+	}
+	if jobConfig.MaxPayloadInMB > 0 {
+		maxPayloadInMB = ToInt64Ptr(jobConfig.MaxPayloadInMB)
+	}
+
+	batchTransformJob := &batchtransformjobv1.BatchTransformJob{
+		Spec: batchtransformjobv1.BatchTransformJobSpec{
+			TransformJobName:        &jobName,
+			ModelName:               ToStringPtr(jobName),
+			BatchStrategy:           commonv1.BatchStrategy(jobConfig.BatchStrategy),
+			MaxConcurrentTransforms: maxConcurrentTransforms,
+			MaxPayloadInMB:          maxPayloadInMB,
+			TransformInput: &commonv1.TransformInput{
+				CompressionType: commonv1.CompressionType(jobConfig.CompressionType),
+				ContentType:     contentType,
+				DataSource: &commonv1.TransformDataSource{
+					S3DataSource: &commonv1.TransformS3DataSource{
+						S3DataType: "S3Prefix",
+						S3Uri:      ToStringPtr(dataPathLiteral.GetScalar().GetBlob().GetUri()),
+					},
+				},
+				SplitType: commonv1.SplitType(jobConfig.SplitType),
+			},
+			TransformOutput: &commonv1.TransformOutput{
+				Accept:       accept,
+				AssembleWith: commonv1.AssemblyType(jobConfig.AssembleWith),
+				S3OutputPath: ToStringPtr(outputPath),
+			},
+			TransformResources: &commonv1.TransformResources{
+				InstanceType:  jobConfig.InstanceType,
+				InstanceCount: ToInt64Ptr(jobConfig.InstanceCount),
+			},
+			Region: ToStringPtr(cfg.Region),
+		},
+	}
+
+	logger.Infof(ctx, "Successfully built a batch transform job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	return batchTransformJob, nil
+}
+
+func (m awsSagemakerPlugin) getTaskPhaseForBatchTransformJob(
+	ctx context.Context, pluginContext k8s.PluginContext, batchTransformJob *batchtransformjobv1.BatchTransformJob) (pluginsCore.PhaseInfo, error) {
+
+	logger.Infof(ctx, "Getting task phase for sagemaker batch transform job [%v]", batchTransformJob.Status.SageMakerTransformJobName)
+	info, err := m.getEventInfoForBatchTransformJob(ctx, batchTransformJob)
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "Failed to get event info for the job")
+	}
+
+	occurredAt := time.Now()
+
+	switch batchTransformJob.Status.TransformJobStatus {
+	case sagemaker.TransformJobStatusFailed, sagemaker.TransformJobStatusStopped, sagemaker.TransformJobStatusCompleted:
+		// The model was created for the job alone, it's deleted once the job is done
+		if err := m.deleteModelOfBatchTransformJob(ctx, batchTransformJob); err != nil {
+			return pluginsCore.PhaseInfoUndefined, err
+		}
+	}
+
+	switch batchTransformJob.Status.TransformJobStatus {
+	case sagemaker.TransformJobStatusFailed:
+		execError := &flyteIdlCore.ExecutionError{
+			Message: batchTransformJob.Status.Additional,
+			Kind:    flyteIdlCore.ExecutionError_USER,
+			Code:    sagemaker.TransformJobStatusFailed,
+		}
+		return pluginsCore.PhaseInfoFailed(pluginsCore.PhasePermanentFailure, execError, info), nil
+	case sagemaker.TransformJobStatusStopped:
+		return pluginsCore.PhaseInfoRetryableFailure(pluginErrors.DownstreamSystemError, "Batch Transform Job Stopped", info), nil
+	case sagemaker.TransformJobStatusCompleted:
+		taskTemplate, err := pluginContext.TaskReader().Read(ctx)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "unable to read the task template")
+		}
+
+		outputPath := createTransformOutputPath(pluginContext.OutputWriter().GetRawOutputPrefix().String(),
+			*batchTransformJob.Spec.TransformJobName)
+		outputLiteralMap, err := createBlobOutputLiteralMap(taskTemplate.GetInterface().GetOutputs(), func(string) string {
+			return outputPath
+		})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to create outputs, err: %s", err)
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "failed to create outputs for the task")
+		}
+		if err := pluginContext.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(outputLiteralMap, nil)); err != nil {
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Unable to write output to the remote location")
+		}
+		logger.Debugf(ctx, "Successfully produced and returned outputs")
+		return pluginsCore.PhaseInfoSuccess(info), nil
+	case "":
+		return pluginsCore.PhaseInfoQueued(occurredAt, pluginsCore.DefaultPhaseVersion, "job submitted"), nil
+	}
+
+	return pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, info), nil
+}
+
+func (m awsSagemakerPlugin) getModelClient() (ModelClient, error) {
+	if m.ModelClient != nil {
+		return m.ModelClient, nil
+	}
+
+	modelClient, err := newModelClient(config.GetSagemakerConfig().Region)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "Failed to create the SageMaker client")
+	}
+
+	return modelClient, nil
+}
+
+func (m awsSagemakerPlugin) deleteModelOfBatchTransformJob(ctx context.Context,
+	batchTransformJob *batchtransformjobv1.BatchTransformJob) error {
+
+	if batchTransformJob.Spec.ModelName == nil {
+		return nil
+	}
+
+	modelClient, err := m.getModelClient()
+	if err != nil {
+		return err
+	}
+
+	if err := deleteModel(ctx, modelClient, *batchTransformJob.Spec.ModelName); err != nil {
+		return pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "Failed to delete the model of the BatchTransformJob")
+	}
+
+	logger.Infof(ctx, "Deleted the model [%v] of the batch transform job", *batchTransformJob.Spec.ModelName)
+	return nil
+}
+
+func (m awsSagemakerPlugin) getEventInfoForBatchTransformJob(ctx context.Context, batchTransformJob *batchtransformjobv1.BatchTransformJob) (*pluginsCore.TaskInfo, error) {
+
+	var jobRegion, jobName, jobTypeInURL, sagemakerLinkName string
+	jobRegion = *batchTransformJob.Spec.Region
+	jobName = *batchTransformJob.Spec.TransformJobName
+	jobTypeInURL = "transform-jobs"
+	sagemakerLinkName = BatchTransformJobSageMakerLinkName
+
+	logger.Infof(ctx, "Getting event information for SageMaker BatchTransformJob task, job region: [%v], job name: [%v], "+
+		"job type in url: [%v], sagemaker link name: [%v]", jobRegion, jobName, jobTypeInURL, sagemakerLinkName)
+
+	return createTaskInfo(ctx, jobRegion, jobName, jobTypeInURL, sagemakerLinkName)
+}
+
+func createTransformOutputPath(prefix, jobName string) string {
+	return fmt.Sprintf("%s/%s", createOutputPath(prefix, BatchTransformOutputPathSubDir), jobName)
+}
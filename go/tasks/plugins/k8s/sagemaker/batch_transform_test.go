@@ -0,0 +1,319 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+
+	batchtransformjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/batchtransformjob"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/config/viper"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	taskError "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+)
+
+func generateMockBatchTransformJobTaskTemplate(jobConfig BatchTransformJobConfig) *flyteIdlCore.TaskTemplate {
+	return generateMockTaskTemplateWithCustom("the transform job", jobConfig, map[string]*flyteIdlCore.Variable{
+		"predictions": generateMockBlobOutputVariable(flyteIdlCore.BlobType_MULTIPART),
+	})
+}
+
+type fakeModelClient struct {
+	inputs        []*sagemaker.CreateModelInput
+	err           error
+	deletedModels []string
+	deleteErr     error
+}
+
+func (c *fakeModelClient) CreateModelWithContext(_ aws.Context, input *sagemaker.CreateModelInput, _ ...request.Option) (
+	*sagemaker.CreateModelOutput, error) {
+	c.inputs = append(c.inputs, input)
+	return &sagemaker.CreateModelOutput{}, c.err
+}
+
+func (c *fakeModelClient) DeleteModelWithContext(_ aws.Context, input *sagemaker.DeleteModelInput, _ ...request.Option) (
+	*sagemaker.DeleteModelOutput, error) {
+	c.deletedModels = append(c.deletedModels, *input.ModelName)
+	return &sagemaker.DeleteModelOutput{}, c.deleteErr
+}
+
+func Test_awsSagemakerPlugin_BuildResourceForBatchTransformJob(t *testing.T) {
+	ctx := context.TODO()
+	configAccessor := viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{"testdata/config2.yaml"},
+	})
+
+	err := configAccessor.UpdateConfig(context.TODO())
+	assert.NoError(t, err)
+
+	modelClient := &fakeModelClient{}
+	awsSageMakerBatchTransformJobHandler := awsSagemakerPlugin{TaskType: batchTransformJobTaskType, ModelClient: modelClient}
+	inputs := map[string]*flyteIdlCore.Literal{
+		BatchTransformDataPredefinedInputVariable:  generateMockBlobLiteral("s3://bucket/data"),
+		BatchTransformModelPredefinedInputVariable: generateMockBlobLiteral("s3://bucket/model.tar.gz"),
+	}
+
+	t.Run("The data input and the output path should be set", func(t *testing.T) {
+		modelClient.inputs = nil
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 2,
+			ContentType:   "text/csv",
+			SplitType:     "Line",
+		})
+
+		resource, err := awsSageMakerBatchTransformJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, inputs, &pluginIOMocks.OutputWriter{}))
+		assert.NoError(t, err)
+
+		batchTransformJob, ok := resource.(*batchtransformjobv1.BatchTransformJob)
+		assert.True(t, ok)
+		assert.Equal(t, "some-acceptable-name", *batchTransformJob.Spec.TransformJobName)
+		assert.Equal(t, "some-acceptable-name", *batchTransformJob.Spec.ModelName)
+		assert.Equal(t, "s3://bucket/data", *batchTransformJob.Spec.TransformInput.DataSource.S3DataSource.S3Uri)
+		assert.Equal(t, "text/csv", *batchTransformJob.Spec.TransformInput.ContentType)
+		assert.Equal(t, "Line", string(batchTransformJob.Spec.TransformInput.SplitType))
+		assert.Equal(t, "s3://bucket/raw/batch_transform_outputs/some-acceptable-name",
+			*batchTransformJob.Spec.TransformOutput.S3OutputPath)
+		assert.Nil(t, batchTransformJob.Spec.TransformOutput.Accept)
+		assert.Equal(t, int64(2), *batchTransformJob.Spec.TransformResources.InstanceCount)
+		assert.Equal(t, "us-west-2", *batchTransformJob.Spec.Region)
+
+		// The model is created from the model input
+		if assert.Len(t, modelClient.inputs, 1) {
+			assert.Equal(t, "some-acceptable-name", *modelClient.inputs[0].ModelName)
+			assert.Equal(t, "my-image", *modelClient.inputs[0].PrimaryContainer.Image)
+			assert.Equal(t, "s3://bucket/model.tar.gz", *modelClient.inputs[0].PrimaryContainer.ModelDataUrl)
+			assert.Equal(t, "default_role", *modelClient.inputs[0].ExecutionRoleArn)
+		}
+	})
+
+	t.Run("An existing model should be reused", func(t *testing.T) {
+		existingModelClient := &fakeModelClient{
+			err: awserr.New("ValidationException", "Cannot create already existing model \"arn:aws:sagemaker:model\"", nil),
+		}
+		handler := awsSagemakerPlugin{TaskType: batchTransformJobTaskType, ModelClient: existingModelClient}
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		resource, err := handler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, inputs, &pluginIOMocks.OutputWriter{}))
+		assert.NoError(t, err)
+		assert.Equal(t, "some-acceptable-name", *resource.(*batchtransformjobv1.BatchTransformJob).Spec.ModelName)
+	})
+
+	t.Run("Failing to create the model should fail", func(t *testing.T) {
+		failingModelClient := &fakeModelClient{err: awserr.New("AccessDeniedException", "not allowed", nil)}
+		handler := awsSagemakerPlugin{TaskType: batchTransformJobTaskType, ModelClient: failingModelClient}
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		_, err := handler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, inputs, &pluginIOMocks.OutputWriter{}))
+		assert.True(t, stdErrors.IsCausedBy(err, taskError.DownstreamSystemError))
+	})
+
+	t.Run("Model creation errors should be classified", func(t *testing.T) {
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		for _, tc := range []struct {
+			name string
+			err  error
+			code stdErrors.ErrorCode
+		}{
+			{"invalid request", awserr.NewRequestFailure(awserr.New("ValidationException", "invalid image", nil), 400, "id"), taskError.BadTaskSpecification},
+			{"throttled", awserr.NewRequestFailure(awserr.New("ThrottlingException", "rate exceeded", nil), 400, "id"), taskError.DownstreamSystemError},
+			{"server error", awserr.NewRequestFailure(awserr.New("InternalFailure", "unavailable", nil), 500, "id"), taskError.DownstreamSystemError},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				handler := awsSagemakerPlugin{TaskType: batchTransformJobTaskType, ModelClient: &fakeModelClient{err: tc.err}}
+				_, err := handler.BuildResource(ctx,
+					generateMockTaskContextWithInputs(taskTemplate, inputs, &pluginIOMocks.OutputWriter{}))
+				assert.True(t, stdErrors.IsCausedBy(err, tc.code))
+			})
+		}
+	})
+
+	t.Run("The model input should be a blob", func(t *testing.T) {
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		_, err := awsSageMakerBatchTransformJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, map[string]*flyteIdlCore.Literal{
+				BatchTransformDataPredefinedInputVariable: generateMockBlobLiteral("s3://bucket/data"),
+			}, &pluginIOMocks.OutputWriter{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("An image is required", func(t *testing.T) {
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		_, err := awsSageMakerBatchTransformJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, inputs, &pluginIOMocks.OutputWriter{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("The data input should be a blob", func(t *testing.T) {
+		taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+			Image:         "my-image",
+			InstanceType:  "ml.m4.xlarge",
+			InstanceCount: 1,
+		})
+
+		_, err := awsSageMakerBatchTransformJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, map[string]*flyteIdlCore.Literal{}, &pluginIOMocks.OutputWriter{}))
+		assert.Error(t, err)
+	})
+}
+
+func Test_awsSagemakerPlugin_GetTaskPhaseForBatchTransformJob(t *testing.T) {
+	ctx := context.TODO()
+	configAccessor := viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{"testdata/config2.yaml"},
+	})
+
+	err := configAccessor.UpdateConfig(context.TODO())
+	assert.NoError(t, err)
+
+	modelClient := &fakeModelClient{}
+	awsSageMakerBatchTransformJobHandler := awsSagemakerPlugin{TaskType: batchTransformJobTaskType, ModelClient: modelClient}
+	taskTemplate := generateMockBatchTransformJobTaskTemplate(BatchTransformJobConfig{
+		Image:         "my-image",
+		InstanceType:  "ml.m4.xlarge",
+		InstanceCount: 1,
+	})
+
+	var outputs *flyteIdlCore.LiteralMap
+	outputWriter := &pluginIOMocks.OutputWriter{}
+	outputWriter.OnPutMatch(mock.Anything, mock.MatchedBy(func(reader io.OutputReader) bool {
+		outputs, _, _ = reader.Read(ctx)
+		return true
+	})).Return(nil)
+
+	taskCtx := generateMockTaskContextWithInputs(taskTemplate, map[string]*flyteIdlCore.Literal{
+		BatchTransformDataPredefinedInputVariable:  generateMockBlobLiteral(storage.DataReference("s3://bucket/data")),
+		BatchTransformModelPredefinedInputVariable: generateMockBlobLiteral(storage.DataReference("s3://bucket/model.tar.gz")),
+	}, outputWriter)
+
+	resource, err := awsSageMakerBatchTransformJobHandler.BuildResource(ctx, taskCtx)
+	assert.NoError(t, err)
+	batchTransformJob := resource.(*batchtransformjobv1.BatchTransformJob)
+
+	t.Run("TransformJobStatusFailed should be a permanent failure", func(t *testing.T) {
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusFailed
+		batchTransformJob.Status.Additional = "model not found"
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, sagemaker.TransformJobStatusFailed, phaseInfo.Err().GetCode())
+		assert.Equal(t, "model not found", phaseInfo.Err().GetMessage())
+	})
+
+	t.Run("TransformJobStatusStopped should be a retryable failure", func(t *testing.T) {
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusStopped
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, taskError.DownstreamSystemError, phaseInfo.Err().GetCode())
+	})
+
+	t.Run("An unset status should be queued", func(t *testing.T) {
+		batchTransformJob.Status.TransformJobStatus = ""
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
+	})
+
+	t.Run("TransformJobStatusInProgress should be running with links to the job", func(t *testing.T) {
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusInProgress
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRunning, phaseInfo.Phase())
+		if assert.Len(t, phaseInfo.Info().Logs, 2) {
+			assert.Contains(t, phaseInfo.Info().Logs[0].Uri, "group=/aws/sagemaker/TransformJobs;prefix=some-acceptable-name")
+			assert.Equal(t, BatchTransformJobSageMakerLinkName, phaseInfo.Info().Logs[1].Name)
+			assert.Contains(t, phaseInfo.Info().Logs[1].Uri, "#/transform-jobs/some-acceptable-name")
+		}
+	})
+
+	t.Run("TransformJobStatusCompleted should write the output path as a blob", func(t *testing.T) {
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusCompleted
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseSuccess, phaseInfo.Phase())
+
+		blob := outputs.GetLiterals()["predictions"].GetScalar().GetBlob()
+		assert.Equal(t, "s3://bucket/raw/batch_transform_outputs/some-acceptable-name", blob.GetUri())
+		assert.Equal(t, flyteIdlCore.BlobType_MULTIPART, blob.GetMetadata().GetType().GetDimensionality())
+	})
+
+	t.Run("The model should be deleted once the job is done", func(t *testing.T) {
+		for _, status := range []string{sagemaker.TransformJobStatusInProgress, sagemaker.TransformJobStatusFailed,
+			sagemaker.TransformJobStatusStopped, sagemaker.TransformJobStatusCompleted} {
+			modelClient.deletedModels = nil
+			batchTransformJob.Status.TransformJobStatus = status
+
+			_, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+			assert.NoError(t, err)
+			if status == sagemaker.TransformJobStatusInProgress {
+				assert.Empty(t, modelClient.deletedModels)
+			} else {
+				assert.Equal(t, []string{"some-acceptable-name"}, modelClient.deletedModels, status)
+			}
+		}
+	})
+
+	t.Run("A model deleted in an earlier round should be ignored", func(t *testing.T) {
+		modelClient.deleteErr = awserr.New("ValidationException", "Could not find model \"arn:aws:sagemaker:model\"", nil)
+		defer func() { modelClient.deleteErr = nil }()
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusFailed
+
+		phaseInfo, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+	})
+
+	t.Run("Failing to delete the model should be retried", func(t *testing.T) {
+		modelClient.deleteErr = awserr.New("InternalFailure", "unavailable", nil)
+		defer func() { modelClient.deleteErr = nil }()
+		batchTransformJob.Status.TransformJobStatus = sagemaker.TransformJobStatusFailed
+
+		_, err := awsSageMakerBatchTransformJobHandler.GetTaskPhase(ctx, taskCtx, batchTransformJob)
+		assert.True(t, stdErrors.IsCausedBy(err, taskError.DownstreamSystemError))
+	})
+}
@@ -133,7 +133,7 @@ func (m awsSagemakerPlugin) buildResourceForTrainingJob(
 				TrainingInputMode: commonv1.TrainingInputMode(inputModeString),
 				MetricDefinitions: metricDefinitions,
 			},
-			// The support of spot training will come in a later version
+			// Spot training is set below for interruptible tasks
 			EnableManagedSpotTraining: nil,
 			HyperParameters:           staticHyperparams,
 			InputDataConfig: []commonv1.Channel{
@@ -163,7 +163,7 @@ func (m awsSagemakerPlugin) buildResourceForTrainingJob(
 			OutputDataConfig: &commonv1.OutputDataConfig{
 				S3OutputPath: ToStringPtr(outputPath),
 			},
-			CheckpointConfig: nil,
+			CheckpointConfig: nil, // Set along with spot training
 			ResourceConfig: &commonv1.ResourceConfig{
 				InstanceType:   sagemakerTrainingJob.GetTrainingJobResourceConfig().GetInstanceType(),
 				InstanceCount:  ToInt64Ptr(sagemakerTrainingJob.GetTrainingJobResourceConfig().GetInstanceCount()),
@@ -181,6 +181,10 @@ func (m awsSagemakerPlugin) buildResourceForTrainingJob(
 			TrainingJobName:         &jobName,
 		},
 	}
+	if err := setManagedSpotTraining(ctx, taskCtx, &trainingJob.Spec); err != nil {
+		return nil, err
+	}
+
 	logger.Infof(ctx, "Successfully built a training job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
 	return trainingJob, nil
 }
@@ -196,6 +200,11 @@ func (m awsSagemakerPlugin) getTaskPhaseForTrainingJob(
 
 	occurredAt := time.Now()
 
+	if phaseInfo, interrupted := getPhaseInfoForSpotInterruption(trainingJob, info); interrupted {
+		logger.Warnf(ctx, "Spot training job [%v] was interrupted [%v]", trainingJob.Status.SageMakerTrainingJobName, trainingJob.Status.SecondaryStatus)
+		return phaseInfo, nil
+	}
+
 	switch trainingJob.Status.TrainingJobStatus {
 	case ReconcilingTrainingJobStatus:
 		logger.Errorf(ctx, "Job stuck in reconciling status, assuming retryable failure [%s]", trainingJob.Status.Additional)
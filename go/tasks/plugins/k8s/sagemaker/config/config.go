@@ -1,6 +1,11 @@
 package config
 
-import pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+import (
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flytestdlib/config"
+)
 
 //go:generate pflags Config --default-var=defaultConfig
 
@@ -8,8 +13,25 @@ const sagemakerConfigSectionKey = "sagemaker"
 
 var (
 	defaultConfig = Config{
-		RoleArn: "default_role",
-		Region:  "us-east-1",
+		RoleArn:       "default_role",
+		Region:        "us-east-1",
+		ImageResolver: ImageResolverStatic,
+		// https://docs.aws.amazon.com/sagemaker/latest/dg/ecr-us-east-1.html
+		AlgorithmRegistries: []AlgorithmRegistryConfig{
+			{
+				Name:          "xgboost",
+				Repository:    "sagemaker-xgboost",
+				LatestVersion: "0.90-2",
+				TagSuffix:     "-cpu-py3",
+				Accounts: map[string]string{
+					"us-east-1": "683313688378",
+				},
+			},
+		},
+		ManagedSpotTraining: ManagedSpotTrainingConfig{
+			Enabled:     false,
+			MaxWaitTime: config.Duration{Duration: 48 * time.Hour},
+		},
 		// https://docs.aws.amazon.com/sagemaker/latest/dg/sagemaker-algo-docker-registry-paths.html
 		PrebuiltAlgorithms: []PrebuiltAlgorithmConfig{
 			{
@@ -34,10 +56,22 @@ var (
 
 // Sagemaker plugin configs
 type Config struct {
-	RoleArn            string                    `json:"roleArn" pflag:",The role the SageMaker plugin uses to communicate with the SageMaker service"`
-	Region             string                    `json:"region" pflag:",The AWS region the SageMaker plugin communicates to"`
-	RoleAnnotationKey  string                    `json:"roleAnnotationKey" pflag:",Map key to use to lookup role from task annotations."`
-	PrebuiltAlgorithms []PrebuiltAlgorithmConfig `json:"prebuiltAlgorithms" pflag:"-,A List of PrebuiltAlgorithm configs"`
+	RoleArn             string                    `json:"roleArn" pflag:",The role the SageMaker plugin uses to communicate with the SageMaker service"`
+	Region              string                    `json:"region" pflag:",The AWS region the SageMaker plugin communicates to"`
+	RoleAnnotationKey   string                    `json:"roleAnnotationKey" pflag:",Map key to use to lookup role from task annotations."`
+	PrebuiltAlgorithms  []PrebuiltAlgorithmConfig `json:"prebuiltAlgorithms" pflag:"-,A List of PrebuiltAlgorithm configs"`
+	ImageResolver       ImageResolverType         `json:"imageResolver" pflag:",How to resolve the images of the prebuilt algorithms. Either static to look them up in prebuiltAlgorithms or registry to build them from algorithmRegistries."`
+	AlgorithmRegistries []AlgorithmRegistryConfig `json:"algorithmRegistries" pflag:"-,The registries AWS publishes the images of the prebuilt algorithms to"`
+	ManagedSpotTraining ManagedSpotTrainingConfig `json:"managedSpotTraining" pflag:",Managed spot training of the training jobs of interruptible tasks"`
+}
+
+// Interruptible tasks are cheaper to run on spot instances. SageMaker resumes spot training jobs interrupted because of
+// lack of capacity from their latest checkpoint, until the max wait time has passed.
+type ManagedSpotTrainingConfig struct {
+	Enabled     bool            `json:"enabled" pflag:",Whether to run the training jobs of interruptible tasks as managed spot training jobs"`
+	MaxWaitTime config.Duration `json:"maxWaitTime" pflag:",How long a spot training job can take in total including waiting for spot instances. Can't be shorter than the max run time. SageMaker allows at most 48 hours."`
+	// Checkpoints are written under the prefix, per node execution, so that they survive the retries of the task
+	CheckpointPrefix string `json:"checkpointPrefix" pflag:",The S3 prefix to write the checkpoints of spot training jobs under. Required when enabled."`
 }
 type PrebuiltAlgorithmConfig struct {
 	Name           string           `json:"name" pflag:",The name of the ML algorithm. Should match Sagemaker"`
@@ -47,6 +81,23 @@ type RegionalConfig struct {
 	Region         string          `json:"region" pflag:",Region for which this config is applicable"`
 	VersionConfigs []VersionConfig `json:"versionConfigs" pflag:",Configuration for various versions of the algorithms'"`
 }
+type ImageResolverType = string
+
+const (
+	ImageResolverStatic   ImageResolverType = "static"
+	ImageResolverRegistry ImageResolverType = "registry"
+)
+
+// AlgorithmRegistryConfig describes where AWS publishes the images of an algorithm, which are named
+// <account>.dkr.ecr.<region>.<domain>/<repository>:<version><tagSuffix>.
+type AlgorithmRegistryConfig struct {
+	Name          string            `json:"name" pflag:",The name of the ML algorithm. Should match Sagemaker"`
+	Repository    string            `json:"repository" pflag:",The repository of the images of the algorithm"`
+	Accounts      map[string]string `json:"accounts" pflag:",The account of the registry of each region"`
+	LatestVersion string            `json:"latestVersion" pflag:",The version to use when the task doesn't specify one"`
+	TagSuffix     string            `json:"tagSuffix" pflag:",Appended to the version to form the tag of the image"`
+}
+
 type VersionConfig struct {
 	Version string `json:"version" pflag:",version of the algorithm"`
 	Image   string `json:"image" pflag:",Image URI of the algorithm"`
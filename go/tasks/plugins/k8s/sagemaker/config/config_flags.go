@@ -53,5 +53,9 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "roleArn"), defaultConfig.RoleArn, "The role the SageMaker plugin uses to communicate with the SageMaker service")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "region"), defaultConfig.Region, "The AWS region the SageMaker plugin communicates to")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "roleAnnotationKey"), defaultConfig.RoleAnnotationKey, "Map key to use to lookup role from task annotations.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "imageResolver"), defaultConfig.ImageResolver, "How to resolve the images of the prebuilt algorithms. Either static to look them up in prebuiltAlgorithms or registry to build them from algorithmRegistries.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "managedSpotTraining.enabled"), defaultConfig.ManagedSpotTraining.Enabled, "Whether to run the training jobs of interruptible tasks as managed spot training jobs")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "managedSpotTraining.maxWaitTime"), defaultConfig.ManagedSpotTraining.MaxWaitTime.String(), "How long a spot training job can take in total including waiting for spot instances. Can't be shorter than the max run time. SageMaker allows at most 48 hours.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "managedSpotTraining.checkpointPrefix"), defaultConfig.ManagedSpotTraining.CheckpointPrefix, "The S3 prefix to write the checkpoints of spot training jobs under. Required when enabled.")
 	return cmdFlags
 }
@@ -141,4 +141,60 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_imageResolver", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("imageResolver", testValue)
+			if vString, err := cmdFlags.GetString("imageResolver"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ImageResolver)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_managedSpotTraining.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("managedSpotTraining.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("managedSpotTraining.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.ManagedSpotTraining.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_managedSpotTraining.maxWaitTime", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ManagedSpotTraining.MaxWaitTime.String()
+
+			cmdFlags.Set("managedSpotTraining.maxWaitTime", testValue)
+			if vString, err := cmdFlags.GetString("managedSpotTraining.maxWaitTime"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ManagedSpotTraining.MaxWaitTime)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_managedSpotTraining.checkpointPrefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("managedSpotTraining.checkpointPrefix", testValue)
+			if vString, err := cmdFlags.GetString("managedSpotTraining.checkpointPrefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ManagedSpotTraining.CheckpointPrefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -15,6 +15,16 @@ const (
 	hyperparameterTuningJobTaskType     = "sagemaker_hyperparameter_tuning_job_task"
 )
 
+const (
+	batchTransformJobTaskPluginID = "sagemaker_batch_transform"
+	batchTransformJobTaskType     = "sagemaker_batch_transform_job_task"
+)
+
+const (
+	processingJobTaskPluginID = "sagemaker_processing"
+	processingJobTaskType     = "sagemaker_processing_job_task"
+)
+
 const (
 	TEXTCSVInputContentType string = "text/csv"
 )
@@ -31,6 +41,8 @@ const (
 const (
 	TrainingJobOutputPathSubDir    = "training_outputs"
 	HyperparameterOutputPathSubDir = "hyperparameter_tuning_outputs"
+	BatchTransformOutputPathSubDir = "batch_transform_outputs"
+	ProcessingOutputPathSubDir     = "processing_outputs"
 )
 
 const (
@@ -41,6 +53,17 @@ const (
 	TrainPredefinedInputVariable                 = "train"
 	ValidationPredefinedInputVariable            = "validation"
 	StaticHyperparametersPredefinedInputVariable = "static_hyperparameters"
+
+	// The input of batch transform tasks holding the data to run the model on
+	BatchTransformDataPredefinedInputVariable = "data"
+	// The input of batch transform tasks holding the model artifacts, e.g. the model output of a training task
+	BatchTransformModelPredefinedInputVariable = "model"
+)
+
+const (
+	// Processing jobs copy their inputs to, and upload their outputs from, subdirectories of these paths named after them
+	ProcessingInputLocalPathPrefix  = "/opt/ml/processing/input"
+	ProcessingOutputLocalPathPrefix = "/opt/ml/processing/output"
 )
 
 const (
@@ -48,4 +71,6 @@ const (
 	TrainingJobSageMakerLinkName             = "SageMaker Built-in Algorithm Training Job"
 	CustomTrainingJobSageMakerLinkName       = "SageMaker Custom Training Job"
 	HyperparameterTuningJobSageMakerLinkName = "SageMaker Hyperparameter Tuning Job"
+	BatchTransformJobSageMakerLinkName       = "SageMaker Batch Transform Job"
+	ProcessingJobSageMakerLinkName           = "SageMaker Processing Job"
 )
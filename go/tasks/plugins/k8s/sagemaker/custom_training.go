@@ -128,14 +128,14 @@ func (m awsSagemakerPlugin) buildResourceForCustomTrainingJob(
 				TrainingInputMode: commonv1.TrainingInputMode(inputModeString),
 				MetricDefinitions: metricDefinitions,
 			},
-			// The support of spot training will come in a later version
+			// Spot training is set below for interruptible tasks
 			EnableManagedSpotTraining: nil,
 			HyperParameters:           hyperParameters,
 			InputDataConfig:           inputChannels,
 			OutputDataConfig: &commonv1.OutputDataConfig{
 				S3OutputPath: ToStringPtr(outputPath),
 			},
-			CheckpointConfig: nil,
+			CheckpointConfig: nil, // Set along with spot training
 			ResourceConfig: &commonv1.ResourceConfig{
 				InstanceType:   sagemakerTrainingJob.GetTrainingJobResourceConfig().GetInstanceType(),
 				InstanceCount:  ToInt64Ptr(sagemakerTrainingJob.GetTrainingJobResourceConfig().GetInstanceCount()),
@@ -153,6 +153,10 @@ func (m awsSagemakerPlugin) buildResourceForCustomTrainingJob(
 			TrainingJobName:         &jobName,
 		},
 	}
+	if err := setManagedSpotTraining(ctx, taskCtx, &trainingJob.Spec); err != nil {
+		return nil, err
+	}
+
 	logger.Infof(ctx, "Successfully built a custom training job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
 	return trainingJob, nil
 }
@@ -168,6 +172,11 @@ func (m awsSagemakerPlugin) getTaskPhaseForCustomTrainingJob(
 
 	occurredAt := time.Now()
 
+	if phaseInfo, interrupted := getPhaseInfoForSpotInterruption(trainingJob, info); interrupted {
+		logger.Warnf(ctx, "Spot training job [%v] was interrupted [%v]", trainingJob.Status.SageMakerTrainingJobName, trainingJob.Status.SecondaryStatus)
+		return phaseInfo, nil
+	}
+
 	switch trainingJob.Status.TrainingJobStatus {
 	case ReconcilingTrainingJobStatus:
 		logger.Errorf(ctx, "Job stuck in reconciling status, assuming retryable failure [%s]", trainingJob.Status.Additional)
@@ -0,0 +1,135 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+// AlgorithmImageResolver resolves the image of a prebuilt algorithm. The latest version is picked when no version is
+// specified.
+type AlgorithmImageResolver interface {
+	ResolveImage(ctx context.Context, algorithmName, region, version string) (string, error)
+}
+
+// The versions AWS tags the images of the prebuilt algorithms with, e.g. 1.2-1
+var algorithmVersionRegex = regexp.MustCompile(`^\d+(\.\d+){0,2}(-\d+)?$`)
+
+func newAlgorithmImageResolver(cfg *config.Config) (AlgorithmImageResolver, error) {
+	switch cfg.ImageResolver {
+	case config.ImageResolverStatic, "":
+		return staticAlgorithmImageResolver{prebuiltAlgorithms: cfg.PrebuiltAlgorithms}, nil
+	case config.ImageResolverRegistry:
+		return registryAlgorithmImageResolver{registries: cfg.AlgorithmRegistries}, nil
+	default:
+		return nil, errors.Errorf(ErrSagemaker, "Unknown image resolver [%v]", cfg.ImageResolver)
+	}
+}
+
+// staticAlgorithmImageResolver looks the images up in the list of images of each version of each algorithm in each
+// region from the config.
+type staticAlgorithmImageResolver struct {
+	prebuiltAlgorithms []config.PrebuiltAlgorithmConfig
+}
+
+func (r staticAlgorithmImageResolver) ResolveImage(ctx context.Context, algorithmName, region, version string) (string, error) {
+	foundAlgorithmCfg, err := findAlgorithmConfig(r.prebuiltAlgorithms, algorithmName)
+	if err != nil {
+		return "", err
+	}
+
+	foundRegionalCfg, err := findRegionConfig(foundAlgorithmCfg, region)
+	if err != nil {
+		return "", err
+	}
+
+	// If the user does not specify a version -> use the latest version found in the config possible
+	if version == "" {
+		logger.Infof(ctx, "The version of the algorithm [%v] is not specified. "+
+			"The plugin will try to pick the latest version available for the algorithm-region combination.", algorithmName, region)
+		latestTrainingImage, err := getLatestTrainingImage(foundRegionalCfg.VersionConfigs)
+		if err != nil {
+			return "", errors.Wrapf(ErrSagemaker, err, "Failed to identify the latest image for algorithm:region [%v:%v]",
+				algorithmName, region)
+		}
+		return latestTrainingImage, nil
+	}
+
+	// If the user specified a version -> we have to translate it to semver and find an exact match
+	userSpecifiedSemVer, err := semver.NewVersion(version)
+	if err != nil {
+		return "", errors.Wrapf(ErrSagemaker, err, "Unable to cast version specified by the user [%v] to a semver", version)
+	}
+	for _, versionCfg := range foundRegionalCfg.VersionConfigs {
+		configSemVer, err := semver.NewVersion(versionCfg.Version)
+		if err != nil {
+			return "", errors.Wrapf(ErrSagemaker, err, "Unable to cast version listed in the config [%v] to a semver", versionCfg.Version)
+		}
+		if configSemVer.Equal(userSpecifiedSemVer) {
+			logger.Infof(ctx, "Image [%v] is picked for algorithm [%v] region [%v] version [%v] ",
+				versionCfg.Image, algorithmName, region, userSpecifiedSemVer)
+			return versionCfg.Image, nil
+		}
+	}
+	logger.Errorf(ctx, "Failed to find an image for [%v]:[%v]:[%v]", algorithmName, region, version)
+
+	return "", errors.Errorf(ErrSagemaker, "Failed to find an image for [%v]:[%v]:[%v]", algorithmName, region, version)
+}
+
+// registryAlgorithmImageResolver builds the images from the paths of the registries AWS publishes them to, so that new
+// versions can be used without listing their images.
+type registryAlgorithmImageResolver struct {
+	registries []config.AlgorithmRegistryConfig
+}
+
+func (r registryAlgorithmImageResolver) ResolveImage(ctx context.Context, algorithmName, region, version string) (string, error) {
+	registry, err := findAlgorithmRegistry(r.registries, algorithmName)
+	if err != nil {
+		return "", err
+	}
+
+	if version == "" {
+		logger.Infof(ctx, "The version of the algorithm [%v] is not specified. Picking the latest version [%v].",
+			algorithmName, registry.LatestVersion)
+		version = registry.LatestVersion
+	}
+
+	account, found := registry.Accounts[region]
+	if !found {
+		return "", errors.Errorf(ErrSagemaker, "Failed to find the registry of algorithm [%v] in region [%v]", algorithmName, region)
+	}
+
+	if !algorithmVersionRegex.MatchString(version) {
+		return "", errors.Errorf(ErrSagemaker, "Invalid version [%v] for algorithm [%v]", version, algorithmName)
+	}
+
+	image := fmt.Sprintf("%s.dkr.ecr.%s.%s/%s:%s%s", account, region, getRegistryDomain(region), registry.Repository,
+		version, registry.TagSuffix)
+
+	logger.Infof(ctx, "Image [%v] is picked for algorithm [%v] region [%v] version [%v] ", image, algorithmName, region, version)
+	return image, nil
+}
+
+func findAlgorithmRegistry(registries []config.AlgorithmRegistryConfig, name string) (config.AlgorithmRegistryConfig, error) {
+	for _, registry := range registries {
+		if strings.EqualFold(name, registry.Name) {
+			return registry, nil
+		}
+	}
+	return config.AlgorithmRegistryConfig{}, errors.Errorf(ErrSagemaker, "Failed to find the registry of algorithm [%v]", name)
+}
+
+// getRegistryDomain returns the domain of the registries of the region, which differs in the China regions.
+func getRegistryDomain(region string) string {
+	if strings.HasPrefix(region, "cn-") {
+		return "amazonaws.com.cn"
+	}
+	return "amazonaws.com"
+}
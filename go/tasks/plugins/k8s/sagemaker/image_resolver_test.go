@@ -0,0 +1,104 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+func Test_newAlgorithmImageResolver(t *testing.T) {
+	resolver, err := newAlgorithmImageResolver(&config.Config{})
+	assert.NoError(t, err)
+	assert.IsType(t, staticAlgorithmImageResolver{}, resolver)
+
+	resolver, err = newAlgorithmImageResolver(&config.Config{ImageResolver: config.ImageResolverRegistry})
+	assert.NoError(t, err)
+	assert.IsType(t, registryAlgorithmImageResolver{}, resolver)
+
+	_, err = newAlgorithmImageResolver(&config.Config{ImageResolver: "ecr"})
+	assert.Error(t, err)
+}
+
+func Test_staticAlgorithmImageResolver(t *testing.T) {
+	ctx := context.TODO()
+	resolver := staticAlgorithmImageResolver{prebuiltAlgorithms: []config.PrebuiltAlgorithmConfig{
+		{
+			Name: "xgboost",
+			RegionalConfig: []config.RegionalConfig{
+				{
+					Region: "us-west-2",
+					VersionConfigs: []config.VersionConfig{
+						{Version: "0.90", Image: "image-0.90"},
+						{Version: "1.0", Image: "image-1.0"},
+					},
+				},
+			},
+		},
+	}}
+
+	image, err := resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "0.90")
+	assert.NoError(t, err)
+	assert.Equal(t, "image-0.90", image)
+
+	image, err = resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "image-1.0", image)
+
+	_, err = resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "1.2")
+	assert.Error(t, err)
+
+	_, err = resolver.ResolveImage(ctx, "XGBOOST", "us-east-1", "0.90")
+	assert.Error(t, err)
+}
+
+func Test_registryAlgorithmImageResolver(t *testing.T) {
+	ctx := context.TODO()
+	resolver := registryAlgorithmImageResolver{
+		registries: []config.AlgorithmRegistryConfig{
+			{
+				Name:          "xgboost",
+				Repository:    "sagemaker-xgboost",
+				LatestVersion: "1.2-1",
+				Accounts: map[string]string{
+					"us-west-2":  "246618743249",
+					"cn-north-1": "450853457545",
+				},
+			},
+		},
+	}
+
+	t.Run("The image is built from the registry of the region", func(t *testing.T) {
+		image, err := resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "1.0-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "246618743249.dkr.ecr.us-west-2.amazonaws.com/sagemaker-xgboost:1.0-1", image)
+
+		image, err = resolver.ResolveImage(ctx, "XGBOOST", "cn-north-1", "1.0-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "450853457545.dkr.ecr.cn-north-1.amazonaws.com.cn/sagemaker-xgboost:1.0-1", image)
+	})
+
+	t.Run("The latest version is picked when none is specified", func(t *testing.T) {
+		image, err := resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "246618743249.dkr.ecr.us-west-2.amazonaws.com/sagemaker-xgboost:1.2-1", image)
+	})
+
+	t.Run("Invalid versions are rejected", func(t *testing.T) {
+		_, err := resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "latest")
+		assert.Error(t, err)
+
+		_, err = resolver.ResolveImage(ctx, "XGBOOST", "us-west-2", "1.0-1:other-tag")
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown algorithms and regions are rejected", func(t *testing.T) {
+		_, err := resolver.ResolveImage(ctx, "LINEAR_LEARNER", "us-west-2", "1")
+		assert.Error(t, err)
+
+		_, err = resolver.ResolveImage(ctx, "XGBOOST", "eu-west-1", "1.0-1")
+		assert.Error(t, err)
+	})
+}
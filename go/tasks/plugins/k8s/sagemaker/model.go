@@ -0,0 +1,83 @@
+package sagemaker
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	awsSdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/flyteorg/flytestdlib/errors"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/aws"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+const (
+	// Returned by SageMaker when a model of the same name exists, e.g. because the resource of the task is built again.
+	modelAlreadyExistsMessage = "Cannot create already existing model"
+	// Returned by SageMaker when the model doesn't exist, e.g. because it was deleted in an earlier round.
+	modelNotFoundMessage = "Could not find model"
+)
+
+// ModelClient creates the SageMaker models batch transform jobs refer to and deletes them once the jobs are done.
+// There's no CRD managing models along with the job, so they're managed through the SageMaker API.
+type ModelClient interface {
+	CreateModelWithContext(ctx awsSdk.Context, input *sagemaker.CreateModelInput, opts ...request.Option) (
+		*sagemaker.CreateModelOutput, error)
+	DeleteModelWithContext(ctx awsSdk.Context, input *sagemaker.DeleteModelInput, opts ...request.Option) (
+		*sagemaker.DeleteModelOutput, error)
+}
+
+func newModelClient(region string) (ModelClient, error) {
+	awsClient, err := aws.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return sagemaker.New(awsClient.GetSession(), awsSdk.NewConfig().WithRegion(region)), nil
+}
+
+// createModel creates a model serving the model data with the image, unless it already exists.
+func createModel(ctx context.Context, modelClient ModelClient, modelName, image, modelDataURL, role string) error {
+	_, err := modelClient.CreateModelWithContext(ctx, &sagemaker.CreateModelInput{
+		ModelName:        ToStringPtr(modelName),
+		ExecutionRoleArn: ToStringPtr(role),
+		PrimaryContainer: &sagemaker.ContainerDefinition{
+			Image:        ToStringPtr(image),
+			ModelDataUrl: ToStringPtr(modelDataURL),
+		},
+	})
+
+	if awsErr, ok := err.(awserr.Error); ok && strings.Contains(awsErr.Message(), modelAlreadyExistsMessage) {
+		return nil
+	}
+
+	return err
+}
+
+// deleteModel deletes the model, unless it doesn't exist anymore.
+func deleteModel(ctx context.Context, modelClient ModelClient, modelName string) error {
+	_, err := modelClient.DeleteModelWithContext(ctx, &sagemaker.DeleteModelInput{
+		ModelName: ToStringPtr(modelName),
+	})
+
+	if awsErr, ok := err.(awserr.Error); ok && strings.Contains(awsErr.Message(), modelNotFoundMessage) {
+		return nil
+	}
+
+	return err
+}
+
+// getModelErrorCode classifies the errors of the SageMaker model API. Client errors, caused by e.g. an invalid image,
+// model data or role, can't be fixed by retrying while throttling and server errors are errors of SageMaker.
+func getModelErrorCode(err error) errors.ErrorCode {
+	if requestFailure, ok := err.(awserr.RequestFailure); ok && !request.IsErrorThrottle(err) &&
+		requestFailure.StatusCode() >= http.StatusBadRequest && requestFailure.StatusCode() < http.StatusInternalServerError {
+		return pluginErrors.BadTaskSpecification
+	}
+
+	return pluginErrors.DownstreamSystemError
+}
@@ -70,3 +70,30 @@ func createModelOutputPath(job client.Object, prefix, jobName string) string {
 		return ""
 	}
 }
+
+// createBlobOutputLiteralMap maps each of the outputs, which have to be blobs, to a blob of the declared type at the uri
+// returned by getOutputURI.
+func createBlobOutputLiteralMap(outputs *core.VariableMap, getOutputURI func(name string) string) (*core.LiteralMap, error) {
+	literals := make(map[string]*core.Literal, len(outputs.GetVariables()))
+	for name, variable := range outputs.GetVariables() {
+		blobType := variable.GetType().GetBlob()
+		if blobType == nil {
+			return nil, fmt.Errorf("output [%v] is of type [%v] but only blob outputs are supported", name, variable.GetType())
+		}
+
+		literals[name] = &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Blob{
+						Blob: &core.Blob{
+							Metadata: &core.BlobMetadata{Type: blobType},
+							Uri:      getOutputURI(name),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return &core.LiteralMap{Literals: literals}, nil
+}
@@ -11,8 +11,10 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 
+	batchtransformjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/batchtransformjob"
 	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
 	hpojobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/hyperparametertuningjob"
+	processingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/processingjob"
 	trainingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/trainingjob"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
@@ -23,6 +25,8 @@ var _ k8s.Plugin = awsSagemakerPlugin{}
 
 type awsSagemakerPlugin struct {
 	TaskType pluginsCore.TaskType
+	// Creates the models of batch transform jobs. A client for the configured region is created if not set.
+	ModelClient ModelClient
 }
 
 func (awsSagemakerPlugin) GetProperties() k8s.PluginProperties {
@@ -36,6 +40,12 @@ func (m awsSagemakerPlugin) BuildIdentityResource(_ context.Context, _ pluginsCo
 	if m.TaskType == hyperparameterTuningJobTaskType {
 		return &hpojobv1.HyperparameterTuningJob{}, nil
 	}
+	if m.TaskType == batchTransformJobTaskType {
+		return &batchtransformjobv1.BatchTransformJob{}, nil
+	}
+	if m.TaskType == processingJobTaskType {
+		return &processingjobv1.ProcessingJob{}, nil
+	}
 	return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "The sagemaker plugin is unable to build identity resource for an unknown task type [%v]", m.TaskType)
 }
 
@@ -51,6 +61,12 @@ func (m awsSagemakerPlugin) BuildResource(ctx context.Context, taskCtx pluginsCo
 	if m.TaskType == hyperparameterTuningJobTaskType {
 		return m.buildResourceForHyperparameterTuningJob(ctx, taskCtx)
 	}
+	if m.TaskType == batchTransformJobTaskType {
+		return m.buildResourceForBatchTransformJob(ctx, taskCtx)
+	}
+	if m.TaskType == processingJobTaskType {
+		return m.buildResourceForProcessingJob(ctx, taskCtx)
+	}
 	return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "The SageMaker plugin is unable to build resource for unknown task type [%s]", m.TaskType)
 }
 
@@ -64,6 +80,12 @@ func (m awsSagemakerPlugin) GetTaskPhase(ctx context.Context, pluginContext k8s.
 	} else if m.TaskType == hyperparameterTuningJobTaskType {
 		job := resource.(*hpojobv1.HyperparameterTuningJob)
 		return m.getTaskPhaseForHyperparameterTuningJob(ctx, pluginContext, job)
+	} else if m.TaskType == batchTransformJobTaskType {
+		job := resource.(*batchtransformjobv1.BatchTransformJob)
+		return m.getTaskPhaseForBatchTransformJob(ctx, pluginContext, job)
+	} else if m.TaskType == processingJobTaskType {
+		job := resource.(*processingjobv1.ProcessingJob)
+		return m.getTaskPhaseForProcessingJob(ctx, pluginContext, job)
 	}
 	return pluginsCore.PhaseInfoUndefined, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "cannot get task phase for unknown task type [%s]", m.TaskType)
 }
@@ -105,4 +127,26 @@ func init() {
 			IsDefault:           false,
 			DefaultForTaskTypes: []pluginsCore.TaskType{customTrainingJobTaskType},
 		})
+
+	// Registering the plugin for BatchTransformJob
+	pluginmachinery.PluginRegistry().RegisterK8sPlugin(
+		k8s.PluginEntry{
+			ID:                  batchTransformJobTaskPluginID,
+			RegisteredTaskTypes: []pluginsCore.TaskType{batchTransformJobTaskType},
+			ResourceToWatch:     &batchtransformjobv1.BatchTransformJob{},
+			Plugin:              awsSagemakerPlugin{TaskType: batchTransformJobTaskType},
+			IsDefault:           false,
+			DefaultForTaskTypes: []pluginsCore.TaskType{batchTransformJobTaskType},
+		})
+
+	// Registering the plugin for ProcessingJob
+	pluginmachinery.PluginRegistry().RegisterK8sPlugin(
+		k8s.PluginEntry{
+			ID:                  processingJobTaskPluginID,
+			RegisteredTaskTypes: []pluginsCore.TaskType{processingJobTaskType},
+			ResourceToWatch:     &processingjobv1.ProcessingJob{},
+			Plugin:              awsSagemakerPlugin{TaskType: processingJobTaskType},
+			IsDefault:           false,
+			DefaultForTaskTypes: []pluginsCore.TaskType{processingJobTaskType},
+		})
 }
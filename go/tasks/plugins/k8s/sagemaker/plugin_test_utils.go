@@ -435,3 +435,53 @@ func generateMockHyperparameterTuningJobCustomObj(
 		MaxParallelTrainingJobs: maxParallelTrainingJobs,
 	}
 }
+
+func generateMockBlobOutputVariable(dimensionality flyteIdlCore.BlobType_BlobDimensionality) *flyteIdlCore.Variable {
+	return &flyteIdlCore.Variable{
+		Type: &flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_Blob{
+				Blob: &flyteIdlCore.BlobType{Dimensionality: dimensionality},
+			},
+		},
+	}
+}
+
+func generateMockTaskTemplateWithCustom(id string, custom interface{}, outputs map[string]*flyteIdlCore.Variable) *flyteIdlCore.TaskTemplate {
+	structObj, err := utils.MarshalObjToStruct(custom)
+	if err != nil {
+		panic(err)
+	}
+
+	return &flyteIdlCore.TaskTemplate{
+		Id:   &flyteIdlCore.Identifier{Name: id},
+		Type: "container",
+		Target: &flyteIdlCore.TaskTemplate_Container{
+			Container: &flyteIdlCore.Container{
+				Image: testImage,
+				Env:   dummyEnvVars,
+			},
+		},
+		Custom: structObj,
+		Interface: &flyteIdlCore.TypedInterface{
+			Outputs: &flyteIdlCore.VariableMap{Variables: outputs},
+		},
+	}
+}
+
+func generateMockTaskContextWithInputs(taskTemplate *flyteIdlCore.TaskTemplate, inputs map[string]*flyteIdlCore.Literal,
+	outputWriter *pluginIOMocks.OutputWriter) pluginsCore.TaskExecutionContext {
+
+	taskCtx := &mocks.TaskExecutionContext{}
+	inputReader := &pluginIOMocks.InputReader{}
+	inputReader.OnGetMatch(mock.Anything).Return(&flyteIdlCore.LiteralMap{Literals: inputs}, nil)
+	taskCtx.OnInputReader().Return(inputReader)
+
+	outputWriter.OnGetRawOutputPrefix().Return(storage.DataReference("s3://bucket/raw"))
+	taskCtx.OnOutputWriter().Return(outputWriter)
+
+	taskReader := &mocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(taskTemplate, nil)
+	taskCtx.OnTaskReader().Return(taskReader)
+	taskCtx.OnTaskExecutionMetadata().Return(genMockTaskExecutionMetadata())
+	return taskCtx
+}
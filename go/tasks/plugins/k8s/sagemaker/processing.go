@@ -0,0 +1,276 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
+	processingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/processingjob"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	awsUtils "github.com/flyteorg/flyteplugins/go/tasks/plugins/awsutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+// The status the operator sets when it fails to reconcile the processing job with SageMaker
+const ReconcilingProcessingJobStatus = "Reconciling"
+
+// ProcessingJobConfig is the custom field of processing tasks. The image defaults to the one of the task container.
+//
+// Each blob input, e.g. a model or a data channel, is copied to ProcessingInputLocalPathPrefix/<input name> and each
+// blob output is uploaded from ProcessingOutputLocalPathPrefix/<output name> once the job has completed.
+type ProcessingJobConfig struct {
+	ImageURI            string            `json:"imageUri,omitempty"`
+	ContainerEntrypoint []string          `json:"containerEntrypoint,omitempty"`
+	ContainerArguments  []string          `json:"containerArguments,omitempty"`
+	Environment         map[string]string `json:"environment,omitempty"`
+	InstanceType        string            `json:"instanceType"`
+	InstanceCount       int64             `json:"instanceCount"`
+	VolumeSizeInGB      int64             `json:"volumeSizeInGB"`
+	MaxRuntimeInSeconds int64             `json:"maxRuntimeInSeconds,omitempty"`
+}
+
+func (m awsSagemakerPlugin) buildResourceForProcessingJob(
+	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
+
+	logger.Infof(ctx, "Building a processing job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	taskTemplate, err := getTaskTemplate(ctx, taskCtx)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Failed to get the task template of the processing job task")
+	}
+
+	jobConfig := ProcessingJobConfig{}
+	err = utils.UnmarshalStructToObj(taskTemplate.GetCustom(), &jobConfig)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid ProcessingJob task specification: not able to unmarshal the custom field to [%s]", m.TaskType)
+	}
+	if len(jobConfig.ImageURI) == 0 {
+		jobConfig.ImageURI = taskTemplate.GetContainer().GetImage()
+	}
+	if len(jobConfig.ImageURI) == 0 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "The ProcessingJob requires an image")
+	}
+	if len(jobConfig.InstanceType) == 0 || jobConfig.InstanceCount < 1 || jobConfig.VolumeSizeInGB < 1 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification,
+			"The ProcessingJob requires an instance type, a positive instance count and a positive volume size")
+	}
+
+	taskInput, err := taskCtx.InputReader().Get(ctx)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "unable to fetch task inputs")
+	}
+
+	processingInputs, err := createProcessingInputs(taskInput.GetLiterals())
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid inputs for the processing job")
+	}
+
+	jobName := taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	outputPath := createProcessingOutputPath(taskCtx.OutputWriter().GetRawOutputPrefix().String(), jobName)
+
+	processingOutputs, err := createProcessingOutputs(taskTemplate.GetInterface().GetOutputs(), outputPath)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid outputs for the processing job")
+	}
+
+	cfg := config.GetSagemakerConfig()
+
+	role := awsUtils.GetRoleFromSecurityContext(cfg.RoleAnnotationKey, taskCtx.TaskExecutionMetadata())
+
+	if len(role) == 0 {
+		role = cfg.RoleArn
+	}
+
+	maxRuntimeInSeconds := jobConfig.MaxRuntimeInSeconds
+	if maxRuntimeInSeconds <= 0 {
+		maxRuntimeInSeconds = 86400 // TODO: decide how to coordinate this and Flyte's timeout
+	}
+
+	environmentKeys := make([]string, 0, len(jobConfig.Environment))
+	for key := range jobConfig.Environment {
+		environmentKeys = append(environmentKeys, key)
+	}
+	sort.Strings(environmentKeys)
+
+	var environment []*commonv1.KeyValuePair
+	for _, key := range environmentKeys {
+		environment = append(environment, &commonv1.KeyValuePair{Name: key, Value: jobConfig.Environment[key]})
+	}
+
+	processingJob := &processingjobv1.ProcessingJob{
+		Spec: processingjobv1.ProcessingJobSpec{
+			AppSpecification: &commonv1.AppSpecification{
+				ImageURI:            jobConfig.ImageURI,
+				ContainerEntrypoint: jobConfig.ContainerEntrypoint,
+				ContainerArguments:  jobConfig.ContainerArguments,
+			},
+			Environment:      environment,
+			ProcessingInputs: processingInputs,
+			ProcessingOutputConfig: &commonv1.ProcessingOutputConfig{
+				Outputs: processingOutputs,
+			},
+			ProcessingResources: &commonv1.ProcessingResources{
+				ClusterConfig: &commonv1.ResourceConfig{
+					InstanceType:   jobConfig.InstanceType,
+					InstanceCount:  ToInt64Ptr(jobConfig.InstanceCount),
+					VolumeSizeInGB: ToInt64Ptr(jobConfig.VolumeSizeInGB),
+				},
+			},
+			RoleArn: ToStringPtr(role),
+			Region:  ToStringPtr(cfg.Region),
+			StoppingCondition: &commonv1.StoppingConditionNoSpot{
+				MaxRuntimeInSeconds: ToInt64Ptr(maxRuntimeInSeconds),
+			},
+		},
+	}
+
+	// The spec doesn't take a job name, the operator derives it from the name and the uid of the resource instead
+	processingJob.Name = jobName
+
+	logger.Infof(ctx, "Successfully built a processing job resource for task [%v]", taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	return processingJob, nil
+}
+
+// createProcessingInputs copies each blob input to a local path named after it, sorted by name for the spec to be stable.
+func createProcessingInputs(inputLiterals map[string]*flyteIdlCore.Literal) ([]*commonv1.ProcessingInput, error) {
+	names := make([]string, 0, len(inputLiterals))
+	for name := range inputLiterals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	processingInputs := make([]*commonv1.ProcessingInput, 0, len(names))
+	for _, name := range names {
+		blob := inputLiterals[name].GetScalar().GetBlob()
+		if blob == nil {
+			return nil, fmt.Errorf("input [%v] should be of Type [Scalar.Blob]", name)
+		}
+
+		processingInputs = append(processingInputs, &commonv1.ProcessingInput{
+			InputName: name,
+			S3Input: commonv1.ProcessingS3Input{
+				LocalPath:              commonv1.LocalPath(fmt.Sprintf("%s/%s", ProcessingInputLocalPathPrefix, name)),
+				S3DataDistributionType: "FullyReplicated",
+				S3DataType:             "S3Prefix",
+				S3InputMode:            "File",
+				S3Uri:                  commonv1.S3Uri(blob.GetUri()),
+			},
+		})
+	}
+
+	return processingInputs, nil
+}
+
+// createProcessingOutputs uploads each blob output from a local path named after it once the job has completed.
+func createProcessingOutputs(outputs *flyteIdlCore.VariableMap, outputPath string) ([]commonv1.ProcessingOutputStruct, error) {
+	names := make([]string, 0, len(outputs.GetVariables()))
+	for name, variable := range outputs.GetVariables() {
+		if variable.GetType().GetBlob() == nil {
+			return nil, fmt.Errorf("output [%v] is of type [%v] but only blob outputs are supported", name, variable.GetType())
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	processingOutputs := make([]commonv1.ProcessingOutputStruct, 0, len(names))
+	for _, name := range names {
+		processingOutputs = append(processingOutputs, commonv1.ProcessingOutputStruct{
+			OutputName: name,
+			S3Output: commonv1.ProcessingS3Output{
+				LocalPath:    commonv1.LocalPath(fmt.Sprintf("%s/%s", ProcessingOutputLocalPathPrefix, name)),
+				S3UploadMode: "EndOfJob",
+				S3Uri:        commonv1.S3Uri(fmt.Sprintf("%s/%s", outputPath, name)),
+			},
+		})
+	}
+
+	return processingOutputs, nil
+}
+
+func (m awsSagemakerPlugin) getTaskPhaseForProcessingJob(
+	ctx context.Context, pluginContext k8s.PluginContext, processingJob *processingjobv1.ProcessingJob) (pluginsCore.PhaseInfo, error) {
+
+	logger.Infof(ctx, "Getting task phase for sagemaker processing job [%v]", processingJob.Status.SageMakerProcessingJobName)
+	info, err := m.getEventInfoForProcessingJob(ctx, processingJob)
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "Failed to get event info for the job")
+	}
+
+	occurredAt := time.Now()
+
+	switch processingJob.Status.ProcessingJobStatus {
+	case ReconcilingProcessingJobStatus:
+		logger.Errorf(ctx, "Job stuck in reconciling status, assuming retryable failure [%s]", processingJob.Status.Additional)
+		execError := &flyteIdlCore.ExecutionError{
+			Message: processingJob.Status.Additional,
+			Kind:    flyteIdlCore.ExecutionError_USER,
+			Code:    ReconcilingProcessingJobStatus,
+		}
+		return pluginsCore.PhaseInfoFailed(pluginsCore.PhaseRetryableFailure, execError, info), nil
+	case sagemaker.ProcessingJobStatusFailed:
+		execError := &flyteIdlCore.ExecutionError{
+			Message: processingJob.Status.Additional,
+			Kind:    flyteIdlCore.ExecutionError_USER,
+			Code:    sagemaker.ProcessingJobStatusFailed,
+		}
+		return pluginsCore.PhaseInfoFailed(pluginsCore.PhasePermanentFailure, execError, info), nil
+	case sagemaker.ProcessingJobStatusStopped:
+		return pluginsCore.PhaseInfoRetryableFailure(pluginErrors.DownstreamSystemError, "Processing Job Stopped", info), nil
+	case sagemaker.ProcessingJobStatusCompleted:
+		taskTemplate, err := pluginContext.TaskReader().Read(ctx)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "unable to read the task template")
+		}
+
+		outputPath := createProcessingOutputPath(pluginContext.OutputWriter().GetRawOutputPrefix().String(), processingJob.Name)
+		outputLiteralMap, err := createBlobOutputLiteralMap(taskTemplate.GetInterface().GetOutputs(), func(name string) string {
+			return fmt.Sprintf("%s/%s", outputPath, name)
+		})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to create outputs, err: %s", err)
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "failed to create outputs for the task")
+		}
+		if err := pluginContext.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(outputLiteralMap, nil)); err != nil {
+			return pluginsCore.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Unable to write output to the remote location")
+		}
+		logger.Debugf(ctx, "Successfully produced and returned outputs")
+		return pluginsCore.PhaseInfoSuccess(info), nil
+	case "":
+		return pluginsCore.PhaseInfoQueued(occurredAt, pluginsCore.DefaultPhaseVersion, "job submitted"), nil
+	}
+
+	return pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, info), nil
+}
+
+func (m awsSagemakerPlugin) getEventInfoForProcessingJob(ctx context.Context, processingJob *processingjobv1.ProcessingJob) (*pluginsCore.TaskInfo, error) {
+
+	var jobRegion, jobName, jobTypeInURL, sagemakerLinkName string
+	jobRegion = *processingJob.Spec.Region
+	jobName = processingJob.Status.SageMakerProcessingJobName
+	if len(jobName) == 0 {
+		jobName = processingJob.Name
+	}
+	jobTypeInURL = "processing-jobs"
+	sagemakerLinkName = ProcessingJobSageMakerLinkName
+
+	logger.Infof(ctx, "Getting event information for SageMaker ProcessingJob task, job region: [%v], job name: [%v], "+
+		"job type in url: [%v], sagemaker link name: [%v]", jobRegion, jobName, jobTypeInURL, sagemakerLinkName)
+
+	return createTaskInfo(ctx, jobRegion, jobName, jobTypeInURL, sagemakerLinkName)
+}
+
+func createProcessingOutputPath(prefix, jobName string) string {
+	return fmt.Sprintf("%s/%s", createOutputPath(prefix, ProcessingOutputPathSubDir), jobName)
+}
@@ -0,0 +1,182 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+
+	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
+	processingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/processingjob"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/config/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+)
+
+var processingJobInputs = map[string]*flyteIdlCore.Literal{
+	"model": generateMockBlobLiteral("s3://bucket/model.tar.gz"),
+	"data":  generateMockBlobLiteral("s3://bucket/data"),
+}
+
+func generateMockProcessingJobTaskTemplate(jobConfig ProcessingJobConfig) *flyteIdlCore.TaskTemplate {
+	return generateMockTaskTemplateWithCustom("the processing job", jobConfig, map[string]*flyteIdlCore.Variable{
+		"report":  generateMockBlobOutputVariable(flyteIdlCore.BlobType_SINGLE),
+		"dataset": generateMockBlobOutputVariable(flyteIdlCore.BlobType_MULTIPART),
+	})
+}
+
+func Test_awsSagemakerPlugin_BuildResourceForProcessingJob(t *testing.T) {
+	ctx := context.TODO()
+	configAccessor := viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{"testdata/config2.yaml"},
+	})
+
+	err := configAccessor.UpdateConfig(context.TODO())
+	assert.NoError(t, err)
+
+	awsSageMakerProcessingJobHandler := awsSagemakerPlugin{TaskType: processingJobTaskType}
+
+	t.Run("Blob inputs and outputs should be mapped to local paths", func(t *testing.T) {
+		taskTemplate := generateMockProcessingJobTaskTemplate(ProcessingJobConfig{
+			ContainerEntrypoint: []string{"python", "evaluate.py"},
+			Environment:         map[string]string{"B": "2", "A": "1"},
+			InstanceType:        "ml.m5.xlarge",
+			InstanceCount:       1,
+			VolumeSizeInGB:      30,
+		})
+
+		resource, err := awsSageMakerProcessingJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, processingJobInputs, &pluginIOMocks.OutputWriter{}))
+		assert.NoError(t, err)
+
+		processingJob, ok := resource.(*processingjobv1.ProcessingJob)
+		assert.True(t, ok)
+		assert.Equal(t, "some-acceptable-name", processingJob.Name)
+		assert.Equal(t, testImage, processingJob.Spec.AppSpecification.ImageURI)
+		assert.Equal(t, []string{"python", "evaluate.py"}, processingJob.Spec.AppSpecification.ContainerEntrypoint)
+		assert.Equal(t, []*commonv1.KeyValuePair{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}, processingJob.Spec.Environment)
+		assert.Equal(t, "default_role", *processingJob.Spec.RoleArn)
+		assert.Equal(t, int64(86400), *processingJob.Spec.StoppingCondition.MaxRuntimeInSeconds)
+
+		if assert.Len(t, processingJob.Spec.ProcessingInputs, 2) {
+			assert.Equal(t, "data", processingJob.Spec.ProcessingInputs[0].InputName)
+			assert.Equal(t, commonv1.LocalPath("/opt/ml/processing/input/data"), processingJob.Spec.ProcessingInputs[0].S3Input.LocalPath)
+			assert.Equal(t, "model", processingJob.Spec.ProcessingInputs[1].InputName)
+			assert.Equal(t, commonv1.S3Uri("s3://bucket/model.tar.gz"), processingJob.Spec.ProcessingInputs[1].S3Input.S3Uri)
+		}
+
+		if assert.Len(t, processingJob.Spec.ProcessingOutputConfig.Outputs, 2) {
+			output := processingJob.Spec.ProcessingOutputConfig.Outputs[1]
+			assert.Equal(t, "report", output.OutputName)
+			assert.Equal(t, commonv1.LocalPath("/opt/ml/processing/output/report"), output.S3Output.LocalPath)
+			assert.Equal(t, commonv1.S3Uri("s3://bucket/raw/processing_outputs/some-acceptable-name/report"), output.S3Output.S3Uri)
+		}
+	})
+
+	t.Run("Non-blob inputs should be rejected", func(t *testing.T) {
+		taskTemplate := generateMockProcessingJobTaskTemplate(ProcessingJobConfig{
+			InstanceType:   "ml.m5.xlarge",
+			InstanceCount:  1,
+			VolumeSizeInGB: 30,
+		})
+
+		_, err := awsSageMakerProcessingJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, map[string]*flyteIdlCore.Literal{
+				"threshold": {Value: &flyteIdlCore.Literal_Scalar{Scalar: &flyteIdlCore.Scalar{
+					Value: &flyteIdlCore.Scalar_Primitive{Primitive: &flyteIdlCore.Primitive{
+						Value: &flyteIdlCore.Primitive_Integer{Integer: 1},
+					}},
+				}}},
+			}, &pluginIOMocks.OutputWriter{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("Resources are required", func(t *testing.T) {
+		taskTemplate := generateMockProcessingJobTaskTemplate(ProcessingJobConfig{InstanceType: "ml.m5.xlarge"})
+
+		_, err := awsSageMakerProcessingJobHandler.BuildResource(ctx,
+			generateMockTaskContextWithInputs(taskTemplate, processingJobInputs, &pluginIOMocks.OutputWriter{}))
+		assert.Error(t, err)
+	})
+}
+
+func Test_awsSagemakerPlugin_GetTaskPhaseForProcessingJob(t *testing.T) {
+	ctx := context.TODO()
+	configAccessor := viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{"testdata/config2.yaml"},
+	})
+
+	err := configAccessor.UpdateConfig(context.TODO())
+	assert.NoError(t, err)
+
+	awsSageMakerProcessingJobHandler := awsSagemakerPlugin{TaskType: processingJobTaskType}
+	taskTemplate := generateMockProcessingJobTaskTemplate(ProcessingJobConfig{
+		InstanceType:   "ml.m5.xlarge",
+		InstanceCount:  1,
+		VolumeSizeInGB: 30,
+	})
+
+	var outputs *flyteIdlCore.LiteralMap
+	outputWriter := &pluginIOMocks.OutputWriter{}
+	outputWriter.OnPutMatch(mock.Anything, mock.MatchedBy(func(reader io.OutputReader) bool {
+		outputs, _, _ = reader.Read(ctx)
+		return true
+	})).Return(nil)
+
+	taskCtx := generateMockTaskContextWithInputs(taskTemplate, processingJobInputs, outputWriter)
+	resource, err := awsSageMakerProcessingJobHandler.BuildResource(ctx, taskCtx)
+	assert.NoError(t, err)
+	processingJob := resource.(*processingjobv1.ProcessingJob)
+
+	t.Run("ReconcilingProcessingJobStatus should lead to a retryable failure", func(t *testing.T) {
+		processingJob.Status.ProcessingJobStatus = ReconcilingProcessingJobStatus
+
+		phaseInfo, err := awsSageMakerProcessingJobHandler.GetTaskPhase(ctx, taskCtx, processingJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, ReconcilingProcessingJobStatus, phaseInfo.Err().GetCode())
+	})
+
+	t.Run("ProcessingJobStatusFailed should be a permanent failure", func(t *testing.T) {
+		processingJob.Status.ProcessingJobStatus = sagemaker.ProcessingJobStatusFailed
+
+		phaseInfo, err := awsSageMakerProcessingJobHandler.GetTaskPhase(ctx, taskCtx, processingJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+	})
+
+	t.Run("The links should refer to the job named by the operator", func(t *testing.T) {
+		processingJob.Status.ProcessingJobStatus = sagemaker.ProcessingJobStatusInProgress
+		processingJob.Status.SageMakerProcessingJobName = "some-acceptable-name-0123456789"
+
+		phaseInfo, err := awsSageMakerProcessingJobHandler.GetTaskPhase(ctx, taskCtx, processingJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRunning, phaseInfo.Phase())
+		if assert.Len(t, phaseInfo.Info().Logs, 2) {
+			assert.Contains(t, phaseInfo.Info().Logs[0].Uri, "group=/aws/sagemaker/ProcessingJobs;prefix=some-acceptable-name-0123456789")
+			assert.Contains(t, phaseInfo.Info().Logs[1].Uri, "#/processing-jobs/some-acceptable-name-0123456789")
+		}
+	})
+
+	t.Run("ProcessingJobStatusCompleted should write the outputs as blobs", func(t *testing.T) {
+		processingJob.Status.ProcessingJobStatus = sagemaker.ProcessingJobStatusCompleted
+
+		phaseInfo, err := awsSageMakerProcessingJobHandler.GetTaskPhase(ctx, taskCtx, processingJob)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseSuccess, phaseInfo.Phase())
+
+		report := outputs.GetLiterals()["report"].GetScalar().GetBlob()
+		assert.Equal(t, "s3://bucket/raw/processing_outputs/some-acceptable-name/report", report.GetUri())
+		assert.Equal(t, flyteIdlCore.BlobType_SINGLE, report.GetMetadata().GetType().GetDimensionality())
+
+		dataset := outputs.GetLiterals()["dataset"].GetScalar().GetBlob()
+		assert.Equal(t, flyteIdlCore.BlobType_MULTIPART, dataset.GetMetadata().GetType().GetDimensionality())
+	})
+}
@@ -0,0 +1,90 @@
+package sagemaker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
+	trainingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/trainingjob"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+const (
+	SpotInterruptionErrorCode = "SpotInterruption"
+
+	// SageMaker doesn't allow spot training jobs to wait for longer than that in total
+	maxSpotTrainingWaitTimeInSeconds = 48 * 60 * 60
+)
+
+// setManagedSpotTraining runs the training job of interruptible tasks as a managed spot training job, checkpointing to
+// a location shared by all the attempts of the task so that retries resume from the latest checkpoint.
+func setManagedSpotTraining(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext, spec *trainingjobv1.TrainingJobSpec) error {
+	cfg := config.GetSagemakerConfig().ManagedSpotTraining
+	if !cfg.Enabled || !taskCtx.TaskExecutionMetadata().IsInterruptible() {
+		return nil
+	}
+
+	if len(cfg.CheckpointPrefix) == 0 {
+		return pluginErrors.Errorf(pluginErrors.RuntimeFailure, "Managed spot training is enabled but no checkpoint prefix is configured")
+	}
+
+	maxWaitTimeInSeconds := int64(cfg.MaxWaitTime.Seconds())
+	if maxWaitTimeInSeconds > maxSpotTrainingWaitTimeInSeconds {
+		maxWaitTimeInSeconds = maxSpotTrainingWaitTimeInSeconds
+	}
+
+	if maxRuntimeInSeconds := spec.StoppingCondition.MaxRuntimeInSeconds; maxRuntimeInSeconds != nil && maxWaitTimeInSeconds < *maxRuntimeInSeconds {
+		if *maxRuntimeInSeconds > maxSpotTrainingWaitTimeInSeconds {
+			return pluginErrors.Errorf(pluginErrors.BadTaskSpecification,
+				"The max run time [%vs] of interruptible tasks can't exceed the [%vs] spot training jobs can take in total",
+				*maxRuntimeInSeconds, maxSpotTrainingWaitTimeInSeconds)
+		}
+
+		maxWaitTimeInSeconds = *maxRuntimeInSeconds
+	}
+
+	checkpointPath := createCheckpointPath(cfg.CheckpointPrefix, taskCtx.TaskExecutionMetadata().GetTaskExecutionID())
+
+	logger.Infof(ctx, "Running the training job of interruptible task [%v] on spot instances, checkpointing to [%v]",
+		taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), checkpointPath)
+
+	enabled := true
+	spec.EnableManagedSpotTraining = &enabled
+	spec.StoppingCondition.MaxWaitTimeInSeconds = ToInt64Ptr(maxWaitTimeInSeconds)
+	spec.CheckpointConfig = &commonv1.CheckpointConfig{
+		S3Uri: ToStringPtr(checkpointPath),
+	}
+
+	return nil
+}
+
+// createCheckpointPath derives the path from the node execution, leaving out the retry attempt, so that the path is the
+// same for all the attempts of the task. The raw output prefix can't be used since it changes between attempts.
+func createCheckpointPath(prefix string, taskExecutionID pluginsCore.TaskExecutionID) string {
+	nodeExecutionID := taskExecutionID.GetID().NodeExecutionId
+	return fmt.Sprintf("%s/%s/%s/%s/%s", strings.TrimSuffix(prefix, "/"),
+		nodeExecutionID.GetExecutionId().GetProject(), nodeExecutionID.GetExecutionId().GetDomain(),
+		nodeExecutionID.GetExecutionId().GetName(), nodeExecutionID.GetNodeId())
+}
+
+// getPhaseInfoForSpotInterruption reports training jobs that ended because spot instances were reclaimed, or never became
+// available in time, as retryable system failures rather than failures of the task.
+func getPhaseInfoForSpotInterruption(trainingJob *trainingjobv1.TrainingJob, info *pluginsCore.TaskInfo) (pluginsCore.PhaseInfo, bool) {
+	status := trainingJob.Status
+	if status.TrainingJobStatus != sagemaker.TrainingJobStatusFailed && status.TrainingJobStatus != sagemaker.TrainingJobStatusStopped {
+		return pluginsCore.PhaseInfoUndefined, false
+	}
+
+	if status.SecondaryStatus != sagemaker.SecondaryStatusInterrupted && status.SecondaryStatus != sagemaker.SecondaryStatusMaxWaitTimeExceeded {
+		return pluginsCore.PhaseInfoUndefined, false
+	}
+
+	return pluginsCore.PhaseInfoSystemRetryableFailure(SpotInterruptionErrorCode,
+		fmt.Sprintf("Spot training job [%v] is [%v]: %v", status.SageMakerTrainingJobName, status.SecondaryStatus, status.Additional), info), true
+}
@@ -0,0 +1,173 @@
+package sagemaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/common"
+	trainingjobv1 "github.com/aws/amazon-sagemaker-operator-for-k8s/api/v1/trainingjob"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	taskError "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/sagemaker/config"
+)
+
+func generateMockSpotTrainingTaskContext(interruptible bool, retryAttempt uint32) pluginsCore.TaskExecutionContext {
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(flyteIdlCore.TaskExecutionIdentifier{
+		NodeExecutionId: &flyteIdlCore.NodeExecutionIdentifier{
+			NodeId: "n0",
+			ExecutionId: &flyteIdlCore.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+		RetryAttempt: retryAttempt,
+	})
+	tID.OnGetGeneratedName().Return("some-acceptable-name")
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+	taskExecutionMetadata.OnIsInterruptible().Return(interruptible)
+
+	taskCtx := &mocks.TaskExecutionContext{}
+	taskCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return taskCtx
+}
+
+func generateMockTrainingJobSpec() trainingjobv1.TrainingJobSpec {
+	return trainingjobv1.TrainingJobSpec{
+		StoppingCondition: &commonv1.StoppingCondition{
+			MaxRuntimeInSeconds: ToInt64Ptr(86400),
+		},
+	}
+}
+
+func Test_setManagedSpotTraining(t *testing.T) {
+	ctx := context.TODO()
+	defaultCfg := config.GetSagemakerConfig()
+	defer func() {
+		_ = config.SetSagemakerConfig(defaultCfg)
+	}()
+
+	cfg := *defaultCfg
+	cfg.ManagedSpotTraining = config.ManagedSpotTrainingConfig{
+		Enabled:          true,
+		MaxWaitTime:      stdConfig.Duration{Duration: 48 * time.Hour},
+		CheckpointPrefix: "s3://checkpoints/",
+	}
+	assert.NoError(t, config.SetSagemakerConfig(&cfg))
+
+	t.Run("Interruptible tasks run on spot instances with checkpoints", func(t *testing.T) {
+		spec := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec))
+
+		assert.True(t, *spec.EnableManagedSpotTraining)
+		assert.Equal(t, int64(172800), *spec.StoppingCondition.MaxWaitTimeInSeconds)
+		assert.Equal(t, "s3://checkpoints/my_project/my_domain/my_name/n0", *spec.CheckpointConfig.S3Uri)
+	})
+
+	t.Run("The checkpoints are shared by the retries", func(t *testing.T) {
+		first := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &first))
+		second := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 2), &second))
+
+		assert.Equal(t, *first.CheckpointConfig.S3Uri, *second.CheckpointConfig.S3Uri)
+	})
+
+	t.Run("Non-interruptible tasks run on demand", func(t *testing.T) {
+		spec := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(false, 0), &spec))
+
+		assert.Nil(t, spec.EnableManagedSpotTraining)
+		assert.Nil(t, spec.StoppingCondition.MaxWaitTimeInSeconds)
+		assert.Nil(t, spec.CheckpointConfig)
+	})
+
+	t.Run("The max wait time can't be shorter than the max run time", func(t *testing.T) {
+		cfg.ManagedSpotTraining.MaxWaitTime = stdConfig.Duration{Duration: time.Hour}
+		assert.NoError(t, config.SetSagemakerConfig(&cfg))
+
+		spec := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec))
+
+		assert.Equal(t, int64(86400), *spec.StoppingCondition.MaxWaitTimeInSeconds)
+	})
+
+	t.Run("The max wait time is capped to what SageMaker allows", func(t *testing.T) {
+		cfg.ManagedSpotTraining.MaxWaitTime = stdConfig.Duration{Duration: 72 * time.Hour}
+		assert.NoError(t, config.SetSagemakerConfig(&cfg))
+
+		spec := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec))
+
+		assert.Equal(t, int64(172800), *spec.StoppingCondition.MaxWaitTimeInSeconds)
+	})
+
+	t.Run("The max run time can't exceed what SageMaker allows", func(t *testing.T) {
+		spec := generateMockTrainingJobSpec()
+		spec.StoppingCondition.MaxRuntimeInSeconds = ToInt64Ptr(72 * 60 * 60)
+		err := setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec)
+
+		assert.Error(t, err)
+		assert.True(t, stdErrors.IsCausedBy(err, taskError.BadTaskSpecification))
+	})
+
+	t.Run("A checkpoint prefix is required", func(t *testing.T) {
+		cfg.ManagedSpotTraining.CheckpointPrefix = ""
+		assert.NoError(t, config.SetSagemakerConfig(&cfg))
+
+		spec := generateMockTrainingJobSpec()
+		assert.Error(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec))
+	})
+
+	t.Run("Spot training can be disabled", func(t *testing.T) {
+		cfg.ManagedSpotTraining.Enabled = false
+		assert.NoError(t, config.SetSagemakerConfig(&cfg))
+
+		spec := generateMockTrainingJobSpec()
+		assert.NoError(t, setManagedSpotTraining(ctx, generateMockSpotTrainingTaskContext(true, 0), &spec))
+
+		assert.Nil(t, spec.EnableManagedSpotTraining)
+	})
+}
+
+func Test_getPhaseInfoForSpotInterruption(t *testing.T) {
+	trainingJob := &trainingjobv1.TrainingJob{}
+
+	t.Run("Interrupted jobs are retryable system failures", func(t *testing.T) {
+		trainingJob.Status.TrainingJobStatus = sagemaker.TrainingJobStatusStopped
+		trainingJob.Status.SecondaryStatus = sagemaker.SecondaryStatusMaxWaitTimeExceeded
+
+		phaseInfo, interrupted := getPhaseInfoForSpotInterruption(trainingJob, nil)
+		assert.True(t, interrupted)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phaseInfo.Phase())
+		assert.Equal(t, flyteIdlCore.ExecutionError_SYSTEM, phaseInfo.Err().GetKind())
+		assert.Equal(t, SpotInterruptionErrorCode, phaseInfo.Err().GetCode())
+	})
+
+	t.Run("Jobs waiting for spot instances are not interrupted", func(t *testing.T) {
+		trainingJob.Status.TrainingJobStatus = sagemaker.TrainingJobStatusInProgress
+		trainingJob.Status.SecondaryStatus = sagemaker.SecondaryStatusInterrupted
+
+		_, interrupted := getPhaseInfoForSpotInterruption(trainingJob, nil)
+		assert.False(t, interrupted)
+	})
+
+	t.Run("Other failures are left to the job status", func(t *testing.T) {
+		trainingJob.Status.TrainingJobStatus = sagemaker.TrainingJobStatusFailed
+		trainingJob.Status.SecondaryStatus = sagemaker.SecondaryStatusFailed
+
+		_, interrupted := getPhaseInfoForSpotInterruption(trainingJob, nil)
+		assert.False(t, interrupted)
+	})
+}
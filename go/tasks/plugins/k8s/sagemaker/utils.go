@@ -66,8 +66,8 @@ func getTrainingJobImage(ctx context.Context, _ pluginsCore.TaskExecutionContext
 }
 
 // Finds the algorithm configuration for the given name
-func findAlgorithmConfig(cfg *config.Config, name string) (config.PrebuiltAlgorithmConfig, error) {
-	for _, algorithmCfg := range cfg.PrebuiltAlgorithms {
+func findAlgorithmConfig(prebuiltAlgorithms []config.PrebuiltAlgorithmConfig, name string) (config.PrebuiltAlgorithmConfig, error) {
+	for _, algorithmCfg := range prebuiltAlgorithms {
 		if strings.EqualFold(name, algorithmCfg.Name) {
 			return algorithmCfg, nil
 		}
@@ -93,51 +93,12 @@ func getPrebuiltTrainingImage(ctx context.Context, job *flyteSagemakerIdl.Traini
 
 	if specifiedAlg := job.GetAlgorithmSpecification().GetAlgorithmName(); specifiedAlg != flyteSagemakerIdl.AlgorithmName_CUSTOM {
 		// Built-in algorithm mode
-		apiAlgorithmName := specifiedAlg.String()
-
-		foundAlgorithmCfg, err := findAlgorithmConfig(cfg, apiAlgorithmName)
-		if err != nil {
-			return "", err
-		}
-
-		foundRegionalCfg, err := findRegionConfig(foundAlgorithmCfg, cfg.Region)
+		resolver, err := newAlgorithmImageResolver(cfg)
 		if err != nil {
 			return "", err
 		}
 
-		userSpecifiedVer := job.GetAlgorithmSpecification().GetAlgorithmVersion()
-		// If the user does not specify a version -> use the latest version found in the config possible
-		if userSpecifiedVer == "" {
-			logger.Infof(ctx, "The version of the algorithm [%v] is not specified. "+
-				"The plugin will try to pick the latest version available for the algorithm-region combination.", userSpecifiedVer, apiAlgorithmName, cfg.Region)
-			latestTrainingImage, err := getLatestTrainingImage(foundRegionalCfg.VersionConfigs)
-			if err != nil {
-				return "", errors.Wrapf(ErrSagemaker, err, "Failed to identify the latest image for algorithm:region [%v:%v]",
-					apiAlgorithmName, cfg.Region)
-			}
-			return latestTrainingImage, nil
-		}
-		// If the user specified a version -> we have to translate it to semver and find an exact match
-		for _, versionCfg := range foundRegionalCfg.VersionConfigs {
-			configSemVer, err := semver.NewVersion(versionCfg.Version)
-			if err != nil {
-				return "", errors.Wrapf(ErrSagemaker, err, "Unable to cast version listed in the config [%v] to a semver", versionCfg.Version)
-			}
-			userSpecifiedSemVer, err := semver.NewVersion(userSpecifiedVer)
-			if err != nil {
-				return "", errors.Wrapf(ErrSagemaker, err, "Unable to cast version specified by the user [%v] to a semver", userSpecifiedVer)
-			}
-			if configSemVer.Equal(userSpecifiedSemVer) {
-				logger.Infof(ctx, "Image [%v] is picked for algorithm [%v] region [%v] version [%v] ",
-					versionCfg.Image, apiAlgorithmName, cfg.Region, userSpecifiedSemVer)
-				return versionCfg.Image, nil
-			}
-		}
-		logger.Errorf(ctx, "Failed to find an image for [%v]:[%v]:[%v]",
-			job.GetAlgorithmSpecification().GetAlgorithmName(), cfg.Region, job.GetAlgorithmSpecification().GetAlgorithmVersion())
-
-		return "", errors.Errorf(ErrSagemaker, "Failed to find an image for [%v]:[%v]:[%v]",
-			job.GetAlgorithmSpecification().GetAlgorithmName(), cfg.Region, job.GetAlgorithmSpecification().GetAlgorithmVersion())
+		return resolver.ResolveImage(ctx, specifiedAlg.String(), cfg.Region, job.GetAlgorithmSpecification().GetAlgorithmVersion())
 	}
 	// Custom image
 	return "", errors.Errorf(ErrSagemaker, "It is invalid to try getting a prebuilt image for AlgorithmName == CUSTOM ")
@@ -371,9 +332,22 @@ func getTaskTemplate(ctx context.Context, taskCtx pluginsCore.TaskExecutionConte
 	return taskTemplate, nil
 }
 
+// getCloudWatchLogGroup returns the log group SageMaker writes the logs of the jobs to. The logs of the training jobs
+// started by hyperparameter tuning jobs are written along with those of standalone training jobs.
+func getCloudWatchLogGroup(jobTypeInURL string) string {
+	switch jobTypeInURL {
+	case "transform-jobs":
+		return "TransformJobs"
+	case "processing-jobs":
+		return "ProcessingJobs"
+	default:
+		return "TrainingJobs"
+	}
+}
+
 func createTaskInfo(_ context.Context, jobRegion string, jobName string, jobTypeInURL string, sagemakerLinkName string) (*pluginsCore.TaskInfo, error) {
-	cwLogURL := fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#logStream:group=/aws/sagemaker/TrainingJobs;prefix=%s;streamFilter=typeLogStreamPrefix",
-		jobRegion, jobRegion, jobName)
+	cwLogURL := fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#logStream:group=/aws/sagemaker/%s;prefix=%s;streamFilter=typeLogStreamPrefix",
+		jobRegion, jobRegion, getCloudWatchLogGroup(jobTypeInURL), jobName)
 	smLogURL := fmt.Sprintf("https://%s.console.aws.amazon.com/sagemaker/home?region=%s#/%s/%s",
 		jobRegion, jobRegion, jobTypeInURL, jobName)
 
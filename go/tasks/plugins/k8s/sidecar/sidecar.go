@@ -31,7 +31,13 @@ type sidecarResourceHandler struct{}
 // This method handles templatizing primary container input args, env variables and adds a GPU toleration to the pod
 // spec if necessary.
 func validateAndFinalizePod(
-	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext, primaryContainerName string, pod k8sv1.Pod) (*k8sv1.Pod, error) {
+	ctx context.Context, taskCtx pluginsCore.TaskExecutionContext, task *core.TaskTemplate, primaryContainerName string,
+	pod k8sv1.Pod) (*k8sv1.Pod, error) {
+	// The accelerator profile may rename the GPUs the containers request, which the tolerations are derived from.
+	if err := flytek8s.ApplyAcceleratorProfile(task, taskCtx.TaskExecutionMetadata(), &pod.Spec); err != nil {
+		return nil, err
+	}
+
 	var hasPrimaryContainer bool
 
 	finalizedContainers := make([]k8sv1.Container, len(pod.Spec.Containers))
@@ -217,7 +223,7 @@ func (sidecarResourceHandler) BuildResource(ctx context.Context, taskCtx plugins
 
 	pod.Spec.ServiceAccountName = flytek8s.GetServiceAccountNameFromTaskExecutionMetadata(taskCtx.TaskExecutionMetadata())
 
-	pod, err = validateAndFinalizePod(ctx, taskCtx, podSpecResource.primaryContainerName, *pod)
+	pod, err = validateAndFinalizePod(ctx, taskCtx, task, podSpecResource.primaryContainerName, *pod)
 	if err != nil {
 		return nil, err
 	}
@@ -241,20 +247,32 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 		OccurredAt: &transitionOccurredAt,
 	}
 	if pod.Status.Phase != k8sv1.PodPending && pod.Status.Phase != k8sv1.PodUnknown {
-		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, 0, " (User)")
+		taskTemplate, err := pluginContext.TaskReader().Read(ctx)
+		if err != nil {
+			return pluginsCore.PhaseInfoUndefined, err
+		}
+
+		id := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+		taskLogs, err := logs.GetLogsForContainerInPod(ctx, pod, &id, taskTemplate.GetType(), 0, " (User)")
 		if err != nil {
 			return pluginsCore.PhaseInfoUndefined, err
 		}
 		info.Logs = taskLogs
+
+		if primaryContainerName, ok := r.GetAnnotations()[primaryContainerKey]; ok {
+			archivedLog := logs.ArchiveLogsForContainerInPod(ctx, pluginContext, pod, primaryContainerName, " (User)")
+			if archivedLog != nil {
+				info.Logs = append(info.Logs, archivedLog)
+			}
+		}
 	}
 	switch pod.Status.Phase {
 	case k8sv1.PodSucceeded:
 		return flytek8s.DemystifySuccess(pod.Status, info)
 	case k8sv1.PodFailed:
-		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
-		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
+		return flytek8s.DemystifyFailure(pod.Status, info, pluginContext.TaskExecutionMetadata().IsInterruptible())
 	case k8sv1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		return flytek8s.DemystifyPendingPod(pod)
 	case k8sv1.PodReasonUnschedulable:
 		return pluginsCore.PhaseInfoQueued(transitionOccurredAt, pluginsCore.DefaultPhaseVersion, "pod unschedulable"), nil
 	case k8sv1.PodUnknown:
@@ -26,6 +26,7 @@ import (
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
@@ -245,6 +246,79 @@ func TestBuildSidecarResource_TaskType2(t *testing.T) {
 
 }
 
+func TestBuildSidecarResource_TaskType2_AcceleratorProfile(t *testing.T) {
+	podSpec := getPodSpec()
+	podSpec.Containers[0].Resources.Limits[ResourceNvidiaGPU] = resource.MustParse("1")
+
+	b, err := json.Marshal(podSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	structObj := &structpb.Struct{}
+	if err := json.Unmarshal(b, structObj); err != nil {
+		t.Fatal(err)
+	}
+
+	task := core.TaskTemplate{
+		TaskTypeVersion: 2,
+		Config: map[string]string{
+			primaryContainerKey:           "primary container",
+			flytek8s.AcceleratorConfigKey: "a100-mig-1g",
+		},
+		Target: &core.TaskTemplate_K8SPod{
+			K8SPod: &core.K8SPod{
+				PodSpec: structObj,
+			},
+		},
+	}
+
+	tolGPU := v1.Toleration{
+		Key:      "flyte/gpu",
+		Value:    "dedicated",
+		Operator: v1.TolerationOpEqual,
+		Effect:   v1.TaintEffectNoSchedule,
+	}
+
+	tolMIG := v1.Toleration{
+		Key:      "flyte/mig",
+		Value:    "dedicated",
+		Operator: v1.TolerationOpEqual,
+		Effect:   v1.TaintEffectNoSchedule,
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		ResourceTolerations: map[v1.ResourceName][]v1.Toleration{
+			ResourceNvidiaGPU:       {tolGPU},
+			"nvidia.com/mig-1g.5gb": {tolMIG},
+		},
+		AcceleratorProfiles: map[string]config.AcceleratorProfile{
+			"a100-mig-1g": {
+				NodeSelector: map[string]string{"accelerator": "nvidia-tesla-a100"},
+				ResourceName: "nvidia.com/mig-1g.5gb",
+			},
+		},
+		DefaultCPURequest:    "1024m",
+		DefaultMemoryRequest: "1024Mi",
+	}))
+	handler := &sidecarResourceHandler{}
+	taskCtx := getDummySidecarTaskContext(&task, resourceRequirements)
+	res, err := handler.BuildResource(context.TODO(), taskCtx)
+	assert.Nil(t, err)
+
+	// The tolerations follow the GPU resource the profile renamed.
+	pod := res.(*v1.Pod)
+	assert.Equal(t, map[string]string{"accelerator": "nvidia-tesla-a100"}, pod.Spec.NodeSelector)
+	assert.Contains(t, pod.Spec.Containers[0].Resources.Limits, v1.ResourceName("nvidia.com/mig-1g.5gb"))
+	assert.NotContains(t, pod.Spec.Containers[0].Resources.Limits, v1.ResourceName(ResourceNvidiaGPU))
+	assert.ElementsMatch(t, []v1.Toleration{
+		{
+			Key:   "my toleration key",
+			Value: "my toleration value",
+		},
+		tolMIG,
+	}, pod.Spec.Tolerations)
+}
+
 func TestBuildSidecarResource_TaskType2_Invalid_Spec(t *testing.T) {
 	task := core.TaskTemplate{
 		TaskTypeVersion: 2,
@@ -1,8 +1,11 @@
 package spark
 
 import (
+	"context"
+
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	"github.com/flyteorg/flytestdlib/config"
 )
 
 //go:generate pflags Config --default-var=defaultConfig
@@ -17,7 +20,12 @@ var (
 		},
 	}
 
-	sparkConfigSection = pluginsConfig.MustRegisterSubSection("spark", defaultConfig)
+	sparkConfigSection = pluginsConfig.MustRegisterSubSectionWithUpdates("spark", defaultConfig,
+		func(ctx context.Context, newValue config.Config) {
+			logResolvers.update(&newValue.(*Config).LogConfig)
+		})
+
+	logResolvers = newLogResolvers(&defaultConfig.LogConfig)
 )
 
 // Spark-specific configs
@@ -35,6 +43,30 @@ type LogConfig struct {
 	AllUser logs.LogConfig `json:"all-user" pflag:",All user logs across driver and executors."`
 }
 
+// logConfigResolvers resolve the log plugins of each log config, rebuilt whenever the config changes.
+type logConfigResolvers struct {
+	Mixed   *logs.CachedResolver
+	User    *logs.CachedResolver
+	System  *logs.CachedResolver
+	AllUser *logs.CachedResolver
+}
+
+func newLogResolvers(cfg *LogConfig) logConfigResolvers {
+	return logConfigResolvers{
+		Mixed:   logs.NewCachedResolver(&cfg.Mixed),
+		User:    logs.NewCachedResolver(&cfg.User),
+		System:  logs.NewCachedResolver(&cfg.System),
+		AllUser: logs.NewCachedResolver(&cfg.AllUser),
+	}
+}
+
+func (r logConfigResolvers) update(cfg *LogConfig) {
+	r.Mixed.Update(&cfg.Mixed)
+	r.User.Update(&cfg.User)
+	r.System.Update(&cfg.System)
+	r.AllUser.Update(&cfg.AllUser)
+}
+
 // Optional feature with name and corresponding spark-config to use.
 type Feature struct {
 	Name        string            `json:"name"`
@@ -47,5 +79,10 @@ func GetSparkConfig() *Config {
 
 // This method should be used for unit testing only
 func setSparkConfig(cfg *Config) error {
-	return sparkConfigSection.SetConfig(cfg)
+	if err := sparkConfigSection.SetConfig(cfg); err != nil {
+		return err
+	}
+
+	logResolvers.update(&cfg.LogConfig)
+	return nil
 }
@@ -275,27 +275,28 @@ func (sparkResourceHandler) BuildIdentityResource(ctx context.Context, taskCtx p
 	}, nil
 }
 
-func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo, error) {
+func getEventInfoForSpark(sj *sparkOp.SparkApplication, taskExecID *core.TaskExecutionIdentifier) (*pluginsCore.TaskInfo, error) {
 	state := sj.Status.AppState.State
 	isQueued := state == sparkOp.NewState ||
 		state == sparkOp.PendingSubmissionState ||
 		state == sparkOp.SubmittedState
 
-	sparkConfig := GetSparkConfig()
 	taskLogs := make([]*core.TaskLog, 0, 3)
+	selection := logs.NewSelection(taskExecID, sparkTaskType, sj.Labels)
 
 	if !isQueued {
 		if sj.Status.DriverInfo.PodName != "" {
-			p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.Mixed)
+			p, err := logResolvers.Mixed.Resolve(selection)
 			if err != nil {
 				return nil, err
 			}
 
 			if p != nil {
 				o, err := p.GetTaskLogs(tasklog.Input{
-					PodName:   sj.Status.DriverInfo.PodName,
-					Namespace: sj.Namespace,
-					LogName:   "(Driver Logs)",
+					PodName:                 sj.Status.DriverInfo.PodName,
+					Namespace:               sj.Namespace,
+					LogName:                 "(Driver Logs)",
+					TaskExecutionIdentifier: taskExecID,
 				})
 
 				if err != nil {
@@ -306,16 +307,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 			}
 		}
 
-		p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.User)
+		p, err := logResolvers.User.Resolve(selection)
 		if err != nil {
 			return nil, err
 		}
 
 		if p != nil {
 			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Status.DriverInfo.PodName,
-				Namespace: sj.Namespace,
-				LogName:   "(User Logs)",
+				PodName:                 sj.Status.DriverInfo.PodName,
+				Namespace:               sj.Namespace,
+				LogName:                 "(User Logs)",
+				TaskExecutionIdentifier: taskExecID,
 			})
 
 			if err != nil {
@@ -325,16 +327,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 			taskLogs = append(taskLogs, o.TaskLogs...)
 		}
 
-		p, err = logs.InitializeLogPlugins(&sparkConfig.LogConfig.System)
+		p, err = logResolvers.System.Resolve(selection)
 		if err != nil {
 			return nil, err
 		}
 
 		if p != nil {
 			o, err := p.GetTaskLogs(tasklog.Input{
-				PodName:   sj.Name,
-				Namespace: sj.Namespace,
-				LogName:   "(System Logs)",
+				PodName:                 sj.Name,
+				Namespace:               sj.Namespace,
+				LogName:                 "(System Logs)",
+				TaskExecutionIdentifier: taskExecID,
 			})
 
 			if err != nil {
@@ -345,16 +348,17 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 		}
 	}
 
-	p, err := logs.InitializeLogPlugins(&sparkConfig.LogConfig.AllUser)
+	p, err := logResolvers.AllUser.Resolve(selection)
 	if err != nil {
 		return nil, err
 	}
 
 	if p != nil {
 		o, err := p.GetTaskLogs(tasklog.Input{
-			PodName:   sj.Name,
-			Namespace: sj.Namespace,
-			LogName:   "(Spark-Submit/All User Logs)",
+			PodName:                 sj.Name,
+			Namespace:               sj.Namespace,
+			LogName:                 "(Spark-Submit/All User Logs)",
+			TaskExecutionIdentifier: taskExecID,
 		})
 
 		if err != nil {
@@ -402,7 +406,8 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 func (sparkResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 
 	app := resource.(*sparkOp.SparkApplication)
-	info, err := getEventInfoForSpark(app)
+	id := pluginContext.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	info, err := getEventInfoForSpark(app, &id)
 	if err != nil {
 		return pluginsCore.PhaseInfoUndefined, err
 	}
@@ -30,6 +30,7 @@ var (
 				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
 				Workers:           10,
 				MaxSystemFailures: 5,
+				BatchSize:         50,
 			},
 			ResourceMeta: nil,
 		},
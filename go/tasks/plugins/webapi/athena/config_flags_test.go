@@ -183,6 +183,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_webApi.caching.notifierResyncInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WebAPI.Caching.NotifierResyncInterval.String()
+
+			cmdFlags.Set("webApi.caching.notifierResyncInterval", testValue)
+			if vString, err := cmdFlags.GetString("webApi.caching.notifierResyncInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WebAPI.Caching.NotifierResyncInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_webApi.caching.workers", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -211,6 +225,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_webApi.caching.batchSize", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.caching.batchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("webApi.caching.batchSize"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WebAPI.Caching.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_defaultWorkGroup", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -32,9 +32,24 @@ const (
 	ErrSystem       errors.ErrorCode = "System"
 )
 
+// Max number of query executions BatchGetQueryExecution accepts in a single call.
+const maxBatchGetSize = 50
+
+// The subset of the Athena API used by the plugin.
+type athenaClient interface {
+	StartQueryExecution(ctx context.Context, params *athena.StartQueryExecutionInput, optFns ...func(*athena.Options)) (
+		*athena.StartQueryExecutionOutput, error)
+	GetQueryExecution(ctx context.Context, params *athena.GetQueryExecutionInput, optFns ...func(*athena.Options)) (
+		*athena.GetQueryExecutionOutput, error)
+	BatchGetQueryExecution(ctx context.Context, params *athena.BatchGetQueryExecutionInput, optFns ...func(*athena.Options)) (
+		*athena.BatchGetQueryExecutionOutput, error)
+	StopQueryExecution(ctx context.Context, params *athena.StopQueryExecutionInput, optFns ...func(*athena.Options)) (
+		*athena.StopQueryExecutionOutput, error)
+}
+
 type Plugin struct {
 	metricScope promutils.Scope
-	client      *athena.Client
+	client      athenaClient
 	cfg         *Config
 	awsConfig   awsSdk.Config
 }
@@ -75,6 +90,9 @@ func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextRead
 		return nil, nil, errors.Errorf(errors2.BadTaskSpecification, "Database must not be empty.")
 	}
 
+	// The request token is derived from the task execution. Athena returns the query execution started by a previous
+	// attempt with the same token instead of starting a new one, which makes Create safe to call again without
+	// implementing webapi.ResourceAdopter.
 	execID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().NodeExecutionId.GetExecutionId()
 	resp, err := p.client.StartQueryExecution(ctx, &athena.StartQueryExecutionInput{
 		ClientRequestToken: awsSdk.String(fmt.Sprintf("%v-%v-%v", execID.Project, execID.Domain, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())),
@@ -110,11 +128,62 @@ func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.
 		return nil, err
 	}
 
-	// Only cache fields we want to keep in memory instead of the potentially huge execution closure.
+	return newResourceWrapper(resp.QueryExecution), nil
+}
+
+// BatchGet retrieves the query executions in batches of up to maxBatchGetSize. Executions Athena couldn't process are
+// left nil to be retrieved individually.
+func (p Plugin) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) (latest []webapi.Resource, err error) {
+	latest = make([]webapi.Resource, len(tCtxs))
+	indices := make(map[string][]int, len(tCtxs))
+	execIDs := make([]string, 0, len(tCtxs))
+	for i, tCtx := range tCtxs {
+		execID := tCtx.ResourceMeta().(string)
+		if _, found := indices[execID]; !found {
+			execIDs = append(execIDs, execID)
+		}
+
+		indices[execID] = append(indices[execID], i)
+	}
+
+	for start := 0; start < len(execIDs); start += maxBatchGetSize {
+		end := start + maxBatchGetSize
+		if end > len(execIDs) {
+			end = len(execIDs)
+		}
+
+		resp, err := p.client.BatchGetQueryExecution(ctx, &athena.BatchGetQueryExecutionInput{
+			QueryExecutionIds: execIDs[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.QueryExecutions {
+			exec := &resp.QueryExecutions[i]
+			if exec.QueryExecutionId == nil {
+				continue
+			}
+
+			for _, idx := range indices[*exec.QueryExecutionId] {
+				latest[idx] = newResourceWrapper(exec)
+			}
+		}
+
+		if len(resp.UnprocessedQueryExecutionIds) > 0 {
+			logger.Infof(ctx, "Athena didn't process [%v] query executions in batch.", len(resp.UnprocessedQueryExecutionIds))
+		}
+	}
+
+	return latest, nil
+}
+
+// Only cache fields we want to keep in memory instead of the potentially huge execution closure.
+func newResourceWrapper(exec *athenaTypes.QueryExecution) ResourceWrapper {
 	return ResourceWrapper{
-		Status:               resp.QueryExecution.Status,
-		ResultsConfiguration: resp.QueryExecution.ResultConfiguration,
-	}, nil
+		Status:               exec.Status,
+		ResultsConfiguration: exec.ResultConfiguration,
+	}
 }
 
 func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
@@ -1,13 +1,20 @@
 package athena
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenaTypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 )
 
 func TestCreateTaskInfo(t *testing.T) {
@@ -28,3 +35,56 @@ func TestCreateTaskInfo(t *testing.T) {
 		},
 	}, taskInfo.Metadata))
 }
+
+type fakeAthenaClient struct {
+	athenaClient
+
+	batchGetCalls int
+}
+
+func (f *fakeAthenaClient) BatchGetQueryExecution(_ context.Context, params *athena.BatchGetQueryExecutionInput,
+	_ ...func(*athena.Options)) (*athena.BatchGetQueryExecutionOutput, error) {
+
+	f.batchGetCalls++
+	resp := &athena.BatchGetQueryExecutionOutput{}
+	for _, id := range params.QueryExecutionIds {
+		if id == "unprocessed" {
+			resp.UnprocessedQueryExecutionIds = append(resp.UnprocessedQueryExecutionIds,
+				athenaTypes.UnprocessedQueryExecutionId{QueryExecutionId: awsSdk.String(id)})
+			continue
+		}
+
+		resp.QueryExecutions = append(resp.QueryExecutions, athenaTypes.QueryExecution{
+			QueryExecutionId: awsSdk.String(id),
+			Status:           &athenaTypes.QueryExecutionStatus{State: athenaTypes.QueryExecutionStateRunning},
+		})
+	}
+
+	return resp, nil
+}
+
+func TestPlugin_BatchGet(t *testing.T) {
+	client := &fakeAthenaClient{}
+	p := Plugin{client: client}
+
+	tCtxs := make([]webapi.GetContext, 0, maxBatchGetSize+2)
+	for i := 0; i < maxBatchGetSize; i++ {
+		tCtx := &mocks.GetContext{}
+		tCtx.OnResourceMeta().Return(fmt.Sprintf("query-%v", i))
+		tCtxs = append(tCtxs, tCtx)
+	}
+
+	for _, id := range []string{"unprocessed", "extra"} {
+		tCtx := &mocks.GetContext{}
+		tCtx.OnResourceMeta().Return(id)
+		tCtxs = append(tCtxs, tCtx)
+	}
+
+	latest, err := p.BatchGet(context.Background(), tCtxs)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.batchGetCalls)
+	assert.Len(t, latest, len(tCtxs))
+	assert.Equal(t, athenaTypes.QueryExecutionStateRunning, latest[0].(ResourceWrapper).Status.State)
+	assert.Nil(t, latest[maxBatchGetSize])
+	assert.NotNil(t, latest[maxBatchGetSize+1])
+}
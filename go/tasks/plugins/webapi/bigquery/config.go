@@ -45,6 +45,7 @@ var (
 			},
 		},
 		GoogleTokenSource: google.GetDefaultConfig(),
+		MaxResultRows:     1,
 	}
 
 	configSection = pluginsConfig.MustRegisterSubSection("bigquery", &defaultConfig)
@@ -61,6 +62,9 @@ type Config struct {
 	// GoogleTokenSource configures token source for BigQuery client
 	GoogleTokenSource google.TokenSourceFactoryConfig `json:"googleTokenSource" pflag:",Defines Google token source"`
 
+	// MaxResultRows caps the number of rows a query may return to have its first row read into primitive outputs
+	MaxResultRows int64 `json:"maxResultRows" pflag:",Defines the maximum number of rows a query may return to have its first row read into primitive outputs."`
+
 	// bigQueryEndpoint overrides BigQuery client endpoint, only for testing
 	bigQueryEndpoint string
 }
@@ -0,0 +1,66 @@
+package bigquery
+
+import (
+	"github.com/pkg/errors"
+
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/api/bigquery/v2"
+)
+
+type CopyJobConfig struct {
+	Location  string `json:"location"`
+	ProjectID string `json:"projectId"`
+
+	// SourceTables: [Required] Source tables to copy.
+	SourceTables []*bigquery.TableReference `json:"sourceTables,omitempty"`
+
+	// DestinationTable: [Required] The destination table.
+	DestinationTable *bigquery.TableReference `json:"destinationTable,omitempty"`
+
+	// OperationType: [Optional] Supported operation types in table copy
+	// job: COPY, SNAPSHOT and RESTORE. The default value is COPY.
+	OperationType string `json:"operationType,omitempty"`
+
+	// CreateDisposition: [Optional] Specifies whether the job is allowed to
+	// create new tables. The following values are supported:
+	// CREATE_IF_NEEDED and CREATE_NEVER. The default value is
+	// CREATE_IF_NEEDED.
+	CreateDisposition string `json:"createDisposition,omitempty"`
+
+	// WriteDisposition: [Optional] Specifies the action that occurs if the
+	// destination table already exists. The following values are supported:
+	// WRITE_TRUNCATE, WRITE_APPEND and WRITE_EMPTY. The default value is
+	// WRITE_EMPTY.
+	WriteDisposition string `json:"writeDisposition,omitempty"`
+
+	// DestinationEncryptionConfiguration: Custom encryption configuration
+	// (e.g., Cloud KMS keys).
+	DestinationEncryptionConfiguration *bigquery.EncryptionConfiguration `json:"destinationEncryptionConfiguration,omitempty"`
+}
+
+func unmarshalCopyJobConfig(structObj *structpb.Struct) (*CopyJobConfig, error) {
+	copyJobConfig := CopyJobConfig{}
+	err := pluginUtils.UnmarshalStructToObj(structObj, &copyJobConfig)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal CopyJobConfig")
+	}
+
+	if len(copyJobConfig.SourceTables) == 0 || copyJobConfig.DestinationTable == nil {
+		return nil, errors.New("sourceTables and destinationTable are required")
+	}
+
+	return &copyJobConfig, nil
+}
+
+func getJobConfigurationTableCopy(custom *CopyJobConfig) *bigquery.JobConfigurationTableCopy {
+	return &bigquery.JobConfigurationTableCopy{
+		CreateDisposition:                  custom.CreateDisposition,
+		DestinationEncryptionConfiguration: custom.DestinationEncryptionConfiguration,
+		DestinationTable:                   custom.DestinationTable,
+		OperationType:                      custom.OperationType,
+		SourceTables:                       custom.SourceTables,
+		WriteDisposition:                   custom.WriteDisposition,
+	}
+}
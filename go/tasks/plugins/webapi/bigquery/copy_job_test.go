@@ -0,0 +1,39 @@
+package bigquery
+
+import (
+	"testing"
+
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestUnmarshalCopyJobConfig(t *testing.T) {
+	table := &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"}
+
+	t.Run("copy job config", func(t *testing.T) {
+		custom, _ := pluginUtils.MarshalObjToStruct(CopyJobConfig{
+			ProjectID:        "flyte",
+			SourceTables:     []*bigquery.TableReference{table},
+			DestinationTable: table,
+			OperationType:    "SNAPSHOT",
+		})
+
+		copyJobConfig, err := unmarshalCopyJobConfig(custom)
+
+		assert.NoError(t, err)
+
+		jobConfigurationTableCopy := getJobConfigurationTableCopy(copyJobConfig)
+		assert.Equal(t, []*bigquery.TableReference{table}, jobConfigurationTableCopy.SourceTables)
+		assert.Equal(t, table, jobConfigurationTableCopy.DestinationTable)
+		assert.Equal(t, "SNAPSHOT", jobConfigurationTableCopy.OperationType)
+	})
+
+	t.Run("source tables are required", func(t *testing.T) {
+		custom, _ := pluginUtils.MarshalObjToStruct(CopyJobConfig{ProjectID: "flyte", DestinationTable: table})
+
+		_, err := unmarshalCopyJobConfig(custom)
+
+		assert.Error(t, err)
+	})
+}
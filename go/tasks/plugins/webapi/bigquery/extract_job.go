@@ -0,0 +1,127 @@
+package bigquery
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flytestdlib/storage"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/api/bigquery/v2"
+)
+
+type ExtractJobConfig struct {
+	Location  string `json:"location"`
+	ProjectID string `json:"projectId"`
+
+	// SourceTable: [Required] A reference to the table being exported.
+	SourceTable *bigquery.TableReference `json:"sourceTable,omitempty"`
+
+	// DestinationFormat: [Optional] The exported file format. Possible
+	// values include CSV, NEWLINE_DELIMITED_JSON, PARQUET or AVRO for
+	// tables. The default value for tables is CSV.
+	DestinationFormat string `json:"destinationFormat,omitempty"`
+
+	// Compression: [Optional] The compression type to use for exported
+	// files. Possible values include GZIP, DEFLATE, SNAPPY, and NONE. The
+	// default value is NONE.
+	Compression string `json:"compression,omitempty"`
+
+	// FieldDelimiter: [Optional] Delimiter to use between fields in the
+	// exported data. Default is ','.
+	FieldDelimiter string `json:"fieldDelimiter,omitempty"`
+
+	// PrintHeader: [Optional] Whether to print out a header row in the
+	// results. Default is true.
+	PrintHeader *bool `json:"printHeader,omitempty"`
+
+	// UseAvroLogicalTypes: [Optional] If destinationFormat is set to "AVRO",
+	// this flag indicates whether to enable extracting applicable column
+	// types (such as TIMESTAMP) to their corresponding AVRO logical types.
+	UseAvroLogicalTypes bool `json:"useAvroLogicalTypes,omitempty"`
+}
+
+// extractDestination is the blob output of an extract job.
+type extractDestination struct {
+	outputName string
+	blob       *flyteIdlCore.Blob
+}
+
+func unmarshalExtractJobConfig(structObj *structpb.Struct) (*ExtractJobConfig, error) {
+	extractJobConfig := ExtractJobConfig{}
+	err := pluginUtils.UnmarshalStructToObj(structObj, &extractJobConfig)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal ExtractJobConfig")
+	}
+
+	if extractJobConfig.SourceTable == nil {
+		return nil, errors.New("sourceTable is required")
+	}
+
+	return &extractJobConfig, nil
+}
+
+// getExtractDestination maps the only output of the task, which must be a blob, to a location under the raw output
+// prefix. Multipart blobs are exported as many files as needed under that location.
+func getExtractDestination(outputs *flyteIdlCore.VariableMap, rawOutputPrefix storage.DataReference) (
+	*extractDestination, error) {
+
+	if len(outputs.GetVariables()) != 1 {
+		return nil, errors.Errorf("extract jobs must declare exactly one blob output, found [%v] outputs",
+			len(outputs.GetVariables()))
+	}
+
+	for name, variable := range outputs.GetVariables() {
+		blobType := variable.GetType().GetBlob()
+		if blobType == nil {
+			return nil, errors.Errorf("output [%v] must be a blob", name)
+		}
+
+		return &extractDestination{
+			outputName: name,
+			blob: &flyteIdlCore.Blob{
+				Uri:      strings.TrimSuffix(rawOutputPrefix.String(), "/") + "/" + name,
+				Metadata: &flyteIdlCore.BlobMetadata{Type: blobType},
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (d extractDestination) destinationURIs() []string {
+	if d.blob.GetMetadata().GetType().GetDimensionality() == flyteIdlCore.BlobType_MULTIPART {
+		return []string{getFilesPattern(d.blob.GetUri())}
+	}
+
+	return []string{d.blob.GetUri()}
+}
+
+func (d extractDestination) outputs() *flyteIdlCore.LiteralMap {
+	return &flyteIdlCore.LiteralMap{
+		Literals: map[string]*flyteIdlCore.Literal{
+			d.outputName: {
+				Value: &flyteIdlCore.Literal_Scalar{
+					Scalar: &flyteIdlCore.Scalar{
+						Value: &flyteIdlCore.Scalar_Blob{Blob: d.blob},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getJobConfigurationExtract(custom *ExtractJobConfig, destination *extractDestination) *bigquery.JobConfigurationExtract {
+	return &bigquery.JobConfigurationExtract{
+		Compression:         custom.Compression,
+		DestinationFormat:   custom.DestinationFormat,
+		DestinationUris:     destination.destinationURIs(),
+		FieldDelimiter:      custom.FieldDelimiter,
+		PrintHeader:         custom.PrintHeader,
+		SourceTable:         custom.SourceTable,
+		UseAvroLogicalTypes: custom.UseAvroLogicalTypes,
+	}
+}
@@ -0,0 +1,84 @@
+package bigquery
+
+import (
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func newBlobOutputs(name string, dimensionality flyteIdlCore.BlobType_BlobDimensionality) *flyteIdlCore.VariableMap {
+	return &flyteIdlCore.VariableMap{
+		Variables: map[string]*flyteIdlCore.Variable{
+			name: {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Blob{
+				Blob: &flyteIdlCore.BlobType{Format: "csv", Dimensionality: dimensionality}}}},
+		},
+	}
+}
+
+func TestUnmarshalExtractJobConfig(t *testing.T) {
+	t.Run("source table is required", func(t *testing.T) {
+		custom, _ := pluginUtils.MarshalObjToStruct(ExtractJobConfig{ProjectID: "flyte"})
+
+		_, err := unmarshalExtractJobConfig(custom)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetExtractDestination(t *testing.T) {
+	t.Run("single blob", func(t *testing.T) {
+		destination, err := getExtractDestination(newBlobOutputs("out", flyteIdlCore.BlobType_SINGLE), "gs://bucket/raw/")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gs://bucket/raw/out", destination.blob.Uri)
+		assert.Equal(t, "csv", destination.blob.Metadata.Type.Format)
+		assert.Equal(t, []string{"gs://bucket/raw/out"}, destination.destinationURIs())
+
+		outputs := destination.outputs()
+		assert.Equal(t, destination.blob, outputs.Literals["out"].GetScalar().GetBlob())
+	})
+
+	t.Run("multipart blob", func(t *testing.T) {
+		destination, err := getExtractDestination(newBlobOutputs("out", flyteIdlCore.BlobType_MULTIPART), "gs://bucket/raw")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gs://bucket/raw/out", destination.blob.Uri)
+		assert.Equal(t, []string{"gs://bucket/raw/out/*"}, destination.destinationURIs())
+	})
+
+	t.Run("no outputs", func(t *testing.T) {
+		_, err := getExtractDestination(&flyteIdlCore.VariableMap{}, "gs://bucket/raw")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not a blob", func(t *testing.T) {
+		outputs := &flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{
+				"out": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{
+					Simple: flyteIdlCore.SimpleType_STRING}}},
+			},
+		}
+
+		_, err := getExtractDestination(outputs, "gs://bucket/raw")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetJobConfigurationExtract(t *testing.T) {
+	sourceTable := &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"}
+	destination, _ := getExtractDestination(newBlobOutputs("out", flyteIdlCore.BlobType_MULTIPART), "gs://bucket/raw")
+
+	jobConfigurationExtract := getJobConfigurationExtract(&ExtractJobConfig{
+		SourceTable:       sourceTable,
+		DestinationFormat: "CSV",
+	}, destination)
+
+	assert.Equal(t, sourceTable, jobConfigurationExtract.SourceTable)
+	assert.Equal(t, "CSV", jobConfigurationExtract.DestinationFormat)
+	assert.Equal(t, []string{"gs://bucket/raw/out/*"}, jobConfigurationExtract.DestinationUris)
+}
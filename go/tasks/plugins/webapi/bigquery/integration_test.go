@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -55,17 +56,99 @@ func TestEndToEnd(t *testing.T) {
 		template := flyteIdlCore.TaskTemplate{
 			Type:   bigqueryQueryJobTask,
 			Custom: custom,
+			Interface: &flyteIdlCore.TypedInterface{
+				Outputs: &flyteIdlCore.VariableMap{
+					Variables: map[string]*flyteIdlCore.Variable{
+						"results": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Schema{
+							Schema: &flyteIdlCore.SchemaType{}}}},
+						"x": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{
+							Simple: flyteIdlCore.SimpleType_INTEGER}}},
+					},
+				},
+			},
 		}
 
-		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, nil, nil, iter)
+		expectedOutputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+		expectedOutputs.Literals["results"] = &flyteIdlCore.Literal{
+			Value: &flyteIdlCore.Literal_Scalar{
+				Scalar: &flyteIdlCore.Scalar{
+					Value: &flyteIdlCore.Scalar_Schema{
+						Schema: &flyteIdlCore.Schema{Uri: "bq://flyte:_anon.results", Type: &flyteIdlCore.SchemaType{}},
+					},
+				},
+			},
+		}
+
+		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, expectedOutputs, nil, iter)
+
+		assert.Equal(t, true, phase.Phase().IsSuccess())
+	})
+
+	t.Run("extract", func(t *testing.T) {
+		extractJobConfig := ExtractJobConfig{
+			ProjectID:   "flyte",
+			SourceTable: &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"},
+		}
+
+		custom, _ := pluginUtils.MarshalObjToStruct(extractJobConfig)
+		blobType := &flyteIdlCore.BlobType{Format: "csv", Dimensionality: flyteIdlCore.BlobType_MULTIPART}
+		template := flyteIdlCore.TaskTemplate{
+			Type:   bigqueryExtractJobTask,
+			Custom: custom,
+			Interface: &flyteIdlCore.TypedInterface{
+				Outputs: &flyteIdlCore.VariableMap{
+					Variables: map[string]*flyteIdlCore.Variable{
+						"table": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Blob{Blob: blobType}}},
+					},
+				},
+			},
+		}
+
+		expectedOutputs := &flyteIdlCore.LiteralMap{
+			Literals: map[string]*flyteIdlCore.Literal{
+				"table": {
+					Value: &flyteIdlCore.Literal_Scalar{
+						Scalar: &flyteIdlCore.Scalar{
+							Value: &flyteIdlCore.Scalar_Blob{
+								Blob: &flyteIdlCore.Blob{
+									Uri: "/sandbox/table",
+									Metadata: &flyteIdlCore.BlobMetadata{Type: &flyteIdlCore.BlobType{
+										Format:         "csv",
+										Dimensionality: flyteIdlCore.BlobType_MULTIPART,
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		phase := tests.RunPluginEndToEndTest(t, plugin, &template, &flyteIdlCore.LiteralMap{}, expectedOutputs, nil, iter)
 
 		assert.Equal(t, true, phase.Phase().IsSuccess())
 	})
 }
 
 func newFakeBigQueryServer() *httptest.Server {
+	var lock sync.Mutex
+	jobs := map[string]bool{}
+
 	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
 		if request.URL.Path == "/projects/flyte/jobs" && request.Method == "POST" {
+			created := bigquery.Job{}
+			_ = json.NewDecoder(request.Body).Decode(&created)
+			if jobs[created.JobReference.JobId] {
+				writer.WriteHeader(409)
+				_, _ = writer.Write([]byte(`{"error": {"code": 409, "message": "Already Exists"}}`))
+				return
+			}
+
+			jobs[created.JobReference.JobId] = true
+
 			writer.WriteHeader(200)
 			job := bigquery.Job{Status: &bigquery.JobStatus{State: "RUNNING"}}
 			bytes, _ := json.Marshal(job)
@@ -74,13 +157,42 @@ func newFakeBigQueryServer() *httptest.Server {
 		}
 
 		if strings.HasPrefix(request.URL.Path, "/projects/flyte/jobs/") && request.Method == "GET" {
+			if !jobs[strings.TrimPrefix(request.URL.Path, "/projects/flyte/jobs/")] {
+				writer.WriteHeader(404)
+				return
+			}
+
 			writer.WriteHeader(200)
-			job := bigquery.Job{Status: &bigquery.JobStatus{State: "DONE"}}
+			job := bigquery.Job{
+				Status: &bigquery.JobStatus{State: "DONE"},
+				Configuration: &bigquery.JobConfiguration{
+					Query: &bigquery.JobConfigurationQuery{
+						DestinationTable: &bigquery.TableReference{ProjectId: "flyte", DatasetId: "_anon", TableId: "results"},
+					},
+				},
+				Statistics: &bigquery.JobStatistics{
+					TotalBytesProcessed: 10,
+					Query:               &bigquery.JobStatistics2{TotalBytesBilled: 10, CacheHit: true},
+				},
+			}
 			bytes, _ := json.Marshal(job)
 			_, _ = writer.Write(bytes)
 			return
 		}
 
+		if strings.HasPrefix(request.URL.Path, "/projects/flyte/queries/") && request.Method == "GET" {
+			writer.WriteHeader(200)
+			results := bigquery.GetQueryResultsResponse{
+				JobComplete: true,
+				TotalRows:   1,
+				Schema:      &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{{Name: "x", Type: "INTEGER"}}},
+				Rows:        []*bigquery.TableRow{{F: []*bigquery.TableCell{{V: "1"}}}},
+			}
+			bytes, _ := json.Marshal(results)
+			_, _ = writer.Write(bytes)
+			return
+		}
+
 		writer.WriteHeader(500)
 	}))
 }
@@ -0,0 +1,202 @@
+package bigquery
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// Flyte schemas are stored as parquet files.
+const schemaSourceFormat = "PARQUET"
+
+type LoadJobConfig struct {
+	Location  string `json:"location"`
+	ProjectID string `json:"projectId"`
+
+	// SourceURIs: [Optional] Fully-qualified URIs that point to your data in
+	// Google Cloud Storage, in addition to the blob and schema inputs of the
+	// task. Each URI can contain one '*' wildcard character that must come
+	// after the bucket's name.
+	SourceURIs []string `json:"sourceUris,omitempty"`
+
+	// DestinationTable: [Required] The destination table to load the data
+	// into.
+	DestinationTable *bigquery.TableReference `json:"destinationTable,omitempty"`
+
+	// SourceFormat: [Optional] The format of the data files. For CSV files,
+	// specify "CSV". For datastore backups, specify "DATASTORE_BACKUP". For
+	// newline-delimited JSON, specify "NEWLINE_DELIMITED_JSON". For Avro,
+	// specify "AVRO". For parquet, specify "PARQUET". For orc, specify
+	// "ORC". The default value is CSV, or PARQUET if the task has schema
+	// inputs.
+	SourceFormat string `json:"sourceFormat,omitempty"`
+
+	// Autodetect: [Optional] Indicates if we should automatically infer the
+	// options and schema for CSV and JSON sources.
+	Autodetect bool `json:"autodetect,omitempty"`
+
+	// Schema: [Optional] The schema for the destination table. The schema
+	// can be omitted if the destination table already exists, or if you're
+	// loading data from Google Cloud Datastore.
+	Schema *bigquery.TableSchema `json:"schema,omitempty"`
+
+	// CreateDisposition: [Optional] Specifies whether the job is allowed to
+	// create new tables. The following values are supported:
+	// CREATE_IF_NEEDED: If the table does not exist, BigQuery creates the
+	// table. CREATE_NEVER: The table must already exist. The default value
+	// is CREATE_IF_NEEDED.
+	CreateDisposition string `json:"createDisposition,omitempty"`
+
+	// WriteDisposition: [Optional] Specifies the action that occurs if the
+	// destination table already exists. The following values are supported:
+	// WRITE_TRUNCATE, WRITE_APPEND and WRITE_EMPTY. The default value is
+	// WRITE_APPEND.
+	WriteDisposition string `json:"writeDisposition,omitempty"`
+
+	// SchemaUpdateOptions: Allows the schema of the destination table to be
+	// updated as a side effect of the load job, ALLOW_FIELD_ADDITION and
+	// ALLOW_FIELD_RELAXATION are supported.
+	SchemaUpdateOptions []string `json:"schemaUpdateOptions,omitempty"`
+
+	// SkipLeadingRows: [Optional] The number of rows at the top of a CSV
+	// file that BigQuery will skip when loading the data.
+	SkipLeadingRows int64 `json:"skipLeadingRows,omitempty"`
+
+	// FieldDelimiter: [Optional] The separator for fields in a CSV file.
+	// The default value is a comma (',').
+	FieldDelimiter string `json:"fieldDelimiter,omitempty"`
+
+	// AllowJaggedRows: [Optional] Accept rows that are missing trailing
+	// optional columns in CSV files.
+	AllowJaggedRows bool `json:"allowJaggedRows,omitempty"`
+
+	// AllowQuotedNewlines: Indicates if BigQuery should allow quoted data
+	// sections that contain newline characters in a CSV file.
+	AllowQuotedNewlines bool `json:"allowQuotedNewlines,omitempty"`
+
+	// IgnoreUnknownValues: [Optional] Indicates if BigQuery should allow
+	// extra values that are not represented in the table schema.
+	IgnoreUnknownValues bool `json:"ignoreUnknownValues,omitempty"`
+
+	// MaxBadRecords: [Optional] The maximum number of bad records that
+	// BigQuery can ignore when running the job.
+	MaxBadRecords int64 `json:"maxBadRecords,omitempty"`
+
+	// UseAvroLogicalTypes: [Optional] If sourceFormat is set to "AVRO",
+	// indicates whether to enable interpreting logical types into their
+	// corresponding types.
+	UseAvroLogicalTypes bool `json:"useAvroLogicalTypes,omitempty"`
+
+	// Clustering: [Beta] Clustering specification for the destination
+	// table.
+	Clustering *bigquery.Clustering `json:"clustering,omitempty"`
+
+	// TimePartitioning: Time-based partitioning specification for the
+	// destination table.
+	TimePartitioning *bigquery.TimePartitioning `json:"timePartitioning,omitempty"`
+
+	// DestinationEncryptionConfiguration: Custom encryption configuration
+	// (e.g., Cloud KMS keys).
+	DestinationEncryptionConfiguration *bigquery.EncryptionConfiguration `json:"destinationEncryptionConfiguration,omitempty"`
+}
+
+func unmarshalLoadJobConfig(structObj *structpb.Struct) (*LoadJobConfig, error) {
+	loadJobConfig := LoadJobConfig{}
+	err := pluginUtils.UnmarshalStructToObj(structObj, &loadJobConfig)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal LoadJobConfig")
+	}
+
+	if loadJobConfig.DestinationTable == nil {
+		return nil, errors.New("destinationTable is required")
+	}
+
+	return &loadJobConfig, nil
+}
+
+func getJobConfigurationLoad(custom *LoadJobConfig, inputs *flyteIdlCore.LiteralMap) (*bigquery.JobConfigurationLoad, error) {
+	sourceURIs, hasSchemaInputs, err := getSourceURIs(inputs.GetLiterals())
+
+	if err != nil {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "unable to build source uris [%v]", err.Error())
+	}
+
+	sourceURIs = append(append([]string{}, custom.SourceURIs...), sourceURIs...)
+	if len(sourceURIs) == 0 {
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "no source uris to load data from")
+	}
+
+	sourceFormat := custom.SourceFormat
+	if len(sourceFormat) == 0 && hasSchemaInputs {
+		sourceFormat = schemaSourceFormat
+	}
+
+	return &bigquery.JobConfigurationLoad{
+		AllowJaggedRows:                    custom.AllowJaggedRows,
+		AllowQuotedNewlines:                custom.AllowQuotedNewlines,
+		Autodetect:                         custom.Autodetect,
+		Clustering:                         custom.Clustering,
+		CreateDisposition:                  custom.CreateDisposition,
+		DestinationEncryptionConfiguration: custom.DestinationEncryptionConfiguration,
+		DestinationTable:                   custom.DestinationTable,
+		FieldDelimiter:                     custom.FieldDelimiter,
+		IgnoreUnknownValues:                custom.IgnoreUnknownValues,
+		MaxBadRecords:                      custom.MaxBadRecords,
+		Schema:                             custom.Schema,
+		SchemaUpdateOptions:                custom.SchemaUpdateOptions,
+		SkipLeadingRows:                    custom.SkipLeadingRows,
+		SourceFormat:                       sourceFormat,
+		SourceUris:                         sourceURIs,
+		TimePartitioning:                   custom.TimePartitioning,
+		UseAvroLogicalTypes:                custom.UseAvroLogicalTypes,
+		WriteDisposition:                   custom.WriteDisposition,
+	}, nil
+}
+
+// getSourceURIs maps blob and schema inputs, in the order of their names, to the URIs of the files they hold.
+func getSourceURIs(literalMap map[string]*flyteIdlCore.Literal) (sourceURIs []string, hasSchemaInputs bool, err error) {
+	names := make([]string, 0, len(literalMap))
+	for name := range literalMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	sourceURIs = make([]string, 0, len(names))
+	for _, name := range names {
+		scalar := literalMap[name].GetScalar()
+
+		if blob := scalar.GetBlob(); blob != nil {
+			if blob.GetMetadata().GetType().GetDimensionality() == flyteIdlCore.BlobType_MULTIPART {
+				sourceURIs = append(sourceURIs, getFilesPattern(blob.GetUri()))
+			} else {
+				sourceURIs = append(sourceURIs, blob.GetUri())
+			}
+
+			continue
+		}
+
+		if schema := scalar.GetSchema(); schema != nil {
+			sourceURIs = append(sourceURIs, getFilesPattern(schema.GetUri()))
+			hasSchemaInputs = true
+			continue
+		}
+
+		return nil, false, errors.Errorf("unsupported input [%v], only blobs and schemas can be loaded", name)
+	}
+
+	return sourceURIs, hasSchemaInputs, nil
+}
+
+// getFilesPattern returns a wildcard URI matching all files under a directory.
+func getFilesPattern(uri string) string {
+	return strings.TrimSuffix(uri, "/") + "/*"
+}
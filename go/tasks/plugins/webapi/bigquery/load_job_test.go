@@ -0,0 +1,101 @@
+package bigquery
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func newBlobLiteral(uri string, dimensionality flyteIdlCore.BlobType_BlobDimensionality) *flyteIdlCore.Literal {
+	return &flyteIdlCore.Literal{
+		Value: &flyteIdlCore.Literal_Scalar{
+			Scalar: &flyteIdlCore.Scalar{
+				Value: &flyteIdlCore.Scalar_Blob{
+					Blob: &flyteIdlCore.Blob{
+						Uri:      uri,
+						Metadata: &flyteIdlCore.BlobMetadata{Type: &flyteIdlCore.BlobType{Dimensionality: dimensionality}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestUnmarshalLoadJobConfig(t *testing.T) {
+	t.Run("destination table is required", func(t *testing.T) {
+		custom, _ := pluginUtils.MarshalObjToStruct(LoadJobConfig{ProjectID: "flyte"})
+
+		_, err := unmarshalLoadJobConfig(custom)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetJobConfigurationLoad(t *testing.T) {
+	destinationTable := &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"}
+
+	t.Run("blob and schema inputs", func(t *testing.T) {
+		config := LoadJobConfig{
+			SourceURIs:       []string{"gs://bucket/extra.parquet"},
+			DestinationTable: destinationTable,
+		}
+
+		inputs := &flyteIdlCore.LiteralMap{Literals: map[string]*flyteIdlCore.Literal{
+			"a": newBlobLiteral("gs://bucket/a.parquet", flyteIdlCore.BlobType_SINGLE),
+			"b": newBlobLiteral("gs://bucket/b/", flyteIdlCore.BlobType_MULTIPART),
+			"c": {
+				Value: &flyteIdlCore.Literal_Scalar{
+					Scalar: &flyteIdlCore.Scalar{
+						Value: &flyteIdlCore.Scalar_Schema{Schema: &flyteIdlCore.Schema{Uri: "gs://bucket/c"}},
+					},
+				},
+			},
+		}}
+
+		jobConfigurationLoad, err := getJobConfigurationLoad(&config, inputs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"gs://bucket/extra.parquet",
+			"gs://bucket/a.parquet",
+			"gs://bucket/b/*",
+			"gs://bucket/c/*",
+		}, jobConfigurationLoad.SourceUris)
+		assert.Equal(t, "PARQUET", jobConfigurationLoad.SourceFormat)
+		assert.Equal(t, destinationTable, jobConfigurationLoad.DestinationTable)
+	})
+
+	t.Run("explicit source format", func(t *testing.T) {
+		config := LoadJobConfig{DestinationTable: destinationTable, SourceFormat: "CSV"}
+		inputs := &flyteIdlCore.LiteralMap{Literals: map[string]*flyteIdlCore.Literal{
+			"a": newBlobLiteral("gs://bucket/a.csv", flyteIdlCore.BlobType_SINGLE),
+		}}
+
+		jobConfigurationLoad, err := getJobConfigurationLoad(&config, inputs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "CSV", jobConfigurationLoad.SourceFormat)
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		config := LoadJobConfig{DestinationTable: destinationTable}
+		inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+
+		_, err := getJobConfigurationLoad(&config, inputs)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("no source uris", func(t *testing.T) {
+		config := LoadJobConfig{DestinationTable: destinationTable}
+
+		_, err := getJobConfigurationLoad(&config, &flyteIdlCore.LiteralMap{})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,158 @@
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/api/bigquery/v2"
+)
+
+// JobStatistics keeps the statistics of a job worth reporting, out of the much larger ones returned by BigQuery.
+type JobStatistics struct {
+	TotalBytesProcessed int64
+	TotalBytesBilled    int64
+	TotalSlotMs         int64
+	CacheHit            bool
+	StatementType       string
+	NumDmlAffectedRows  int64
+	DestinationTable    *bigquery.TableReference
+}
+
+func getJobStatistics(job *bigquery.Job) *JobStatistics {
+	statistics := &JobStatistics{}
+
+	if job.Statistics != nil {
+		statistics.TotalBytesProcessed = job.Statistics.TotalBytesProcessed
+		statistics.TotalSlotMs = job.Statistics.TotalSlotMs
+
+		if query := job.Statistics.Query; query != nil {
+			statistics.TotalBytesBilled = query.TotalBytesBilled
+			statistics.CacheHit = query.CacheHit
+			statistics.StatementType = query.StatementType
+			statistics.NumDmlAffectedRows = query.NumDmlAffectedRows
+		}
+	}
+
+	// BigQuery sets the destination table of queries without one to a temporary table.
+	if configuration := job.Configuration; configuration != nil {
+		switch {
+		case configuration.Query != nil:
+			statistics.DestinationTable = configuration.Query.DestinationTable
+		case configuration.Load != nil:
+			statistics.DestinationTable = configuration.Load.DestinationTable
+		case configuration.Copy != nil:
+			statistics.DestinationTable = configuration.Copy.DestinationTable
+		}
+	}
+
+	return statistics
+}
+
+func getCustomInfo(statistics *JobStatistics) (*structpb.Struct, error) {
+	customInfo := map[string]interface{}{
+		"totalBytesProcessed": statistics.TotalBytesProcessed,
+		"totalBytesBilled":    statistics.TotalBytesBilled,
+		"totalSlotMs":         statistics.TotalSlotMs,
+		"cacheHit":            statistics.CacheHit,
+	}
+
+	if len(statistics.StatementType) > 0 {
+		customInfo["statementType"] = statistics.StatementType
+		customInfo["numDmlAffectedRows"] = statistics.NumDmlAffectedRows
+	}
+
+	if statistics.DestinationTable != nil {
+		customInfo["destinationTable"] = formatTableReference(*statistics.DestinationTable)
+	}
+
+	return pluginUtils.MarshalObjToStruct(customInfo)
+}
+
+// getTableOutputs builds the outputs declared by the task. Schema outputs point to the destination table. Other
+// outputs are read from the first row of the result set, using readFirstRow, from the column with the same name.
+func getTableOutputs(outputs *flyteIdlCore.VariableMap, destinationTable *bigquery.TableReference,
+	readFirstRow func() (map[string]string, error)) (*flyteIdlCore.LiteralMap, error) {
+
+	literals := make(map[string]*flyteIdlCore.Literal, len(outputs.GetVariables()))
+	var firstRow map[string]string
+
+	for name, variable := range outputs.GetVariables() {
+		if schemaType := variable.GetType().GetSchema(); schemaType != nil {
+			if destinationTable == nil {
+				return nil, errors.Errorf("job has no destination table for output [%v]", name)
+			}
+
+			literals[name] = &flyteIdlCore.Literal{
+				Value: &flyteIdlCore.Literal_Scalar{
+					Scalar: &flyteIdlCore.Scalar{
+						Value: &flyteIdlCore.Scalar_Schema{
+							Schema: &flyteIdlCore.Schema{Uri: formatTableURI(*destinationTable), Type: schemaType},
+						},
+					},
+				},
+			}
+
+			continue
+		}
+
+		if firstRow == nil {
+			var err error
+			if firstRow, err = readFirstRow(); err != nil {
+				return nil, err
+			}
+		}
+
+		value, found := firstRow[strings.ToLower(name)]
+		if !found {
+			return nil, errors.Errorf("result set has no column for output [%v]", name)
+		}
+
+		literal, err := pluginUtils.ParseLiteral(variable.GetType(), value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert column [%v]", name)
+		}
+
+		literals[name] = literal
+	}
+
+	return &flyteIdlCore.LiteralMap{Literals: literals}, nil
+}
+
+// getFirstRow maps the lower-cased names of the non-null columns of the first row of the result set to their values.
+func getFirstRow(results *bigquery.GetQueryResultsResponse, maxRows int64) (map[string]string, error) {
+	if results.TotalRows > uint64(maxRows) {
+		return nil, errors.Errorf("result set has [%v] rows, more than the [%v] rows that can be read into outputs",
+			results.TotalRows, maxRows)
+	}
+
+	if len(results.Rows) == 0 || results.Schema == nil {
+		return nil, errors.New("result set has no rows to read outputs from")
+	}
+
+	firstRow := make(map[string]string, len(results.Schema.Fields))
+	for i, field := range results.Schema.Fields {
+		if i >= len(results.Rows[0].F) {
+			break
+		}
+
+		// Scalar values are returned as strings, null ones as nil.
+		if value, ok := results.Rows[0].F[i].V.(string); ok {
+			firstRow[strings.ToLower(field.Name)] = value
+		}
+	}
+
+	return firstRow, nil
+}
+
+func formatTableReference(reference bigquery.TableReference) string {
+	return fmt.Sprintf("%s:%s.%s", reference.ProjectId, reference.DatasetId, reference.TableId)
+}
+
+func formatTableURI(reference bigquery.TableReference) string {
+	return "bq://" + formatTableReference(reference)
+}
@@ -0,0 +1,147 @@
+package bigquery
+
+import (
+	"errors"
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/bigquery/v2"
+)
+
+func TestGetJobStatistics(t *testing.T) {
+	destinationTable := &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"}
+
+	t.Run("query job", func(t *testing.T) {
+		statistics := getJobStatistics(&bigquery.Job{
+			Configuration: &bigquery.JobConfiguration{
+				Query: &bigquery.JobConfigurationQuery{DestinationTable: destinationTable},
+			},
+			Statistics: &bigquery.JobStatistics{
+				TotalBytesProcessed: 100,
+				TotalSlotMs:         20,
+				Query: &bigquery.JobStatistics2{
+					TotalBytesBilled: 200,
+					CacheHit:         true,
+					StatementType:    "SELECT",
+				},
+			},
+		})
+
+		assert.Equal(t, JobStatistics{
+			TotalBytesProcessed: 100,
+			TotalBytesBilled:    200,
+			TotalSlotMs:         20,
+			CacheHit:            true,
+			StatementType:       "SELECT",
+			DestinationTable:    destinationTable,
+		}, *statistics)
+	})
+
+	t.Run("load job", func(t *testing.T) {
+		statistics := getJobStatistics(&bigquery.Job{
+			Configuration: &bigquery.JobConfiguration{
+				Load: &bigquery.JobConfigurationLoad{DestinationTable: destinationTable},
+			},
+		})
+
+		assert.Equal(t, destinationTable, statistics.DestinationTable)
+	})
+}
+
+func TestGetCustomInfo(t *testing.T) {
+	customInfo, err := getCustomInfo(&JobStatistics{
+		TotalBytesProcessed: 100,
+		TotalBytesBilled:    200,
+		TotalSlotMs:         20,
+		CacheHit:            true,
+		DestinationTable:    &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), customInfo.Fields["totalBytesProcessed"].GetNumberValue())
+	assert.Equal(t, float64(200), customInfo.Fields["totalBytesBilled"].GetNumberValue())
+	assert.Equal(t, float64(20), customInfo.Fields["totalSlotMs"].GetNumberValue())
+	assert.Equal(t, true, customInfo.Fields["cacheHit"].GetBoolValue())
+	assert.Equal(t, "flyte:dataset.table", customInfo.Fields["destinationTable"].GetStringValue())
+	assert.NotContains(t, customInfo.Fields, "statementType")
+}
+
+func TestGetTableOutputs(t *testing.T) {
+	destinationTable := &bigquery.TableReference{ProjectId: "flyte", DatasetId: "dataset", TableId: "table"}
+	outputs := &flyteIdlCore.VariableMap{
+		Variables: map[string]*flyteIdlCore.Variable{
+			"table": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Schema{
+				Schema: &flyteIdlCore.SchemaType{}}}},
+			"Count": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{
+				Simple: flyteIdlCore.SimpleType_INTEGER}}},
+		},
+	}
+
+	t.Run("schema and primitive outputs", func(t *testing.T) {
+		reads := 0
+		literals, err := getTableOutputs(outputs, destinationTable, func() (map[string]string, error) {
+			reads++
+			return map[string]string{"count": "42"}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reads)
+		assert.Equal(t, "bq://flyte:dataset.table", literals.Literals["table"].GetScalar().GetSchema().Uri)
+		assert.Equal(t, int64(42), literals.Literals["Count"].GetScalar().GetPrimitive().GetInteger())
+	})
+
+	t.Run("no destination table", func(t *testing.T) {
+		_, err := getTableOutputs(outputs, nil, func() (map[string]string, error) {
+			return map[string]string{"count": "42"}, nil
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := getTableOutputs(outputs, destinationTable, func() (map[string]string, error) {
+			return map[string]string{}, nil
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		_, err := getTableOutputs(outputs, destinationTable, func() (map[string]string, error) {
+			return nil, errors.New("oops")
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetFirstRow(t *testing.T) {
+	results := &bigquery.GetQueryResultsResponse{
+		TotalRows: 2,
+		Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+			{Name: "X", Type: "INTEGER"},
+			{Name: "y", Type: "STRING"},
+		}},
+		Rows: []*bigquery.TableRow{{F: []*bigquery.TableCell{{V: "1"}, {V: nil}}}},
+	}
+
+	t.Run("first row", func(t *testing.T) {
+		firstRow, err := getFirstRow(results, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"x": "1"}, firstRow)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		_, err := getFirstRow(results, 1)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		_, err := getFirstRow(&bigquery.GetQueryResultsResponse{}, 1)
+
+		assert.Error(t, err)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 
+	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/google"
@@ -19,6 +20,8 @@ import (
 	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flytestdlib/storage"
 	"google.golang.org/api/option"
 
 	"github.com/flyteorg/flytestdlib/logger"
@@ -31,8 +34,12 @@ import (
 )
 
 const (
-	bigqueryQueryJobTask = "bigquery_query_job_task"
-	bigqueryConsolePath  = "https://console.cloud.google.com/bigquery"
+	// Query jobs also run scripts, i.e. multiple statements.
+	bigqueryQueryJobTask   = "bigquery_query_job_task"
+	bigqueryLoadJobTask    = "bigquery_load_job_task"
+	bigqueryExtractJobTask = "bigquery_extract_job_task"
+	bigqueryCopyJobTask    = "bigquery_copy_job_task"
+	bigqueryConsolePath    = "https://console.cloud.google.com/bigquery"
 )
 
 type Plugin struct {
@@ -43,6 +50,7 @@ type Plugin struct {
 
 type ResourceWrapper struct {
 	Status      *bigquery.JobStatus
+	Statistics  *JobStatistics
 	CreateError *googleapi.Error
 }
 
@@ -95,9 +103,17 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get bigquery client")
 	}
 
-	if taskTemplate.Type == bigqueryQueryJobTask {
+	switch taskTemplate.Type {
+	case bigqueryQueryJobTask:
 		job, err = createQueryJob(jobID, taskTemplate.GetCustom(), inputs)
-	} else {
+	case bigqueryLoadJobTask:
+		job, err = createLoadJob(jobID, taskTemplate.GetCustom(), inputs)
+	case bigqueryExtractJobTask:
+		job, err = createExtractJob(jobID, taskTemplate.GetCustom(), taskTemplate.GetInterface().GetOutputs(),
+			taskCtx.OutputWriter().GetRawOutputPrefix())
+	case bigqueryCopyJobTask:
+		job, err = createCopyJob(jobID, taskTemplate.GetCustom())
+	default:
 		err = pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "unexpected task type [%v]", taskTemplate.Type)
 	}
 
@@ -117,6 +133,8 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 			K8sServiceAccount: k8sServiceAccount,
 		}
 
+		// Job ids are derived from the task execution, a conflict means the job was created by a previous attempt
+		// whose state wasn't persisted (e.g. propeller restarted right after creating it). Adopt it.
 		if ok && apiError.Code == 409 {
 			job, err := client.Jobs.Get(resourceMeta.JobReference.ProjectId, resourceMeta.JobReference.JobId).Do()
 
@@ -130,7 +148,7 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 				return nil, nil, err
 			}
 
-			resource := ResourceWrapper{Status: job.Status}
+			resource := ResourceWrapper{Status: job.Status, Statistics: getJobStatistics(job)}
 
 			return &resourceMeta, &resource, nil
 		}
@@ -141,10 +159,10 @@ func (p Plugin) createImpl(ctx context.Context, taskCtx webapi.TaskExecutionCont
 			return &resourceMeta, &resource, nil
 		}
 
-		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "failed to create query job")
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "failed to create job")
 	}
 
-	resource := ResourceWrapper{Status: resp.Status}
+	resource := ResourceWrapper{Status: resp.Status, Statistics: getJobStatistics(resp)}
 	resourceMeta := ResourceMetaWrapper{
 		JobReference:      *job.JobReference,
 		Namespace:         namespace,
@@ -181,6 +199,77 @@ func createQueryJob(jobID string, custom *structpb.Struct, inputs *flyteIdlCore.
 	}, nil
 }
 
+func createLoadJob(jobID string, custom *structpb.Struct, inputs *flyteIdlCore.LiteralMap) (*bigquery.Job, error) {
+	loadJobConfig, err := unmarshalLoadJobConfig(custom)
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "can't unmarshall struct to LoadJobConfig")
+	}
+
+	jobConfigurationLoad, err := getJobConfigurationLoad(loadJobConfig, inputs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &bigquery.Job{
+		Configuration: &bigquery.JobConfiguration{
+			Load: jobConfigurationLoad,
+		},
+		JobReference: &bigquery.JobReference{
+			JobId:     jobID,
+			Location:  loadJobConfig.Location,
+			ProjectId: loadJobConfig.ProjectID,
+		},
+	}, nil
+}
+
+func createExtractJob(jobID string, custom *structpb.Struct, outputs *flyteIdlCore.VariableMap,
+	rawOutputPrefix storage.DataReference) (*bigquery.Job, error) {
+
+	extractJobConfig, err := unmarshalExtractJobConfig(custom)
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "can't unmarshall struct to ExtractJobConfig")
+	}
+
+	destination, err := getExtractDestination(outputs, rawOutputPrefix)
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "unable to build extract destination")
+	}
+
+	return &bigquery.Job{
+		Configuration: &bigquery.JobConfiguration{
+			Extract: getJobConfigurationExtract(extractJobConfig, destination),
+		},
+		JobReference: &bigquery.JobReference{
+			JobId:     jobID,
+			Location:  extractJobConfig.Location,
+			ProjectId: extractJobConfig.ProjectID,
+		},
+	}, nil
+}
+
+func createCopyJob(jobID string, custom *structpb.Struct) (*bigquery.Job, error) {
+	copyJobConfig, err := unmarshalCopyJobConfig(custom)
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "can't unmarshall struct to CopyJobConfig")
+	}
+
+	return &bigquery.Job{
+		Configuration: &bigquery.JobConfiguration{
+			Copy: getJobConfigurationTableCopy(copyJobConfig),
+		},
+		JobReference: &bigquery.JobReference{
+			JobId:     jobID,
+			Location:  copyJobConfig.Location,
+			ProjectId: copyJobConfig.ProjectID,
+		},
+	}, nil
+}
+
 func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
 	return p.getImpl(ctx, taskCtx)
 }
@@ -211,7 +300,8 @@ func (p Plugin) getImpl(ctx context.Context, taskCtx webapi.GetContext) (wrapper
 	}
 
 	return &ResourceWrapper{
-		Status: job.Status,
+		Status:     job.Status,
+		Statistics: getJobStatistics(job),
 	}, nil
 }
 
@@ -243,7 +333,7 @@ func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error
 	return nil
 }
 
-func (p Plugin) Status(_ context.Context, tCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
 	resourceMeta := tCtx.ResourceMeta().(*ResourceMetaWrapper)
 	resource := tCtx.Resource().(*ResourceWrapper)
 	version := pluginsCore.DefaultPhaseVersion
@@ -254,6 +344,13 @@ func (p Plugin) Status(_ context.Context, tCtx webapi.StatusContext) (phase core
 
 	taskInfo := createTaskInfo(resourceMeta)
 
+	if resource.Statistics != nil {
+		if taskInfo.CustomInfo, err = getCustomInfo(resource.Statistics); err != nil {
+			return core.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err,
+				"unable to create a custom info object")
+		}
+	}
+
 	if resource.CreateError != nil {
 		return handleCreateError(resource.CreateError, taskInfo), nil
 	}
@@ -273,12 +370,113 @@ func (p Plugin) Status(_ context.Context, tCtx webapi.StatusContext) (phase core
 				taskInfo), nil
 		}
 
-		return pluginsCore.PhaseInfoSuccess(taskInfo), nil
+		return p.writeOutputs(ctx, tCtx, resourceMeta, resource, taskInfo)
 	}
 
 	return core.PhaseInfoUndefined, pluginErrors.Errorf(pluginsCore.SystemErrorCode, "unknown execution phase [%v].", resource.Status.State)
 }
 
+// writeOutputs writes the outputs declared by the task. Extract jobs produce the blob the table is exported to, other
+// jobs the destination table and optionally values read from the result set.
+func (p Plugin) writeOutputs(ctx context.Context, tCtx webapi.StatusContext, resourceMeta *ResourceMetaWrapper,
+	resource *ResourceWrapper, taskInfo *core.TaskInfo) (core.PhaseInfo, error) {
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	outputs := taskTemplate.GetInterface().GetOutputs()
+	if len(outputs.GetVariables()) == 0 {
+		return pluginsCore.PhaseInfoSuccess(taskInfo), nil
+	}
+
+	var literals *flyteIdlCore.LiteralMap
+
+	if taskTemplate.Type == bigqueryExtractJobTask {
+		destination, err := getExtractDestination(outputs, tCtx.OutputWriter().GetRawOutputPrefix())
+
+		if err != nil {
+			return core.PhaseInfoFailure(pluginErrors.BadTaskSpecification, err.Error(), taskInfo), nil
+		}
+
+		literals = destination.outputs()
+	} else {
+		var destinationTable *bigquery.TableReference
+		if resource.Statistics != nil {
+			destinationTable = resource.Statistics.DestinationTable
+		}
+
+		// Reading the result set fails because of the API rather than the task, it's retried rather than failing the
+		// task.
+		var readErr error
+		literals, err = getTableOutputs(outputs, destinationTable, func() (map[string]string, error) {
+			if taskTemplate.Type != bigqueryQueryJobTask {
+				return nil, errors.New("only query jobs have a result set to read outputs from")
+			}
+
+			results, err := p.getQueryResults(ctx, resourceMeta)
+			if err != nil {
+				readErr = err
+				return nil, err
+			}
+
+			return getFirstRow(results, p.cfg.MaxResultRows)
+		})
+
+		if readErr != nil {
+			return core.PhaseInfoUndefined, readErr
+		}
+
+		if err != nil {
+			return core.PhaseInfoFailure(pluginErrors.BadTaskSpecification, err.Error(), taskInfo), nil
+		}
+	}
+
+	err = tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(literals, nil))
+
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	return pluginsCore.PhaseInfoSuccess(taskInfo), nil
+}
+
+// getQueryResults retrieves the first row of the result set of a query job, along with its total number of rows.
+func (p Plugin) getQueryResults(ctx context.Context, resourceMeta *ResourceMetaWrapper) (
+	*bigquery.GetQueryResultsResponse, error) {
+
+	identity := google.Identity{
+		K8sNamespace:      resourceMeta.Namespace,
+		K8sServiceAccount: resourceMeta.K8sServiceAccount,
+	}
+	client, err := p.newBigQueryClient(ctx, identity)
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get client")
+	}
+
+	call := client.Jobs.GetQueryResults(resourceMeta.JobReference.ProjectId, resourceMeta.JobReference.JobId).
+		MaxResults(1)
+
+	if len(resourceMeta.JobReference.Location) > 0 {
+		call = call.Location(resourceMeta.JobReference.Location)
+	}
+
+	results, err := call.Do()
+
+	if err != nil {
+		return nil, pluginErrors.Wrapf(
+			pluginErrors.RuntimeFailure,
+			err,
+			"failed to get query results of job [%s]",
+			formatJobReference(resourceMeta.JobReference))
+	}
+
+	return results, nil
+}
+
 func handleCreateError(createError *googleapi.Error, taskInfo *core.TaskInfo) core.PhaseInfo {
 	code := fmt.Sprintf("http%d", createError.Code)
 
@@ -486,7 +684,7 @@ func NewPlugin(cfg *Config, metricScope promutils.Scope) (*Plugin, error) {
 func newBigQueryJobTaskPlugin() webapi.PluginEntry {
 	return webapi.PluginEntry{
 		ID:                 "bigquery",
-		SupportedTaskTypes: []core.TaskType{bigqueryQueryJobTask},
+		SupportedTaskTypes: []core.TaskType{bigqueryQueryJobTask, bigqueryLoadJobTask, bigqueryExtractJobTask, bigqueryCopyJobTask},
 		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
 			cfg := GetConfig()
 
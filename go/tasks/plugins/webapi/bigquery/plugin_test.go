@@ -1,13 +1,20 @@
 package bigquery
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/google"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/googleapi"
 )
@@ -204,3 +211,49 @@ func TestHandleErrorResult(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate_AlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeBigQueryServer()
+	defer server.Close()
+
+	cfg := defaultConfig
+	cfg.bigQueryEndpoint = server.URL
+	p := Plugin{cfg: &cfg}
+
+	custom, _ := pluginUtils.MarshalObjToStruct(QueryJobConfig{ProjectID: "flyte", Query: "SELECT 1"})
+	taskReader := &coreMocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(&flyteIdlCore.TaskTemplate{
+		Type:   bigqueryQueryJobTask,
+		Custom: custom,
+	}, nil)
+
+	inputReader := &ioMocks.InputReader{}
+	inputReader.OnGetMatch(mock.Anything).Return(&flyteIdlCore.LiteralMap{}, nil)
+
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("my-job-id")
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnGetNamespace().Return("ns")
+	tMeta.OnGetSecurityContext().Return(flyteIdlCore.SecurityContext{})
+	tMeta.OnGetK8sServiceAccount().Return("sa")
+	tMeta.OnGetLabels().Return(map[string]string{})
+
+	taskCtx := &mocks.TaskExecutionContextReader{}
+	taskCtx.OnTaskReader().Return(taskReader)
+	taskCtx.OnInputReader().Return(inputReader)
+	taskCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+	client, err := p.newBigQueryClient(ctx, google.Identity{})
+	assert.NoError(t, err)
+	_, err = client.Jobs.Insert("flyte", &bigquery.Job{JobReference: &bigquery.JobReference{JobId: "my-job-id"}}).Do()
+	assert.NoError(t, err)
+
+	resourceMeta, resource, err := p.Create(ctx, taskCtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "my-job-id", resourceMeta.(*ResourceMetaWrapper).JobReference.JobId)
+	assert.Equal(t, "sa", resourceMeta.(*ResourceMetaWrapper).K8sServiceAccount)
+	assert.Equal(t, "DONE", resource.(*ResourceWrapper).Status.State)
+}
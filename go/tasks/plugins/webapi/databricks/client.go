@@ -0,0 +1,103 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+const (
+	submitPath = "/api/2.0/jobs/runs/submit"
+	getPath    = "/api/2.0/jobs/runs/get"
+	cancelPath = "/api/2.0/jobs/runs/cancel"
+)
+
+type runState struct {
+	LifeCycleState string `json:"life_cycle_state"`
+	ResultState    string `json:"result_state,omitempty"`
+	StateMessage   string `json:"state_message,omitempty"`
+}
+
+type run struct {
+	RunID      int64    `json:"run_id"`
+	RunPageURL string   `json:"run_page_url,omitempty"`
+	State      runState `json:"state"`
+}
+
+type errorResponse struct {
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// client calls the Jobs API of a Databricks workspace, authenticating with a token.
+type client struct {
+	httpClient *http.Client
+	endpoint   string
+	token      string
+}
+
+func (c client) submit(ctx context.Context, request *runSubmitRequest) (runID int64, err error) {
+	resp := run{}
+	if err := c.do(ctx, http.MethodPost, submitPath, request, &resp); err != nil {
+		return 0, err
+	}
+
+	return resp.RunID, nil
+}
+
+func (c client) get(ctx context.Context, runID int64) (run, error) {
+	resp := run{}
+	err := c.do(ctx, http.MethodGet, getPath+"?run_id="+strconv.FormatInt(runID, 10), nil, &resp)
+	return resp, err
+}
+
+func (c client) cancel(ctx context.Context, runID int64) error {
+	return c.do(ctx, http.MethodPost, cancelPath, run{RunID: runID}, nil)
+}
+
+func (c client) do(ctx context.Context, method, path string, request, response interface{}) error {
+	var body []byte
+	if request != nil {
+		var err error
+		if body, err = json.Marshal(request); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "flytepropeller")
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer httpResp.Body.Close()
+	raw, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		errResp := errorResponse{}
+		_ = json.Unmarshal(raw, &errResp)
+		return fmt.Errorf("databricks returned status [%v], code [%v]: %v", httpResp.StatusCode, errResp.ErrorCode,
+			errResp.Message)
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, response)
+}
@@ -0,0 +1,94 @@
+// Package databricks implements WebAPI plugin for running Spark jobs on Databricks
+package databricks
+
+import (
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"default": 1000,
+			},
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+			},
+			ResourceMeta: nil,
+		},
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+		TokenKey: "FLYTE_DATABRICKS_API_TOKEN",
+		DefaultCluster: ClusterConfig{
+			NumWorkers: 1,
+		},
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("databricks", &defaultConfig)
+)
+
+// Config is config for 'databricks' plugin
+type Config struct {
+	// WebAPI defines config for the base WebAPI plugin
+	WebAPI webapi.PluginConfig `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+
+	// ResourceConstraints defines resource constraints on how many executions to be created per project/overall at any given time
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// Instance is the hostname of the Databricks workspace
+	Instance string `json:"instance" pflag:",Defines the hostname of the Databricks workspace (e.g. dbc-a1b2c3d4-e5f6.cloud.databricks.com)."`
+
+	// TokenKey is the key of the secret that holds the Databricks API token
+	TokenKey string `json:"tokenKey" pflag:",Defines the key of the secret holding the token used to call the Databricks API."`
+
+	// DefaultSparkConfig is applied to every job submitted on a new cluster
+	DefaultSparkConfig map[string]string `json:"sparkConfigDefault" pflag:"-,Key value pairs of default spark configuration that should be applied to every SparkJob"`
+
+	// ExistingClusterID runs jobs on an existing cluster instead of creating one, unless overridden by the task
+	ExistingClusterID string `json:"existingClusterId" pflag:",Defines the ID of an existing cluster to run jobs on instead of creating a new one, unless overwritten by the task."`
+
+	// DefaultCluster is the spec of the clusters created for jobs
+	DefaultCluster ClusterConfig `json:"defaultCluster" pflag:",Defines the cluster created to run a job, unless overwritten by the task."`
+
+	// databricksEndpoint overrides the Databricks API endpoint, only for testing
+	databricksEndpoint string
+}
+
+// ClusterConfig is the subset of the Databricks new cluster spec that can be configured.
+type ClusterConfig struct {
+	SparkVersion string `json:"sparkVersion" pflag:",Databricks runtime version of the cluster."`
+	NodeTypeID   string `json:"nodeTypeId" pflag:",Node type of the workers and driver of the cluster."`
+	NumWorkers   int    `json:"numWorkers" pflag:",Number of workers of the cluster."`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+func SetConfig(cfg *Config) error {
+	return configSection.SetConfig(cfg)
+}
@@ -0,0 +1,71 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package databricks
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.readRateLimiter.qps"), defaultConfig.WebAPI.ReadRateLimiter.QPS, "Defines the max rate of calls per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.readRateLimiter.burst"), defaultConfig.WebAPI.ReadRateLimiter.Burst, "Defines the maximum burst size.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.writeRateLimiter.qps"), defaultConfig.WebAPI.WriteRateLimiter.QPS, "Defines the max rate of calls per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.writeRateLimiter.burst"), defaultConfig.WebAPI.WriteRateLimiter.Burst, "Defines the maximum burst size.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.size"), defaultConfig.WebAPI.Caching.Size, "Defines the maximum number of items to cache.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.caching.resyncInterval"), defaultConfig.WebAPI.Caching.ResyncInterval.String(), "Defines the sync interval.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.caching.notifierResyncInterval"), defaultConfig.WebAPI.Caching.NotifierResyncInterval.String(), "Defines the sync interval of plugins notified of updates.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.workers"), defaultConfig.WebAPI.Caching.Workers, "Defines the number of workers to start up to process items.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.maxSystemFailures"), defaultConfig.WebAPI.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.batchSize"), defaultConfig.WebAPI.Caching.BatchSize, "Defines the max number of resources to retrieve in a single call for plugins that support batching.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "instance"), defaultConfig.Instance, "Defines the hostname of the Databricks workspace (e.g. dbc-a1b2c3d4-e5f6.cloud.databricks.com).")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tokenKey"), defaultConfig.TokenKey, "Defines the key of the secret holding the token used to call the Databricks API.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "existingClusterId"), defaultConfig.ExistingClusterID, "Defines the ID of an existing cluster to run jobs on instead of creating a new one,  unless overwritten by the task.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultCluster.sparkVersion"), defaultConfig.DefaultCluster.SparkVersion, "Databricks runtime version of the cluster.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultCluster.nodeTypeId"), defaultConfig.DefaultCluster.NodeTypeID, "Node type of the workers and driver of the cluster.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "defaultCluster.numWorkers"), defaultConfig.DefaultCluster.NumWorkers, "Number of workers of the cluster.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "databricksEndpoint"), defaultConfig.databricksEndpoint, "")
+	return cmdFlags
+}
@@ -0,0 +1,148 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/tests"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEndToEnd(t *testing.T) {
+	server := newFakeDatabricksServer()
+	defer server.Close()
+
+	outputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+	iter := func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
+		// Stands for the outputs the job writes.
+		return tCtx.DataStore().WriteProtobuf(ctx, tCtx.OutputWriter().GetOutputPath(), storage.Options{}, outputs)
+	}
+
+	cfg := defaultConfig
+	cfg.Instance = "test-instance"
+	cfg.databricksEndpoint = server.URL
+	cfg.WebAPI.Caching.Workers = 1
+	cfg.WebAPI.Caching.ResyncInterval.Duration = 5 * time.Second
+	err := SetConfig(&cfg)
+	assert.NoError(t, err)
+
+	pluginEntry := pluginmachinery.CreateRemotePlugin(newDatabricksJobTaskPlugin())
+	plugin, err := pluginEntry.LoadPlugin(context.TODO(), newFakeSetupContext())
+	assert.NoError(t, err)
+
+	t.Run("python job", func(t *testing.T) {
+		sparkJob := plugins.SparkJob{
+			ApplicationType:     plugins.SparkApplication_PYTHON,
+			MainApplicationFile: "local:///usr/local/bin/entrypoint.py",
+		}
+
+		inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+		custom := &structpb.Struct{}
+		assert.NoError(t, pluginUtils.MarshalStruct(&sparkJob, custom))
+		template := flyteIdlCore.TaskTemplate{
+			Type:   databricksTaskType,
+			Custom: custom,
+			Target: &flyteIdlCore.TaskTemplate_Container{
+				Container: &flyteIdlCore.Container{
+					Image: "image",
+					Args:  []string{"pyflyte-execute", "--inputs", "{{.input}}"},
+				},
+			},
+		}
+
+		expectedOutputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, expectedOutputs, nil, iter)
+
+		assert.Equal(t, true, phase.Phase().IsSuccess())
+	})
+}
+
+// newFakeDatabricksServer reports submitted runs as pending the first time they're polled and as succeeded afterwards.
+func newFakeDatabricksServer() *httptest.Server {
+	var lock sync.Mutex
+	runs := map[int64]bool{}
+	tokens := map[string]int64{}
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if request.Header.Get("Authorization") != "Bearer fake-secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writeResponse := func(resp interface{}) {
+			writer.WriteHeader(http.StatusOK)
+			bytes, _ := json.Marshal(resp)
+			_, _ = writer.Write(bytes)
+		}
+
+		switch request.URL.Path {
+		case submitPath:
+			submitRequest := runSubmitRequest{}
+			_ = json.NewDecoder(request.Body).Decode(&submitRequest)
+			if submitRequest.SparkPythonTask == nil || submitRequest.NewCluster.DockerImage.URL != "image" {
+				writer.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			runID, found := tokens[submitRequest.IdempotencyToken]
+			if !found {
+				runID = int64(len(tokens) + 1)
+				tokens[submitRequest.IdempotencyToken] = runID
+			}
+
+			writeResponse(run{RunID: runID})
+		case getPath:
+			runID, _ := strconv.ParseInt(request.URL.Query().Get("run_id"), 10, 64)
+			state := runState{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"}
+			if !runs[runID] {
+				runs[runID] = true
+				state = runState{LifeCycleState: "PENDING"}
+			}
+
+			writeResponse(run{RunID: runID, RunPageURL: "https://test-instance/#job/1/run/1", State: state})
+		case cancelPath:
+			writeResponse(struct{}{})
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newFakeSetupContext() *pluginCoreMocks.SetupContext {
+	fakeResourceRegistrar := pluginCoreMocks.ResourceRegistrar{}
+	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+
+	fakeSetupContext := pluginCoreMocks.SetupContext{}
+	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
+	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+
+	secretManager := &pluginCoreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, mock.Anything).Return("fake-secret", nil)
+	fakeSetupContext.OnSecretManager().Return(secretManager)
+
+	return &fakeSetupContext
+}
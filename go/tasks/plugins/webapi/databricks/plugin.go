@@ -0,0 +1,238 @@
+package databricks
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"net/http"
+	"time"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+// Distinct from the spark task type, which is handled by the k8s spark plugin.
+const databricksTaskType = "databricks"
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	secretManager core.SecretManager
+	httpClient    *http.Client
+}
+
+// ResourceMetaWrapper identifies a run and holds what's needed to interact with it.
+type ResourceMetaWrapper struct {
+	RunID    int64
+	Instance string
+}
+
+// ResourceWrapper only keeps the state of the run and its page URL.
+type ResourceWrapper struct {
+	State      runState
+	RunPageURL string
+}
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	return GetConfig().WebAPI
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
+	webapi.Resource, error) {
+
+	request, err := newRunSubmitRequest(ctx, p.cfg, taskCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runID, err := p.newClient(token).submit(ctx, request)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "failed to submit run")
+	}
+
+	logger.Infof(ctx, "Submitted run [%v] for [%v]", runID, request.RunName)
+
+	return &ResourceMetaWrapper{
+		RunID:    runID,
+		Instance: p.cfg.Instance,
+	}, nil, nil
+}
+
+func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := p.newClient(token).get(ctx, resourceMeta.RunID)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "failed to get run [%v]",
+			resourceMeta.RunID)
+	}
+
+	return &ResourceWrapper{
+		State:      run.State,
+		RunPageURL: run.RunPageURL,
+	}, nil
+}
+
+func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error {
+	if taskCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.newClient(token).cancel(ctx, resourceMeta.RunID)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Cancelled run [%v]", resourceMeta.RunID)
+
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	resource := taskCtx.Resource().(*ResourceWrapper)
+	taskInfo := createTaskInfo(resourceMeta, resource)
+	state := resource.State
+
+	switch state.LifeCycleState {
+	case "PENDING":
+		return core.PhaseInfoQueuedWithTaskInfo(core.DefaultPhaseVersion, state.StateMessage, taskInfo), nil
+	case "RUNNING", "TERMINATING":
+		return core.PhaseInfoRunning(core.DefaultPhaseVersion, taskInfo), nil
+	case "TERMINATED":
+		return p.handleTerminated(ctx, taskCtx, state, taskInfo)
+	case "SKIPPED":
+		return core.PhaseInfoSystemRetryableFailure(state.LifeCycleState, state.StateMessage, taskInfo), nil
+	case "INTERNAL_ERROR":
+		return core.PhaseInfoSystemRetryableFailure(state.LifeCycleState, state.StateMessage, taskInfo), nil
+	}
+
+	return core.PhaseInfoUndefined, pluginErrors.Errorf(pluginErrors.DownstreamSystemError,
+		"unknown life cycle state [%v]", state.LifeCycleState)
+}
+
+func (p Plugin) handleTerminated(ctx context.Context, taskCtx webapi.StatusContext, state runState,
+	taskInfo *core.TaskInfo) (core.PhaseInfo, error) {
+
+	switch state.ResultState {
+	case "SUCCESS":
+		// The job writes its outputs to the output prefix, as it would on k8s.
+		outputReader := ioutils.NewRemoteFileOutputReader(ctx, taskCtx.DataStore(), taskCtx.OutputWriter(),
+			taskCtx.MaxDatasetSizeBytes())
+		if err := taskCtx.OutputWriter().Put(ctx, outputReader); err != nil {
+			return core.PhaseInfoUndefined, err
+		}
+
+		return core.PhaseInfoSuccess(taskInfo), nil
+	case "CANCELED":
+		return core.PhaseInfoRetryableFailure("ABORTED", state.StateMessage, taskInfo), nil
+	case "TIMEDOUT":
+		return core.PhaseInfoFailure(state.ResultState, state.StateMessage, taskInfo), nil
+	}
+
+	return core.PhaseInfoRetryableFailure(state.ResultState, state.StateMessage, taskInfo), nil
+}
+
+// getToken reads the personal access token from the secret manager on every call rather than keeping it in the
+// resource meta, which is persisted in the plugin state.
+func (p Plugin) getToken(ctx context.Context) (string, error) {
+	token, err := p.secretManager.Get(ctx, p.cfg.TokenKey)
+	if err != nil {
+		return "", pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get databricks token")
+	}
+
+	return token, nil
+}
+
+func (p Plugin) newClient(token string) client {
+	endpoint := p.cfg.databricksEndpoint
+	if len(endpoint) == 0 {
+		endpoint = "https://" + p.cfg.Instance
+	}
+
+	return client{
+		httpClient: p.httpClient,
+		endpoint:   endpoint,
+		token:      token,
+	}
+}
+
+func createTaskInfo(resourceMeta *ResourceMetaWrapper, resource *ResourceWrapper) *core.TaskInfo {
+	timeNow := time.Now()
+	taskInfo := &core.TaskInfo{
+		OccurredAt: &timeNow,
+		Metadata: &event.TaskExecutionMetadata{
+			ExternalResources: []*event.ExternalResourceInfo{
+				{
+					ExternalId: fmt.Sprint(resourceMeta.RunID),
+				},
+			},
+		},
+	}
+
+	if len(resource.RunPageURL) > 0 {
+		taskInfo.Logs = []*flyteIdlCore.TaskLog{
+			{
+				Uri:  resource.RunPageURL,
+				Name: "Databricks Console",
+			},
+		}
+	}
+
+	return taskInfo
+}
+
+func NewPlugin(cfg *Config, secretManager core.SecretManager, metricScope promutils.Scope) *Plugin {
+	return &Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		secretManager: secretManager,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func newDatabricksJobTaskPlugin() webapi.PluginEntry {
+	return webapi.PluginEntry{
+		ID:                 "databricks",
+		SupportedTaskTypes: []core.TaskType{databricksTaskType},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return NewPlugin(GetConfig(), iCtx.SecretManager(), iCtx.MetricsScope()), nil
+		},
+	}
+}
+
+func init() {
+	gob.Register(ResourceMetaWrapper{})
+	gob.Register(ResourceWrapper{})
+
+	pluginmachinery.PluginRegistry().RegisterRemotePlugin(newDatabricksJobTaskPlugin())
+}
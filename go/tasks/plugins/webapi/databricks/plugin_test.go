@@ -0,0 +1,86 @@
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewDatabricksJobTaskPlugin(t *testing.T) {
+	entry := newDatabricksJobTaskPlugin()
+	assert.Equal(t, []pluginsCore.TaskType{"databricks"}, entry.SupportedTaskTypes)
+}
+
+func TestCreateTaskInfo(t *testing.T) {
+	taskInfo := createTaskInfo(&ResourceMetaWrapper{RunID: 1}, &ResourceWrapper{RunPageURL: "https://instance/#job/1/run/1"})
+
+	assert.Len(t, taskInfo.Logs, 1)
+	assert.Equal(t, "https://instance/#job/1/run/1", taskInfo.Logs[0].Uri)
+	assert.Equal(t, "1", taskInfo.Metadata.ExternalResources[0].ExternalId)
+
+	taskInfo = createTaskInfo(&ResourceMetaWrapper{RunID: 1}, &ResourceWrapper{})
+	assert.Empty(t, taskInfo.Logs)
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{}
+
+	newStatusContext := func(state runState) *mocks.StatusContext {
+		tCtx := &mocks.StatusContext{}
+		tCtx.OnResourceMeta().Return(&ResourceMetaWrapper{RunID: 1})
+		tCtx.OnResource().Return(&ResourceWrapper{State: state})
+		return tCtx
+	}
+
+	for _, tc := range []struct {
+		state    runState
+		expected pluginsCore.Phase
+	}{
+		{runState{LifeCycleState: "PENDING"}, pluginsCore.PhaseQueued},
+		{runState{LifeCycleState: "RUNNING"}, pluginsCore.PhaseRunning},
+		{runState{LifeCycleState: "TERMINATING"}, pluginsCore.PhaseRunning},
+		{runState{LifeCycleState: "TERMINATED", ResultState: "FAILED"}, pluginsCore.PhaseRetryableFailure},
+		{runState{LifeCycleState: "TERMINATED", ResultState: "CANCELED"}, pluginsCore.PhaseRetryableFailure},
+		{runState{LifeCycleState: "TERMINATED", ResultState: "TIMEDOUT"}, pluginsCore.PhasePermanentFailure},
+		{runState{LifeCycleState: "SKIPPED"}, pluginsCore.PhaseRetryableFailure},
+		{runState{LifeCycleState: "INTERNAL_ERROR"}, pluginsCore.PhaseRetryableFailure},
+	} {
+		t.Run(tc.state.LifeCycleState+" "+tc.state.ResultState, func(t *testing.T) {
+			phase, err := p.Status(ctx, newStatusContext(tc.state))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, phase.Phase())
+		})
+	}
+
+	t.Run("unknown life cycle state", func(t *testing.T) {
+		_, err := p.Status(ctx, newStatusContext(runState{LifeCycleState: "UNKNOWN"}))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	server := newFakeDatabricksServer()
+	defer server.Close()
+
+	secretManager := &coreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "databricks-token").Return("fake-secret", nil)
+	p := Plugin{
+		cfg:           &Config{TokenKey: "databricks-token", databricksEndpoint: server.URL},
+		secretManager: secretManager,
+		httpClient:    server.Client(),
+	}
+
+	tCtx := &mocks.DeleteContext{}
+	tCtx.OnResourceMeta().Return(&ResourceMetaWrapper{RunID: 1})
+
+	assert.NoError(t, p.Delete(context.Background(), tCtx))
+	secretManager.AssertExpectations(t)
+}
@@ -0,0 +1,198 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+const (
+	// Task config key to run the job on an existing cluster.
+	clusterIDKey = "databricks_cluster_id"
+
+	// Task config key holding the JSON cluster spec to run the job on a new cluster.
+	newClusterKey = "databricks_new_cluster"
+)
+
+type dockerImage struct {
+	URL string `json:"url"`
+}
+
+type newCluster struct {
+	SparkVersion string            `json:"spark_version,omitempty"`
+	NodeTypeID   string            `json:"node_type_id,omitempty"`
+	NumWorkers   int               `json:"num_workers"`
+	SparkConf    map[string]string `json:"spark_conf,omitempty"`
+	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
+	DockerImage  *dockerImage      `json:"docker_image,omitempty"`
+}
+
+type sparkPythonTask struct {
+	PythonFile string   `json:"python_file"`
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+type sparkJarTask struct {
+	MainClassName string   `json:"main_class_name"`
+	Parameters    []string `json:"parameters,omitempty"`
+}
+
+type library struct {
+	Jar string `json:"jar,omitempty"`
+}
+
+type runSubmitRequest struct {
+	RunName           string           `json:"run_name"`
+	ExistingClusterID string           `json:"existing_cluster_id,omitempty"`
+	NewCluster        *newCluster      `json:"new_cluster,omitempty"`
+	SparkPythonTask   *sparkPythonTask `json:"spark_python_task,omitempty"`
+	SparkJarTask      *sparkJarTask    `json:"spark_jar_task,omitempty"`
+	Libraries         []library        `json:"libraries,omitempty"`
+	IdempotencyToken  string           `json:"idempotency_token,omitempty"`
+}
+
+// newRunSubmitRequest maps the SparkJob of the task, its container and its config to a runs/submit request.
+func newRunSubmitRequest(ctx context.Context, cfg *Config, taskCtx webapi.TaskExecutionContextReader) (
+	*runSubmitRequest, error) {
+
+	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to fetch task specification")
+	}
+
+	sparkJob := plugins.SparkJob{}
+	err = utils.UnmarshalStruct(taskTemplate.GetCustom(), &sparkJob)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	container := taskTemplate.GetContainer()
+	args, err := template.Render(ctx, container.GetArgs(), template.Parameters{
+		TaskExecMetadata: taskCtx.TaskExecutionMetadata(),
+		Inputs:           taskCtx.InputReader(),
+		OutputPath:       taskCtx.OutputWriter(),
+		Task:             taskCtx.TaskReader(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	generatedName := taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	request := &runSubmitRequest{
+		RunName: generatedName,
+		// Databricks returns the run of a previous request with the same token instead of submitting a new one.
+		IdempotencyToken: generatedName,
+	}
+
+	switch sparkJob.GetApplicationType() {
+	case plugins.SparkApplication_PYTHON:
+		if len(sparkJob.GetMainApplicationFile()) == 0 {
+			return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "MainApplicationFile must be set for python jobs")
+		}
+
+		request.SparkPythonTask = &sparkPythonTask{
+			PythonFile: toDatabricksPath(sparkJob.GetMainApplicationFile()),
+			Parameters: args,
+		}
+	case plugins.SparkApplication_JAVA, plugins.SparkApplication_SCALA:
+		if len(sparkJob.GetMainClass()) == 0 {
+			return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "MainClass must be set for %v jobs", sparkJob.GetApplicationType())
+		}
+
+		request.SparkJarTask = &sparkJarTask{
+			MainClassName: sparkJob.GetMainClass(),
+			Parameters:    args,
+		}
+
+		if len(sparkJob.GetMainApplicationFile()) > 0 {
+			request.Libraries = []library{{Jar: toDatabricksPath(sparkJob.GetMainApplicationFile())}}
+		}
+	default:
+		return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "unsupported application type [%v]", sparkJob.GetApplicationType())
+	}
+
+	request.ExistingClusterID = cfg.ExistingClusterID
+	if clusterID, found := taskTemplate.GetConfig()[clusterIDKey]; found {
+		request.ExistingClusterID = clusterID
+	}
+
+	if len(request.ExistingClusterID) > 0 {
+		// Existing clusters run with their own configuration and image.
+		return request, nil
+	}
+
+	clusterConfig := cfg.DefaultCluster
+	if spec, found := taskTemplate.GetConfig()[newClusterKey]; found {
+		if err := json.Unmarshal([]byte(spec), &clusterConfig); err != nil {
+			return nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid cluster spec [%v]", spec)
+		}
+	}
+
+	request.NewCluster = &newCluster{
+		SparkVersion: clusterConfig.SparkVersion,
+		NodeTypeID:   clusterConfig.NodeTypeID,
+		NumWorkers:   clusterConfig.NumWorkers,
+		SparkConf:    getSparkConf(cfg, &sparkJob),
+		SparkEnvVars: getSparkEnvVars(ctx, taskCtx, &sparkJob, container.GetEnv()),
+	}
+
+	if len(container.GetImage()) > 0 {
+		request.NewCluster.DockerImage = &dockerImage{URL: container.GetImage()}
+	}
+
+	return request, nil
+}
+
+func getSparkConf(cfg *Config, sparkJob *plugins.SparkJob) map[string]string {
+	sparkConf := make(map[string]string, len(cfg.DefaultSparkConfig)+len(sparkJob.GetSparkConf()))
+	for k, v := range cfg.DefaultSparkConfig {
+		sparkConf[k] = v
+	}
+
+	for k, v := range sparkJob.GetSparkConf() {
+		sparkConf[k] = v
+	}
+
+	// Hadoop configuration is passed through spark config on Databricks.
+	for k, v := range sparkJob.GetHadoopConf() {
+		sparkConf["spark.hadoop."+k] = v
+	}
+
+	return sparkConf
+}
+
+func getSparkEnvVars(ctx context.Context, taskCtx webapi.TaskExecutionContextReader, sparkJob *plugins.SparkJob,
+	env []*flyteIdlCore.KeyValuePair) map[string]string {
+	decorated := flytek8s.DecorateEnvVars(ctx, flytek8s.ToK8sEnvVar(env), taskCtx.TaskExecutionMetadata().GetTaskExecutionID())
+	envVars := make(map[string]string, len(decorated)+2)
+	for _, envVar := range decorated {
+		envVars[envVar.Name] = envVar.Value
+	}
+
+	envVars["FLYTE_MAX_ATTEMPTS"] = strconv.Itoa(int(taskCtx.TaskExecutionMetadata().GetMaxAttempts()))
+
+	if len(sparkJob.GetExecutorPath()) > 0 {
+		envVars["PYSPARK_PYTHON"] = sparkJob.GetExecutorPath()
+	}
+
+	return envVars
+}
+
+// toDatabricksPath converts paths to files in the container image, which spark-on-k8s prefixes with local://, to the
+// file: scheme Databricks understands.
+func toDatabricksPath(path string) string {
+	if strings.HasPrefix(path, "local://") {
+		return "file:" + strings.TrimPrefix(path, "local://")
+	}
+
+	return path
+}
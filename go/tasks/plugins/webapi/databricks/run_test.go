@@ -0,0 +1,147 @@
+package databricks
+
+import (
+	"context"
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTaskContext(t *testing.T, sparkJob *plugins.SparkJob, taskConfig map[string]string) *mocks.TaskExecutionContextReader {
+	custom := &structpb.Struct{}
+	assert.NoError(t, pluginUtils.MarshalStruct(sparkJob, custom))
+
+	taskReader := &coreMocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(&flyteIdlCore.TaskTemplate{
+		Type:   databricksTaskType,
+		Custom: custom,
+		Config: taskConfig,
+		Target: &flyteIdlCore.TaskTemplate_Container{
+			Container: &flyteIdlCore.Container{
+				Image: "image",
+				Args:  []string{"--arg"},
+				Env:   []*flyteIdlCore.KeyValuePair{{Key: "KEY", Value: "value"}},
+			},
+		},
+	}, nil)
+
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("generated-name")
+	tID.OnGetID().Return(flyteIdlCore.TaskExecutionIdentifier{})
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnGetMaxAttempts().Return(3)
+
+	inputReader := &ioMocks.InputReader{}
+	inputReader.OnGetInputPath().Return("inputs.pb")
+	inputReader.OnGetInputPrefixPath().Return("")
+	inputReader.OnGetMatch(mock.Anything).Return(&flyteIdlCore.LiteralMap{}, nil)
+
+	outputWriter := &ioMocks.OutputWriter{}
+	outputWriter.OnGetOutputPrefixPath().Return("")
+	outputWriter.OnGetRawOutputPrefix().Return("")
+
+	taskCtx := &mocks.TaskExecutionContextReader{}
+	taskCtx.OnTaskReader().Return(taskReader)
+	taskCtx.OnTaskExecutionMetadata().Return(tMeta)
+	taskCtx.OnInputReader().Return(inputReader)
+	taskCtx.OnOutputWriter().Return(outputWriter)
+	return taskCtx
+}
+
+func TestNewRunSubmitRequest(t *testing.T) {
+	ctx := context.Background()
+	cfg := &Config{
+		DefaultSparkConfig: map[string]string{"spark.executor.cores": "1", "spark.driver.cores": "1"},
+		DefaultCluster:     ClusterConfig{SparkVersion: "9.1.x-scala2.12", NodeTypeID: "i3.xlarge", NumWorkers: 2},
+	}
+
+	t.Run("python job on a new cluster", func(t *testing.T) {
+		taskCtx := newTaskContext(t, &plugins.SparkJob{
+			ApplicationType:     plugins.SparkApplication_PYTHON,
+			MainApplicationFile: "local:///usr/local/bin/entrypoint.py",
+			SparkConf:           map[string]string{"spark.executor.cores": "4"},
+			HadoopConf:          map[string]string{"fs.s3a.acl.default": "BucketOwnerFullControl"},
+			ExecutorPath:        "/usr/bin/python3",
+		}, nil)
+
+		request, err := newRunSubmitRequest(ctx, cfg, taskCtx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "generated-name", request.RunName)
+		assert.Equal(t, "generated-name", request.IdempotencyToken)
+		assert.Equal(t, &sparkPythonTask{PythonFile: "file:/usr/local/bin/entrypoint.py", Parameters: []string{"--arg"}},
+			request.SparkPythonTask)
+		assert.Empty(t, request.ExistingClusterID)
+		assert.Equal(t, "9.1.x-scala2.12", request.NewCluster.SparkVersion)
+		assert.Equal(t, 2, request.NewCluster.NumWorkers)
+		assert.Equal(t, "image", request.NewCluster.DockerImage.URL)
+		assert.Equal(t, map[string]string{
+			"spark.executor.cores":            "4",
+			"spark.driver.cores":              "1",
+			"spark.hadoop.fs.s3a.acl.default": "BucketOwnerFullControl",
+		}, request.NewCluster.SparkConf)
+		assert.Equal(t, "value", request.NewCluster.SparkEnvVars["KEY"])
+		assert.Equal(t, "/usr/bin/python3", request.NewCluster.SparkEnvVars["PYSPARK_PYTHON"])
+		assert.Equal(t, "3", request.NewCluster.SparkEnvVars["FLYTE_MAX_ATTEMPTS"])
+	})
+
+	t.Run("cluster overridden by the task", func(t *testing.T) {
+		taskCtx := newTaskContext(t, &plugins.SparkJob{
+			ApplicationType:     plugins.SparkApplication_PYTHON,
+			MainApplicationFile: "dbfs:/main.py",
+		}, map[string]string{newClusterKey: `{"sparkVersion": "10.4.x-scala2.12", "nodeTypeId": "i3.2xlarge", "numWorkers": 8}`})
+
+		request, err := newRunSubmitRequest(ctx, cfg, taskCtx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dbfs:/main.py", request.SparkPythonTask.PythonFile)
+		assert.Equal(t, "10.4.x-scala2.12", request.NewCluster.SparkVersion)
+		assert.Equal(t, "i3.2xlarge", request.NewCluster.NodeTypeID)
+		assert.Equal(t, 8, request.NewCluster.NumWorkers)
+	})
+
+	t.Run("scala job on an existing cluster", func(t *testing.T) {
+		taskCtx := newTaskContext(t, &plugins.SparkJob{
+			ApplicationType:     plugins.SparkApplication_SCALA,
+			MainClass:           "org.example.Main",
+			MainApplicationFile: "dbfs:/main.jar",
+		}, map[string]string{clusterIDKey: "cluster-id"})
+
+		request, err := newRunSubmitRequest(ctx, cfg, taskCtx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cluster-id", request.ExistingClusterID)
+		assert.Nil(t, request.NewCluster)
+		assert.Equal(t, &sparkJarTask{MainClassName: "org.example.Main", Parameters: []string{"--arg"}}, request.SparkJarTask)
+		assert.Equal(t, []library{{Jar: "dbfs:/main.jar"}}, request.Libraries)
+	})
+
+	t.Run("missing main class", func(t *testing.T) {
+		taskCtx := newTaskContext(t, &plugins.SparkJob{ApplicationType: plugins.SparkApplication_JAVA}, nil)
+
+		_, err := newRunSubmitRequest(ctx, cfg, taskCtx)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported application type", func(t *testing.T) {
+		taskCtx := newTaskContext(t, &plugins.SparkJob{
+			ApplicationType:     plugins.SparkApplication_R,
+			MainApplicationFile: "dbfs:/main.R",
+		}, nil)
+
+		_, err := newRunSubmitRequest(ctx, cfg, taskCtx)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxResponseSize bounds how much of a response is read, since responses are kept in the resource cache.
+	maxResponseSize = 1 << 20
+
+	maxRedirects = 10
+)
+
+// newHTTPClient returns a client that only follows redirects to allowed hosts, so that a response can't steer the
+// requests elsewhere.
+func newHTTPClient(cfg *Config) *http.Client {
+	return &http.Client{
+		Timeout: cfg.RequestTimeout.Duration,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %v redirects", maxRedirects)
+			}
+
+			if !isAllowed(cfg.AllowedHosts, req.URL.Host) {
+				return fmt.Errorf("redirect to host [%v] is not allowed", req.URL.Host)
+			}
+
+			return nil
+		},
+	}
+}
+
+// send sends the request, authenticated with the token if any, and returns the body of the response if it was
+// successful. Responses larger than maxResponseSize are reported as errors rather than truncated.
+func send(ctx context.Context, httpClient *http.Client, request PreparedRequest, token string) (body []byte,
+	statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, strings.NewReader(request.Body))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "flytepropeller")
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer httpResp.Body.Close()
+	// One more byte than allowed is read to tell a response of the maximum size apart from a larger one.
+	raw, err := ioutil.ReadAll(&io.LimitedReader{R: httpResp.Body, N: maxResponseSize + 1})
+	if err != nil {
+		return nil, httpResp.StatusCode, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		if len(raw) > maxResponseSize {
+			raw = raw[:maxResponseSize]
+		}
+
+		return nil, httpResp.StatusCode, fmt.Errorf("%v %v returned status [%v]: %v", request.Method, request.URL,
+			httpResp.StatusCode, string(raw))
+	}
+
+	if len(raw) > maxResponseSize {
+		return nil, httpResp.StatusCode, fmt.Errorf("response too large: %v %v returned more than [%v] bytes",
+			request.Method, request.URL, maxResponseSize)
+	}
+
+	return raw, httpResp.StatusCode, nil
+}
@@ -0,0 +1,75 @@
+// Package http implements a generic WebAPI plugin that drives remote resources through REST APIs declared by the task
+package http
+
+import (
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"default": 1000,
+			},
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+			},
+			ResourceMeta: nil,
+		},
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+		RequestTimeout: config.Duration{Duration: 30 * time.Second},
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("http", &defaultConfig)
+)
+
+// Config is config for 'http' plugin
+type Config struct {
+	// WebAPI defines config for the base WebAPI plugin
+	WebAPI webapi.PluginConfig `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+
+	// ResourceConstraints defines resource constraints on how many executions to be created per project/overall at any given time
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// AllowedHosts lists the hosts tasks may send requests to. Requests to any other host are rejected.
+	AllowedHosts []string `json:"allowedHosts" pflag:",Defines the hosts (optionally with a port) tasks are allowed to send requests to."`
+
+	// TokenKeys maps allowed hosts to the key of the secret holding the bearer token sent along with requests to them
+	TokenKeys map[string]string `json:"tokenKeys" pflag:"-,Maps hosts to the key of the secret holding the bearer token to send to them."`
+
+	// RequestTimeout bounds the time taken by every request
+	RequestTimeout config.Duration `json:"requestTimeout" pflag:",Defines the timeout of every request sent to the remote service."`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+func SetConfig(cfg *Config) error {
+	return configSection.SetConfig(cfg)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/tests"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndToEnd(t *testing.T) {
+	server := newFakeJobServer()
+	defer server.Close()
+
+	iter := func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
+		return nil
+	}
+
+	cfg := defaultConfig
+	cfg.AllowedHosts = []string{"127.0.0.1"}
+	cfg.TokenKeys = map[string]string{"127.0.0.1": "FLYTE_JOBS_TOKEN"}
+	cfg.WebAPI.Caching.Workers = 1
+	cfg.WebAPI.Caching.ResyncInterval.Duration = 5 * time.Second
+	err := SetConfig(&cfg)
+	assert.NoError(t, err)
+
+	pluginEntry := pluginmachinery.CreateRemotePlugin(newHTTPTaskPlugin())
+	plugin, err := pluginEntry.LoadPlugin(context.TODO(), newFakeSetupContext())
+	assert.NoError(t, err)
+
+	t.Run("double", func(t *testing.T) {
+		taskConfig := TaskConfig{
+			Create: Request{
+				URL:  server.URL + "/jobs",
+				Body: `{"name": "{{ .PerRetryUniqueKey }}", "x": {{ .Inputs.x }}}`,
+			},
+			ResourceID: "{.job.id}",
+			Status:     Request{URL: server.URL + "/jobs/{{ .ResourceID }}"},
+			Cancel:     &Request{URL: server.URL + "/jobs/{{ .ResourceID }}"},
+			Phase: PhaseMapping{
+				State:     "{.state}",
+				Succeeded: []string{"DONE"},
+				Failed:    []string{"ERROR"},
+			},
+			Outputs: map[string]string{"y": "{.result.y}"},
+		}
+
+		inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 21})
+		custom, _ := pluginUtils.MarshalObjToStruct(taskConfig)
+		template := flyteIdlCore.TaskTemplate{
+			Type:   httpTaskType,
+			Custom: custom,
+			Interface: &flyteIdlCore.TypedInterface{
+				Outputs: &flyteIdlCore.VariableMap{
+					Variables: map[string]*flyteIdlCore.Variable{
+						"y": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{
+							Simple: flyteIdlCore.SimpleType_INTEGER}}},
+					},
+				},
+			},
+		}
+
+		expectedOutputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"y": 42})
+		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, expectedOutputs, nil, iter)
+
+		assert.Equal(t, true, phase.Phase().IsSuccess())
+	})
+}
+
+// newFakeJobServer runs jobs doubling their input x. Jobs are reported as running the first time they're polled.
+func newFakeJobServer() *httptest.Server {
+	var lock sync.Mutex
+	jobs := map[string]int64{}
+	polled := map[string]bool{}
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if request.Header.Get("Authorization") != "Bearer fake-secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writeResponse := func(statusCode int, resp interface{}) {
+			writer.WriteHeader(statusCode)
+			bytes, _ := json.Marshal(resp)
+			_, _ = writer.Write(bytes)
+		}
+
+		if request.URL.Path == "/jobs" && request.Method == http.MethodPost {
+			job := struct {
+				Name string `json:"name"`
+				X    int64  `json:"x"`
+			}{}
+
+			if err := json.NewDecoder(request.Body).Decode(&job); err != nil {
+				writeResponse(http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+
+			id := fmt.Sprintf("job-%v", job.Name)
+			jobs[id] = job.X
+			writeResponse(http.StatusCreated, map[string]interface{}{"job": map[string]string{"id": id}})
+			return
+		}
+
+		id := strings.TrimPrefix(request.URL.Path, "/jobs/")
+		x, found := jobs[id]
+		if !found {
+			writeResponse(http.StatusNotFound, map[string]string{"error": "not found"})
+			return
+		}
+
+		switch request.Method {
+		case http.MethodDelete:
+			delete(jobs, id)
+			writeResponse(http.StatusOK, map[string]string{})
+		case http.MethodGet:
+			if !polled[id] {
+				polled[id] = true
+				writeResponse(http.StatusOK, map[string]string{"state": "RUNNING"})
+				return
+			}
+
+			writeResponse(http.StatusOK, map[string]interface{}{"state": "DONE", "result": map[string]int64{"y": 2 * x}})
+		default:
+			writeResponse(http.StatusMethodNotAllowed, map[string]string{})
+		}
+	}))
+}
+
+func newFakeSetupContext() *pluginCoreMocks.SetupContext {
+	fakeResourceRegistrar := pluginCoreMocks.ResourceRegistrar{}
+	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+
+	fakeSetupContext := pluginCoreMocks.SetupContext{}
+	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
+	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+
+	secretManager := &pluginCoreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "FLYTE_JOBS_TOKEN").Return("fake-secret", nil)
+	fakeSetupContext.OnSecretManager().Return(secretManager)
+
+	return &fakeSetupContext
+}
@@ -0,0 +1,353 @@
+package http
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"net/http"
+	"time"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+const (
+	httpTaskType = "http"
+
+	// Substituted for the id of the resource to validate requests before it's created.
+	placeholderResourceID = "resource-id"
+)
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	secretManager core.SecretManager
+	httpClient    *http.Client
+}
+
+// ResourceMetaWrapper holds the rendered requests to interact with the created resource, since the inputs are only
+// available when creating it.
+type ResourceMetaWrapper struct {
+	ResourceID string
+	Status     PreparedRequest
+	Cancel     *PreparedRequest
+}
+
+// ResourceWrapper holds the raw response to the status request, or why the create request was rejected.
+type ResourceWrapper struct {
+	Response    []byte
+	CreateError string
+}
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	return GetConfig().WebAPI
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
+	webapi.Resource, error) {
+
+	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to fetch task specification")
+	}
+
+	taskConfig, err := unmarshalTaskConfig(taskTemplate.GetCustom())
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid task config")
+	}
+
+	createRequest, err := p.prepare(ctx, taskCtx, taskConfig.Create)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statusRequest, err := p.prepare(ctx, taskCtx, taskConfig.Status)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cancelRequest *PreparedRequest
+	if taskConfig.Cancel != nil {
+		prepared, err := p.prepare(ctx, taskCtx, *taskConfig.Cancel)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cancelRequest = &prepared
+	}
+
+	body, statusCode, err := p.send(ctx, createRequest)
+	if err != nil && isRejected(statusCode) {
+		// Sending the same request again would be rejected again, fail the task through Status instead of retrying.
+		return &ResourceMetaWrapper{}, &ResourceWrapper{CreateError: err.Error()}, nil
+	}
+
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "failed to create resource")
+	}
+
+	response, err := parseResponse(body)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "unexpected create response")
+	}
+
+	resourceID, err := evaluate(taskConfig.ResourceID, response)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err,
+			"failed to extract the resource id from the create response")
+	}
+
+	resourceMeta := &ResourceMetaWrapper{
+		ResourceID: resourceID,
+		Status:     statusRequest.withResourceID(resourceID),
+	}
+
+	if err := p.checkHost(resourceMeta.Status); err != nil {
+		return nil, nil, err
+	}
+
+	if cancelRequest != nil {
+		prepared := cancelRequest.withResourceID(resourceID)
+		if err := p.checkHost(prepared); err != nil {
+			return nil, nil, err
+		}
+
+		resourceMeta.Cancel = &prepared
+	}
+
+	logger.Infof(ctx, "Created resource [%v] for [%v]", resourceID,
+		taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+
+	return resourceMeta, nil, nil
+}
+
+// prepare renders the request, checks it's sent to an allowed host and attaches the key of the token configured for
+// the host.
+func (p Plugin) prepare(ctx context.Context, taskCtx webapi.TaskExecutionContextReader, request Request) (
+	PreparedRequest, error) {
+
+	prepared, err := render(ctx, request, template.Parameters{
+		TaskExecMetadata: taskCtx.TaskExecutionMetadata(),
+		Inputs:           taskCtx.InputReader(),
+		OutputPath:       taskCtx.OutputWriter(),
+		Task:             taskCtx.TaskReader(),
+	})
+
+	if err != nil {
+		return PreparedRequest{}, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err,
+			"failed to render request [%v]", request.URL)
+	}
+
+	// The id of the resource may be part of the url, check it once substituted with something that can't change the
+	// host.
+	if err := p.checkHost(prepared.withResourceID(placeholderResourceID)); err != nil {
+		return PreparedRequest{}, err
+	}
+
+	host, _ := prepared.getHost()
+	prepared.TokenKey, _ = getTokenKey(p.cfg.TokenKeys, host)
+
+	return prepared, nil
+}
+
+// send reads the token of the request from the secret manager on every call, rather than keeping it in the resource
+// meta, and sends the request.
+func (p Plugin) send(ctx context.Context, request PreparedRequest) (body []byte, statusCode int, err error) {
+	var token string
+	if len(request.TokenKey) > 0 {
+		token, err = p.secretManager.Get(ctx, request.TokenKey)
+		if err != nil {
+			return nil, 0, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get the token for [%v]",
+				request.URL)
+		}
+	}
+
+	return send(ctx, p.httpClient, request, token)
+}
+
+func (p Plugin) checkHost(request PreparedRequest) error {
+	host, err := request.getHost()
+	if err != nil {
+		return pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid url [%v]", request.URL)
+	}
+
+	if !isAllowed(p.cfg.AllowedHosts, host) {
+		return pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "host [%v] is not allowed", host)
+	}
+
+	return nil
+}
+
+func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	body, _, err := p.send(ctx, resourceMeta.Status)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "failed to get resource [%v]",
+			resourceMeta.ResourceID)
+	}
+
+	return &ResourceWrapper{Response: body}, nil
+}
+
+func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error {
+	if taskCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	if resourceMeta.Cancel == nil {
+		logger.Infof(ctx, "No cancel request declared, leaving resource [%v] running", resourceMeta.ResourceID)
+		return nil
+	}
+
+	_, statusCode, err := p.send(ctx, *resourceMeta.Cancel)
+	if err != nil && statusCode != http.StatusNotFound {
+		return err
+	}
+
+	logger.Infof(ctx, "Cancelled resource [%v]", resourceMeta.ResourceID)
+
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	resource := taskCtx.Resource().(*ResourceWrapper)
+	taskInfo := createTaskInfo(resourceMeta)
+
+	if len(resource.CreateError) > 0 {
+		return core.PhaseInfoFailure(pluginErrors.BadTaskSpecification, resource.CreateError, taskInfo), nil
+	}
+
+	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	taskConfig, err := unmarshalTaskConfig(taskTemplate.GetCustom())
+	if err != nil {
+		return core.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "invalid task config")
+	}
+
+	response, err := parseResponse(resource.Response)
+	if err != nil {
+		return core.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err,
+			"unexpected status response")
+	}
+
+	state, err := evaluate(taskConfig.Phase.State, response)
+	if err != nil {
+		return core.PhaseInfoUndefined, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err,
+			"failed to extract the state of resource [%v]", resourceMeta.ResourceID)
+	}
+
+	switch {
+	case contains(taskConfig.Phase.Succeeded, state):
+		return p.writeOutputs(ctx, taskCtx, taskConfig, taskTemplate.GetInterface().GetOutputs(), response, taskInfo)
+	case contains(taskConfig.Phase.Failed, state):
+		message := fmt.Sprintf("resource [%v] is in state [%v]", resourceMeta.ResourceID, state)
+		if len(taskConfig.Phase.Message) > 0 {
+			if reason, err := evaluate(taskConfig.Phase.Message, response); err == nil {
+				message = reason
+			}
+		}
+
+		return core.PhaseInfoFailure(state, message, taskInfo), nil
+	}
+
+	return core.PhaseInfoRunning(core.DefaultPhaseVersion, taskInfo), nil
+}
+
+func (p Plugin) writeOutputs(ctx context.Context, taskCtx webapi.StatusContext, taskConfig *TaskConfig,
+	outputs *flyteIdlCore.VariableMap, response interface{}, taskInfo *core.TaskInfo) (core.PhaseInfo, error) {
+
+	if len(outputs.GetVariables()) == 0 {
+		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
+		return core.PhaseInfoSuccess(taskInfo), nil
+	}
+
+	literals, err := getOutputs(taskConfig, outputs, response)
+	if err != nil {
+		return core.PhaseInfoFailure(pluginErrors.BadTaskSpecification, err.Error(), taskInfo), nil
+	}
+
+	err = taskCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(literals, nil))
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	return core.PhaseInfoSuccess(taskInfo), nil
+}
+
+// isRejected tells whether the remote service refused the request itself, as opposed to failing to process it or
+// asking to be called again later.
+func isRejected(statusCode int) bool {
+	return statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError &&
+		statusCode != http.StatusRequestTimeout && statusCode != http.StatusTooManyRequests
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func createTaskInfo(resourceMeta *ResourceMetaWrapper) *core.TaskInfo {
+	timeNow := time.Now()
+
+	return &core.TaskInfo{
+		OccurredAt: &timeNow,
+		Metadata: &event.TaskExecutionMetadata{
+			ExternalResources: []*event.ExternalResourceInfo{
+				{
+					ExternalId: resourceMeta.ResourceID,
+				},
+			},
+		},
+	}
+}
+
+func NewPlugin(cfg *Config, secretManager core.SecretManager, metricScope promutils.Scope) *Plugin {
+	return &Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		secretManager: secretManager,
+		httpClient:    newHTTPClient(cfg),
+	}
+}
+
+func newHTTPTaskPlugin() webapi.PluginEntry {
+	return webapi.PluginEntry{
+		ID:                 "http",
+		SupportedTaskTypes: []core.TaskType{httpTaskType},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return NewPlugin(GetConfig(), iCtx.SecretManager(), iCtx.MetricsScope()), nil
+		},
+	}
+}
+
+func init() {
+	gob.Register(ResourceMetaWrapper{})
+	gob.Register(ResourceWrapper{})
+
+	pluginmachinery.PluginRegistry().RegisterRemotePlugin(newHTTPTaskPlugin())
+}
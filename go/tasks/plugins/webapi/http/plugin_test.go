@@ -0,0 +1,249 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTaskReader(taskConfig TaskConfig) *coreMocks.TaskReader {
+	custom, _ := pluginUtils.MarshalObjToStruct(taskConfig)
+	taskReader := &coreMocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(&flyteIdlCore.TaskTemplate{Custom: custom}, nil)
+	return taskReader
+}
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "Bearer fake-secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"state": "RUNNING"}`))
+	}))
+	defer server.Close()
+
+	secretManager := &coreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "jobs-token").Return("fake-secret", nil)
+	p := Plugin{secretManager: secretManager, httpClient: server.Client()}
+
+	tCtx := &mocks.GetContext{}
+	tCtx.OnResourceMeta().Return(&ResourceMetaWrapper{
+		ResourceID: "job-1",
+		Status:     PreparedRequest{Method: http.MethodGet, URL: server.URL + "/jobs/job-1", TokenKey: "jobs-token"},
+	})
+
+	resource, err := p.Get(context.Background(), tCtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"state": "RUNNING"}`, string(resource.(*ResourceWrapper).Response))
+	secretManager.AssertExpectations(t)
+}
+
+func TestSend_Redirect(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte(`{}`))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/same-host":
+			http.Redirect(writer, request, "/jobs", http.StatusFound)
+		case "/other-host":
+			http.Redirect(writer, request, other.URL+"/jobs", http.StatusFound)
+		default:
+			_, _ = writer.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	p := Plugin{httpClient: newHTTPClient(&Config{AllowedHosts: []string{serverURL.Host}})}
+
+	t.Run("allowed host", func(t *testing.T) {
+		_, _, err := p.send(context.Background(), PreparedRequest{Method: http.MethodGet, URL: server.URL + "/same-host"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("host not allowed", func(t *testing.T) {
+		_, _, err := p.send(context.Background(), PreparedRequest{Method: http.MethodGet, URL: server.URL + "/other-host"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSend_ResponseTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		size := maxResponseSize
+		if request.URL.Path == "/too-large" {
+			size++
+		}
+
+		_, _ = writer.Write([]byte(`"` + strings.Repeat("a", size-2) + `"`))
+	}))
+	defer server.Close()
+
+	p := Plugin{httpClient: server.Client()}
+
+	body, _, err := p.send(context.Background(), PreparedRequest{Method: http.MethodGet, URL: server.URL + "/largest"})
+	assert.NoError(t, err)
+	assert.Len(t, body, maxResponseSize)
+
+	_, _, err = p.send(context.Background(), PreparedRequest{Method: http.MethodGet, URL: server.URL + "/too-large"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "response too large")
+}
+
+func newCreateContext(taskConfig TaskConfig) *mocks.TaskExecutionContextReader {
+	taskExecID := &coreMocks.TaskExecutionID{}
+	taskExecID.OnGetGeneratedName().Return("generated-name")
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(taskExecID)
+
+	inputReader := &ioMocks.InputReader{}
+	inputReader.OnGetInputPath().Return("/inputs.pb")
+	inputReader.OnGetInputPrefixPath().Return("/")
+	inputReader.OnGetMatch(mock.Anything).Return(&flyteIdlCore.LiteralMap{}, nil)
+
+	outputWriter := &ioMocks.OutputWriter{}
+	outputWriter.OnGetOutputPrefixPath().Return("/")
+	outputWriter.OnGetRawOutputPrefix().Return("/")
+
+	tCtx := &mocks.TaskExecutionContextReader{}
+	tCtx.OnTaskReader().Return(newTaskReader(taskConfig))
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnInputReader().Return(inputReader)
+	tCtx.OnOutputWriter().Return(outputWriter)
+	return tCtx
+}
+
+func TestCreate_HostNotAllowed(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{cfg: &Config{AllowedHosts: []string{"jobs.example.com"}}}
+
+	taskConfig := newTaskConfig()
+	taskConfig.Status.URL = "https://other.example.com/jobs/{{ .ResourceID }}"
+
+	_, _, err := p.Create(ctx, newCreateContext(taskConfig))
+
+	assert.Error(t, err)
+	assert.True(t, stdErrors.IsCausedBy(err, pluginErrors.BadTaskSpecification))
+}
+
+func TestCreate_ErrorStatus(t *testing.T) {
+	ctx := context.Background()
+
+	newPlugin := func(t *testing.T, statusCode int) (Plugin, TaskConfig) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(statusCode)
+			_, _ = writer.Write([]byte(`{"error": "invalid job"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		serverURL, err := url.Parse(server.URL)
+		assert.NoError(t, err)
+
+		taskConfig := newTaskConfig()
+		taskConfig.Create.URL = server.URL + "/jobs"
+		taskConfig.Status.URL = server.URL + "/jobs/{{ .ResourceID }}"
+		return Plugin{cfg: &Config{AllowedHosts: []string{serverURL.Host}}, httpClient: server.Client()}, taskConfig
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		p, taskConfig := newPlugin(t, http.StatusUnprocessableEntity)
+		resourceMeta, resource, err := p.Create(ctx, newCreateContext(taskConfig))
+		assert.NoError(t, err)
+
+		tCtx := &mocks.StatusContext{}
+		tCtx.OnResourceMeta().Return(resourceMeta)
+		tCtx.OnResource().Return(resource)
+
+		phase, err := p.Status(ctx, tCtx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phase.Phase())
+		assert.Equal(t, pluginErrors.BadTaskSpecification, phase.Err().Code)
+		assert.Contains(t, phase.Err().Message, "invalid job")
+	})
+
+	for _, statusCode := range []int{http.StatusTooManyRequests, http.StatusInternalServerError} {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			p, taskConfig := newPlugin(t, statusCode)
+			_, _, err := p.Create(ctx, newCreateContext(taskConfig))
+
+			assert.Error(t, err)
+			assert.True(t, stdErrors.IsCausedBy(err, pluginErrors.DownstreamSystemError))
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{}
+	resourceMeta := &ResourceMetaWrapper{ResourceID: "job-1"}
+
+	taskConfig := newTaskConfig()
+	taskConfig.Phase.Failed = []string{"ERROR"}
+	taskConfig.Phase.Message = "{.error}"
+
+	newStatusContext := func(response string) *mocks.StatusContext {
+		tCtx := &mocks.StatusContext{}
+		tCtx.OnResourceMeta().Return(resourceMeta)
+		tCtx.OnResource().Return(&ResourceWrapper{Response: []byte(response)})
+		tCtx.OnTaskReader().Return(newTaskReader(taskConfig))
+		return tCtx
+	}
+
+	t.Run("running", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(`{"state": "PENDING"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRunning, phase.Phase())
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(`{"state": "DONE"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseSuccess, phase.Phase())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(`{"state": "ERROR", "error": "out of memory"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phase.Phase())
+		assert.Equal(t, "ERROR", phase.Err().Code)
+		assert.Equal(t, "out of memory", phase.Err().Message)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		_, err := p.Status(ctx, newStatusContext(`{}`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDelete_NoCancelRequest(t *testing.T) {
+	p := Plugin{}
+	tCtx := &mocks.DeleteContext{}
+	tCtx.OnResourceMeta().Return(&ResourceMetaWrapper{ResourceID: "job-1"})
+
+	assert.NoError(t, p.Delete(context.Background(), tCtx))
+}
@@ -0,0 +1,342 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// TaskConfig declares, in the custom field of the task, how to drive a remote resource through its REST API.
+//
+// The URLs, headers and bodies of the requests are templates supporting the same variables as container args (e.g.
+// {{ .Inputs.x }} and {{ .PerRetryUniqueKey }}, which can be used to make the create request idempotent). The status
+// and cancel requests can additionally refer to the id of the created resource as {{ .ResourceID }}. Values substituted
+// in a body are escaped according to its Content-Type header: to fit in a JSON string for JSON bodies, the default,
+// and query-escaped for form bodies.
+//
+// Values are extracted from JSON responses using kubectl-style JSONPath expressions (e.g. {.status.state}).
+type TaskConfig struct {
+	// Create creates the remote resource. Defaults to a POST request.
+	Create Request `json:"create"`
+
+	// ResourceID extracts the id of the created resource from the response to the create request.
+	ResourceID string `json:"resourceId"`
+
+	// Status retrieves the status of the resource. Defaults to a GET request.
+	Status Request `json:"status"`
+
+	// Cancel optionally cancels the resource when the task is aborted. Defaults to a DELETE request.
+	Cancel *Request `json:"cancel,omitempty"`
+
+	// Phase maps the response to the status request to the phase of the task.
+	Phase PhaseMapping `json:"phase"`
+
+	// Outputs maps the outputs of the task to expressions extracting them from the response to the status request once
+	// the resource has succeeded.
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+type Request struct {
+	Method  string            `json:"method,omitempty"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type PhaseMapping struct {
+	// State extracts the state of the resource from the response to the status request.
+	State string `json:"state"`
+
+	// Succeeded lists the states in which the resource has succeeded.
+	Succeeded []string `json:"succeeded"`
+
+	// Failed lists the states in which the resource has failed. Any other state is considered running.
+	Failed []string `json:"failed,omitempty"`
+
+	// Message optionally extracts the reason of a failure from the response to the status request.
+	Message string `json:"message,omitempty"`
+}
+
+// PreparedRequest is a rendered request, ready to be sent.
+type PreparedRequest struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+
+	// TokenKey is the key of the secret holding the bearer token configured for the host of the request, if any. Only
+	// the key is kept since prepared requests are persisted in the plugin state.
+	TokenKey string
+}
+
+var resourceIDRegex = regexp.MustCompile(`(?i){{\s*[\.$]ResourceID\s*}}`)
+
+func unmarshalTaskConfig(structObj *structpb.Struct) (*TaskConfig, error) {
+	taskConfig := TaskConfig{}
+	err := pluginUtils.UnmarshalStructToObj(structObj, &taskConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal TaskConfig")
+	}
+
+	if len(taskConfig.Create.URL) == 0 || len(taskConfig.Status.URL) == 0 {
+		return nil, errors.New("the create and status requests must specify a url")
+	}
+
+	if refersToResourceID(taskConfig.Create) {
+		return nil, errors.New("the create request can't refer to the id of the resource")
+	}
+
+	if len(taskConfig.Phase.Succeeded) == 0 {
+		return nil, errors.New("at least one state must map to succeeded")
+	}
+
+	if len(taskConfig.Create.Method) == 0 {
+		taskConfig.Create.Method = http.MethodPost
+	}
+
+	if len(taskConfig.Status.Method) == 0 {
+		taskConfig.Status.Method = http.MethodGet
+	}
+
+	if taskConfig.Cancel != nil && len(taskConfig.Cancel.Method) == 0 {
+		taskConfig.Cancel.Method = http.MethodDelete
+	}
+
+	expressions := map[string]string{
+		"resourceId":  taskConfig.ResourceID,
+		"phase.state": taskConfig.Phase.State,
+	}
+
+	if len(taskConfig.Phase.Message) > 0 {
+		expressions["phase.message"] = taskConfig.Phase.Message
+	}
+
+	for name, expression := range taskConfig.Outputs {
+		expressions["outputs."+name] = expression
+	}
+
+	for field, expression := range expressions {
+		if err := jsonpath.New(field).Parse(expression); err != nil || len(expression) == 0 {
+			return nil, errors.Errorf("invalid expression [%v] for [%v]: %v", expression, field, err)
+		}
+	}
+
+	return &taskConfig, nil
+}
+
+func refersToResourceID(request Request) bool {
+	if resourceIDRegex.MatchString(request.URL) || resourceIDRegex.MatchString(request.Body) {
+		return true
+	}
+
+	for _, value := range request.Headers {
+		if resourceIDRegex.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// render renders the templates of the request. The id of the resource, if referred to, is left to be substituted once
+// known. Inputs are escaped in the body according to its content type.
+func render(ctx context.Context, request Request, params template.Parameters) (PreparedRequest, error) {
+	headerNames := make([]string, 0, len(request.Headers))
+	templates := []string{request.URL}
+	for name, value := range request.Headers {
+		headerNames = append(headerNames, name)
+		templates = append(templates, value)
+	}
+
+	rendered, err := template.Render(ctx, templates, params)
+	if err != nil {
+		return PreparedRequest{}, err
+	}
+
+	prepared := PreparedRequest{
+		Method:  request.Method,
+		URL:     rendered[0],
+		Headers: make(map[string]string, len(headerNames)),
+	}
+
+	for i, name := range headerNames {
+		prepared.Headers[name] = rendered[i+1]
+	}
+
+	if len(request.Body) > 0 {
+		params.EscapeInput = getBodyEscaper(prepared.Headers)
+		body, err := template.Render(ctx, []string{request.Body}, params)
+		if err != nil {
+			return PreparedRequest{}, err
+		}
+
+		prepared.Body = body[0]
+	}
+
+	return prepared, nil
+}
+
+// getBodyEscaper returns how to escape values substituted in the body given its content type, JSON unless a header
+// says otherwise. Values are substituted as they are in bodies of other types.
+func getBodyEscaper(headers map[string]string) func(string) string {
+	contentType := "application/json"
+	for name, value := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			contentType = value
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return escapeJSONString
+	case mediaType == "application/x-www-form-urlencoded":
+		return url.QueryEscape
+	default:
+		return nil
+	}
+}
+
+// escapeJSONString escapes the value so that it can be placed between the quotes of a JSON string.
+func escapeJSONString(value string) string {
+	escaped, _ := json.Marshal(value)
+	return string(escaped[1 : len(escaped)-1])
+}
+
+// withResourceID substitutes the id of the resource in the request. It's escaped when part of the url or the body.
+func (r PreparedRequest) withResourceID(resourceID string) PreparedRequest {
+	bodyResourceID := resourceID
+	if escape := getBodyEscaper(r.Headers); escape != nil {
+		bodyResourceID = escape(resourceID)
+	}
+
+	prepared := PreparedRequest{
+		Method:   r.Method,
+		URL:      resourceIDRegex.ReplaceAllLiteralString(r.URL, url.PathEscape(resourceID)),
+		Body:     resourceIDRegex.ReplaceAllLiteralString(r.Body, bodyResourceID),
+		Headers:  make(map[string]string, len(r.Headers)),
+		TokenKey: r.TokenKey,
+	}
+
+	for name, value := range r.Headers {
+		prepared.Headers[name] = resourceIDRegex.ReplaceAllLiteralString(value, resourceID)
+	}
+
+	return prepared
+}
+
+// getHost validates the scheme of the url of the request and returns its host.
+func (r PreparedRequest) getHost() (string, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.Errorf("unsupported scheme [%v] in url [%v]", u.Scheme, r.URL)
+	}
+
+	return u.Host, nil
+}
+
+// isAllowed checks whether the host, which may include a port, is in the allow-list. Entries without a port allow
+// any port.
+func isAllowed(allowedHosts []string, host string) bool {
+	hostname := getHostname(host)
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(allowed, host) || strings.EqualFold(allowed, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getTokenKey returns the key of the secret configured for the host, preferring an entry with a matching port over
+// one without.
+func getTokenKey(tokenKeys map[string]string, host string) (string, bool) {
+	if tokenKey, found := tokenKeys[host]; found {
+		return tokenKey, true
+	}
+
+	tokenKey, found := tokenKeys[getHostname(host)]
+	return tokenKey, found
+}
+
+func getHostname(host string) string {
+	if u, err := url.Parse("//" + host); err == nil {
+		return u.Hostname()
+	}
+
+	return host
+}
+
+// parseResponse parses a JSON response, keeping numbers as they are written.
+func parseResponse(body []byte) (interface{}, error) {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse response as JSON")
+	}
+
+	return data, nil
+}
+
+// evaluate evaluates the expression against the parsed response and returns its result as text.
+func evaluate(expression string, data interface{}) (string, error) {
+	j := jsonpath.New("")
+	if err := j.Parse(expression); err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	if err := j.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "failed to evaluate [%v]", expression)
+	}
+
+	return buf.String(), nil
+}
+
+// getOutputs extracts the outputs declared by the task from the response to the status request.
+func getOutputs(taskConfig *TaskConfig, outputs *flyteIdlCore.VariableMap, data interface{}) (
+	*flyteIdlCore.LiteralMap, error) {
+	literals := make(map[string]*flyteIdlCore.Literal, len(outputs.GetVariables()))
+	for name, variable := range outputs.GetVariables() {
+		expression, found := taskConfig.Outputs[name]
+		if !found {
+			return nil, errors.Errorf("no expression to extract output [%v]", name)
+		}
+
+		value, err := evaluate(expression, data)
+		if err != nil {
+			return nil, err
+		}
+
+		literal, err := pluginUtils.ParseLiteral(variable.GetType(), value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert output [%v]", name)
+		}
+
+		literals[name] = literal
+	}
+
+	return &flyteIdlCore.LiteralMap{Literals: literals}, nil
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTaskConfig() TaskConfig {
+	return TaskConfig{
+		Create:     Request{URL: "https://jobs.example.com/jobs"},
+		ResourceID: "{.id}",
+		Status:     Request{URL: "https://jobs.example.com/jobs/{{ .ResourceID }}"},
+		Phase: PhaseMapping{
+			State:     "{.state}",
+			Succeeded: []string{"DONE"},
+		},
+	}
+}
+
+func TestUnmarshalTaskConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		taskConfig := newTaskConfig()
+		taskConfig.Cancel = &Request{URL: "https://jobs.example.com/jobs/{{ .ResourceID }}"}
+		custom, _ := pluginUtils.MarshalObjToStruct(taskConfig)
+
+		unmarshalled, err := unmarshalTaskConfig(custom)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "POST", unmarshalled.Create.Method)
+		assert.Equal(t, "GET", unmarshalled.Status.Method)
+		assert.Equal(t, "DELETE", unmarshalled.Cancel.Method)
+	})
+
+	invalid := map[string]func(taskConfig *TaskConfig){
+		"missing url":            func(taskConfig *TaskConfig) { taskConfig.Status.URL = "" },
+		"no succeeded state":     func(taskConfig *TaskConfig) { taskConfig.Phase.Succeeded = nil },
+		"missing resource id":    func(taskConfig *TaskConfig) { taskConfig.ResourceID = "" },
+		"invalid expression":     func(taskConfig *TaskConfig) { taskConfig.Phase.State = "{.state" },
+		"invalid output":         func(taskConfig *TaskConfig) { taskConfig.Outputs = map[string]string{"x": "{["} },
+		"create with resourceId": func(taskConfig *TaskConfig) { taskConfig.Create.Body = "{{ .ResourceID }}" },
+	}
+
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			taskConfig := newTaskConfig()
+			mutate(&taskConfig)
+			custom, _ := pluginUtils.MarshalObjToStruct(taskConfig)
+
+			_, err := unmarshalTaskConfig(custom)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPreparedRequest_WithResourceID(t *testing.T) {
+	request := PreparedRequest{
+		Method:   "GET",
+		URL:      "https://jobs.example.com/jobs/{{ .ResourceID }}",
+		Headers:  map[string]string{"X-Job": "{{ .resourceId }}"},
+		Body:     `{"id": "{{.ResourceID}}"}`,
+		TokenKey: "token-key",
+	}
+
+	prepared := request.withResourceID("a/b")
+
+	assert.Equal(t, "https://jobs.example.com/jobs/a%2Fb", prepared.URL)
+	assert.Equal(t, "a/b", prepared.Headers["X-Job"])
+	assert.Equal(t, `{"id": "a/b"}`, prepared.Body)
+	assert.Equal(t, "token-key", prepared.TokenKey)
+	assert.Equal(t, "{{ .resourceId }}", request.Headers["X-Job"])
+
+	assert.Equal(t, `{"id": "a\"b"}`, request.withResourceID(`a"b`).Body)
+}
+
+func TestRender(t *testing.T) {
+	taskExecID := &coreMocks.TaskExecutionID{}
+	taskExecID.OnGetGeneratedName().Return("generated-name")
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(taskExecID)
+
+	inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"query": `say "hi" & bye`})
+	inputReader := &ioMocks.InputReader{}
+	inputReader.OnGetInputPath().Return("/inputs.pb")
+	inputReader.OnGetInputPrefixPath().Return("/")
+	inputReader.OnGetMatch(mock.Anything).Return(inputs, nil)
+
+	outputWriter := &ioMocks.OutputWriter{}
+	outputWriter.OnGetOutputPrefixPath().Return("/")
+	outputWriter.OnGetRawOutputPrefix().Return("/")
+
+	params := template.Parameters{TaskExecMetadata: tMeta, Inputs: inputReader, OutputPath: outputWriter}
+
+	for _, tc := range []struct {
+		contentType  string
+		body         string
+		expectedBody string
+	}{
+		{"", `{"query": "{{ .Inputs.query }}"}`, `{"query": "say \"hi\" \u0026 bye"}`},
+		{"application/vnd.api+json; charset=utf-8", `{"query": "{{ .Inputs.query }}"}`,
+			`{"query": "say \"hi\" \u0026 bye"}`},
+		{"application/x-www-form-urlencoded", `query={{ .Inputs.query }}`, `query=say+%22hi%22+%26+bye`},
+		{"text/plain", `{{ .Inputs.query }}`, `say "hi" & bye`},
+	} {
+		t.Run(tc.contentType, func(t *testing.T) {
+			request := Request{
+				Method:  "POST",
+				URL:     "https://jobs.example.com/jobs?name={{ .Inputs.query }}",
+				Headers: map[string]string{"X-Query": "{{ .Inputs.query }}"},
+				Body:    tc.body,
+			}
+
+			if len(tc.contentType) > 0 {
+				request.Headers["content-type"] = tc.contentType
+			}
+
+			prepared, err := render(context.Background(), request, params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, prepared.Body)
+			assert.Equal(t, `https://jobs.example.com/jobs?name=say "hi" & bye`, prepared.URL)
+			assert.Equal(t, `say "hi" & bye`, prepared.Headers["X-Query"])
+		})
+	}
+}
+
+func TestPreparedRequest_GetHost(t *testing.T) {
+	host, err := PreparedRequest{URL: "https://jobs.example.com:8443/jobs"}.getHost()
+	assert.NoError(t, err)
+	assert.Equal(t, "jobs.example.com:8443", host)
+
+	_, err = PreparedRequest{URL: "file:///etc/passwd"}.getHost()
+	assert.Error(t, err)
+}
+
+func TestIsAllowed(t *testing.T) {
+	allowedHosts := []string{"jobs.example.com", "api.example.com:8443"}
+
+	assert.True(t, isAllowed(allowedHosts, "jobs.example.com"))
+	assert.True(t, isAllowed(allowedHosts, "jobs.example.com:443"))
+	assert.True(t, isAllowed(allowedHosts, "API.example.com:8443"))
+	assert.False(t, isAllowed(allowedHosts, "api.example.com"))
+	assert.False(t, isAllowed(allowedHosts, "jobs.example.com.evil.com"))
+	assert.False(t, isAllowed(nil, "jobs.example.com"))
+}
+
+func TestGetTokenKey(t *testing.T) {
+	tokenKeys := map[string]string{"jobs.example.com": "any-port", "jobs.example.com:8443": "port"}
+
+	tokenKey, found := getTokenKey(tokenKeys, "jobs.example.com:8443")
+	assert.True(t, found)
+	assert.Equal(t, "port", tokenKey)
+
+	tokenKey, found = getTokenKey(tokenKeys, "jobs.example.com:443")
+	assert.True(t, found)
+	assert.Equal(t, "any-port", tokenKey)
+
+	_, found = getTokenKey(tokenKeys, "api.example.com")
+	assert.False(t, found)
+}
+
+func TestEvaluate(t *testing.T) {
+	response, err := parseResponse([]byte(`{"state": "DONE", "result": {"count": 12345678901234, "ratio": 0.5}}`))
+	assert.NoError(t, err)
+
+	value, err := evaluate("{.state}", response)
+	assert.NoError(t, err)
+	assert.Equal(t, "DONE", value)
+
+	value, err = evaluate("{.result.count}", response)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234", value)
+
+	_, err = evaluate("{.missing}", response)
+	assert.Error(t, err)
+
+	_, err = parseResponse([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestGetOutputs(t *testing.T) {
+	response, _ := parseResponse([]byte(`{"result": {"count": 3, "ratio": 0.5, "ok": true, "name": "x"}}`))
+	taskConfig := &TaskConfig{Outputs: map[string]string{
+		"count": "{.result.count}",
+		"ratio": "{.result.ratio}",
+		"ok":    "{.result.ok}",
+		"name":  "{.result.name}",
+	}}
+
+	newVariable := func(simpleType flyteIdlCore.SimpleType) *flyteIdlCore.Variable {
+		return &flyteIdlCore.Variable{Type: &flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_Simple{Simple: simpleType}}}
+	}
+
+	outputs := &flyteIdlCore.VariableMap{Variables: map[string]*flyteIdlCore.Variable{
+		"count": newVariable(flyteIdlCore.SimpleType_INTEGER),
+		"ratio": newVariable(flyteIdlCore.SimpleType_FLOAT),
+		"ok":    newVariable(flyteIdlCore.SimpleType_BOOLEAN),
+		"name":  newVariable(flyteIdlCore.SimpleType_STRING),
+	}}
+
+	literals, err := getOutputs(taskConfig, outputs, response)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), literals.Literals["count"].GetScalar().GetPrimitive().GetInteger())
+	assert.Equal(t, 0.5, literals.Literals["ratio"].GetScalar().GetPrimitive().GetFloatValue())
+	assert.Equal(t, true, literals.Literals["ok"].GetScalar().GetPrimitive().GetBoolean())
+	assert.Equal(t, "x", literals.Literals["name"].GetScalar().GetPrimitive().GetStringValue())
+
+	t.Run("undeclared output", func(t *testing.T) {
+		outputs := &flyteIdlCore.VariableMap{Variables: map[string]*flyteIdlCore.Variable{
+			"other": newVariable(flyteIdlCore.SimpleType_STRING),
+		}}
+
+		_, err := getOutputs(taskConfig, outputs, response)
+		assert.Error(t, err)
+	})
+}
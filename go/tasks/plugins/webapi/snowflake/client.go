@@ -0,0 +1,152 @@
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const (
+	statementsPath = "/api/v2/statements"
+
+	// Snowflake returns 202 while the statement is running, 200 once it has succeeded and 422 if it has failed.
+	statusRunning   = http.StatusAccepted
+	statusSucceeded = http.StatusOK
+	statusFailed    = http.StatusUnprocessableEntity
+)
+
+type statementRequest struct {
+	Statement string             `json:"statement"`
+	Timeout   int64              `json:"timeout,omitempty"`
+	Database  string             `json:"database,omitempty"`
+	Schema    string             `json:"schema,omitempty"`
+	Warehouse string             `json:"warehouse,omitempty"`
+	Role      string             `json:"role,omitempty"`
+	Bindings  map[string]Binding `json:"bindings,omitempty"`
+}
+
+type rowType struct {
+	Name string `json:"name"`
+}
+
+type resultSetMetaData struct {
+	NumRows int64     `json:"numRows"`
+	RowType []rowType `json:"rowType"`
+}
+
+type statementResponse struct {
+	Code              string             `json:"code"`
+	Message           string             `json:"message"`
+	SQLState          string             `json:"sqlState"`
+	StatementHandle   string             `json:"statementHandle"`
+	ResultSetMetaData *resultSetMetaData `json:"resultSetMetaData,omitempty"`
+	Data              [][]*string        `json:"data,omitempty"`
+}
+
+// client calls the Snowflake SQL API of an account, authenticating with a key-pair JWT.
+type client struct {
+	httpClient *http.Client
+	endpoint   string
+	token      string
+}
+
+// submit submits the statement for asynchronous execution. requestID makes resubmissions idempotent.
+func (c client) submit(ctx context.Context, requestID string, request statementRequest) (statementResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return statementResponse{}, err
+	}
+
+	query := url.Values{}
+	query.Set("async", "true")
+	query.Set("requestId", requestID)
+	resp, statusCode, err := c.do(ctx, http.MethodPost, statementsPath+"?"+query.Encode(), body)
+	if err != nil {
+		return statementResponse{}, err
+	}
+
+	if statusCode != statusRunning && statusCode != statusSucceeded {
+		return statementResponse{}, newAPIError(statusCode, resp)
+	}
+
+	return resp, nil
+}
+
+// get retrieves the status of the statement and, once it has succeeded, the first partition of its result set.
+func (c client) get(ctx context.Context, statementHandle string) (resp statementResponse, statusCode int, err error) {
+	resp, statusCode, err = c.do(ctx, http.MethodGet, statementsPath+"/"+url.PathEscape(statementHandle), nil)
+	if err != nil {
+		return statementResponse{}, 0, err
+	}
+
+	if statusCode != statusRunning && statusCode != statusSucceeded && statusCode != statusFailed {
+		return statementResponse{}, 0, newAPIError(statusCode, resp)
+	}
+
+	return resp, statusCode, nil
+}
+
+func (c client) cancel(ctx context.Context, statementHandle string) error {
+	resp, statusCode, err := c.do(ctx, http.MethodPost,
+		statementsPath+"/"+url.PathEscape(statementHandle)+"/cancel", nil)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		return newAPIError(statusCode, resp)
+	}
+
+	return nil
+}
+
+func (c client) do(ctx context.Context, method, path string, body []byte) (resp statementResponse, statusCode int,
+	err error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return statementResponse{}, 0, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", "KEYPAIR_JWT")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "flytepropeller")
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return statementResponse{}, 0, err
+	}
+
+	defer httpResp.Body.Close()
+	raw, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return statementResponse{}, 0, err
+	}
+
+	if len(raw) > 0 {
+		// Error responses don't always have a JSON body, keep the status code in that case.
+		_ = json.Unmarshal(raw, &resp)
+	}
+
+	return resp, httpResp.StatusCode, nil
+}
+
+// apiError is returned when Snowflake answers with an unexpected status code.
+type apiError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e apiError) Error() string {
+	return fmt.Sprintf("snowflake returned status [%v], code [%v]: %v", e.StatusCode, e.Code, e.Message)
+}
+
+func newAPIError(statusCode int, resp statementResponse) error {
+	return apiError{StatusCode: statusCode, Code: resp.Code, Message: resp.Message}
+}
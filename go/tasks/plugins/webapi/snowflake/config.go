@@ -0,0 +1,81 @@
+// Package snowflake implements WebAPI plugin for Snowflake using the Snowflake SQL API
+package snowflake
+
+import (
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"default": 1000,
+			},
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+			},
+			ResourceMeta: nil,
+		},
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+		TokenKey: "FLYTE_SNOWFLAKE_CLIENT_TOKEN",
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("snowflake", &defaultConfig)
+)
+
+// Config is config for 'snowflake' plugin
+type Config struct {
+	// WebAPI defines config for the base WebAPI plugin
+	WebAPI webapi.PluginConfig `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+
+	// ResourceConstraints defines resource constraints on how many executions to be created per project/overall at any given time
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// Account is the identifier of the Snowflake account statements are submitted to
+	Account string `json:"account" pflag:",Defines the Snowflake account identifier (e.g. myorg-myaccount) to submit statements to."`
+
+	// DefaultWarehouse is the warehouse statements run in unless overridden by the task
+	DefaultWarehouse string `json:"defaultWarehouse" pflag:",Defines the default warehouse to use when running on Snowflake unless overwritten by the task."`
+
+	// DefaultRole is the role statements run as unless overridden by the task
+	DefaultRole string `json:"defaultRole" pflag:",Defines the default role to use when running on Snowflake unless overwritten by the task."`
+
+	// TokenKey is the key of the secret that holds the key-pair JWT used to authenticate to Snowflake
+	TokenKey string `json:"tokenKey" pflag:",Defines the key of the secret holding the key-pair JWT used to authenticate to Snowflake."`
+
+	// snowflakeEndpoint overrides the Snowflake SQL API endpoint, only for testing
+	snowflakeEndpoint string
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+func SetConfig(cfg *Config) error {
+	return configSection.SetConfig(cfg)
+}
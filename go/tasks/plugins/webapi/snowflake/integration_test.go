@@ -0,0 +1,153 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	pluginCoreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/tests"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndToEnd(t *testing.T) {
+	server := newFakeSnowflakeServer()
+	defer server.Close()
+
+	iter := func(ctx context.Context, tCtx pluginCore.TaskExecutionContext) error {
+		return nil
+	}
+
+	cfg := defaultConfig
+	cfg.Account = "test-account"
+	cfg.snowflakeEndpoint = server.URL
+	cfg.WebAPI.Caching.Workers = 1
+	cfg.WebAPI.Caching.ResyncInterval.Duration = 5 * time.Second
+	err := SetConfig(&cfg)
+	assert.NoError(t, err)
+
+	pluginEntry := pluginmachinery.CreateRemotePlugin(newSnowflakeJobTaskPlugin())
+	plugin, err := pluginEntry.LoadPlugin(context.TODO(), newFakeSetupContext())
+	assert.NoError(t, err)
+
+	t.Run("SELECT 1", func(t *testing.T) {
+		queryJobConfig := QueryJobConfig{
+			Statement: "SELECT ? AS x",
+			Bindings:  []string{"x"},
+		}
+
+		inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+		custom, _ := pluginUtils.MarshalObjToStruct(queryJobConfig)
+		template := flyteIdlCore.TaskTemplate{
+			Type:   snowflakeTaskType,
+			Custom: custom,
+			Interface: &flyteIdlCore.TypedInterface{
+				Outputs: &flyteIdlCore.VariableMap{
+					Variables: map[string]*flyteIdlCore.Variable{
+						"x": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Simple{
+							Simple: flyteIdlCore.SimpleType_INTEGER}}},
+					},
+				},
+			},
+		}
+
+		expectedOutputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{"x": 1})
+		phase := tests.RunPluginEndToEndTest(t, plugin, &template, inputs, expectedOutputs, nil, iter)
+
+		assert.Equal(t, true, phase.Phase().IsSuccess())
+	})
+}
+
+// newFakeSnowflakeServer runs submitted statements of the form "SELECT ? AS <column>", returning the bound value.
+func newFakeSnowflakeServer() *httptest.Server {
+	var lock sync.Mutex
+	statements := map[string]statementRequest{}
+	polled := map[string]bool{}
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if request.Header.Get("Authorization") != "Bearer fake-secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writeResponse := func(statusCode int, resp statementResponse) {
+			writer.WriteHeader(statusCode)
+			bytes, _ := json.Marshal(resp)
+			_, _ = writer.Write(bytes)
+		}
+
+		if request.URL.Path == statementsPath && request.Method == http.MethodPost {
+			statement := statementRequest{}
+			_ = json.NewDecoder(request.Body).Decode(&statement)
+			handle := request.URL.Query().Get("requestId")
+			statements[handle] = statement
+			writeResponse(http.StatusAccepted, statementResponse{Code: "333334", StatementHandle: handle})
+			return
+		}
+
+		handle := strings.TrimPrefix(request.URL.Path, statementsPath+"/")
+		if strings.HasSuffix(handle, "/cancel") && request.Method == http.MethodPost {
+			writeResponse(http.StatusOK, statementResponse{})
+			return
+		}
+
+		statement, found := statements[handle]
+		if !found || request.Method != http.MethodGet {
+			writeResponse(http.StatusNotFound, statementResponse{Message: "not found"})
+			return
+		}
+
+		// Report the statement as running the first time it's polled.
+		if !polled[handle] {
+			polled[handle] = true
+			writeResponse(http.StatusAccepted, statementResponse{Code: "333334", StatementHandle: handle})
+			return
+		}
+
+		column := strings.TrimPrefix(statement.Statement, "SELECT ? AS ")
+		value := statement.Bindings["1"].Value
+		writeResponse(http.StatusOK, statementResponse{
+			Code:            "090001",
+			StatementHandle: handle,
+			ResultSetMetaData: &resultSetMetaData{
+				NumRows: 1,
+				RowType: []rowType{{Name: strings.ToUpper(column)}},
+			},
+			Data: [][]*string{{&value}},
+		})
+	}))
+}
+
+func newFakeSetupContext() *pluginCoreMocks.SetupContext {
+	fakeResourceRegistrar := pluginCoreMocks.ResourceRegistrar{}
+	fakeResourceRegistrar.On("RegisterResourceQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	labeled.SetMetricKeys(contextutils.NamespaceKey)
+
+	fakeSetupContext := pluginCoreMocks.SetupContext{}
+	fakeSetupContext.OnMetricsScope().Return(promutils.NewScope("test"))
+	fakeSetupContext.OnResourceRegistrar().Return(&fakeResourceRegistrar)
+
+	secretManager := &pluginCoreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, mock.Anything).Return("fake-secret", nil)
+	fakeSetupContext.OnSecretManager().Return(secretManager)
+
+	return &fakeSetupContext
+}
@@ -0,0 +1,329 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/gob"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+const (
+	snowflakeTaskType = "snowflake"
+
+	// Returned by Snowflake when a statement is canceled.
+	statementCanceledCode = "000604"
+)
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	secretManager core.SecretManager
+	httpClient    *http.Client
+}
+
+// ResourceMetaWrapper identifies a statement and holds what's needed to interact with it.
+type ResourceMetaWrapper struct {
+	StatementHandle string
+	Account         string
+	Warehouse       string
+	Database        string
+	Schema          string
+}
+
+// ResourceWrapper only keeps the status of the statement and the first row of its result set.
+type ResourceWrapper struct {
+	StatusCode int
+	Code       string
+	Message    string
+	SQLState   string
+	NumRows    int64
+	Columns    []string
+	FirstRow   []*string
+}
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	return GetConfig().WebAPI
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) Create(ctx context.Context, taskCtx webapi.TaskExecutionContextReader) (webapi.ResourceMeta,
+	webapi.Resource, error) {
+
+	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to fetch task specification")
+	}
+
+	queryJobConfig, err := unmarshalQueryJobConfig(taskTemplate.GetCustom())
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "can't unmarshall struct to QueryJobConfig")
+	}
+
+	inputs, err := taskCtx.InputReader().Get(ctx)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to fetch task inputs")
+	}
+
+	bindings, err := getBindings(queryJobConfig, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := statementRequest{
+		Statement: queryJobConfig.Statement,
+		Timeout:   queryJobConfig.Timeout,
+		Database:  queryJobConfig.Database,
+		Schema:    queryJobConfig.Schema,
+		Warehouse: queryJobConfig.Warehouse,
+		Role:      queryJobConfig.Role,
+		Bindings:  bindings,
+	}
+
+	if len(request.Warehouse) == 0 {
+		request.Warehouse = p.cfg.DefaultWarehouse
+	}
+
+	if len(request.Role) == 0 {
+		request.Role = p.cfg.DefaultRole
+	}
+
+	// The request id is derived from the task execution so that Snowflake doesn't run the statement twice if it's
+	// submitted again.
+	generatedName := taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	requestID := uuid.NewSHA1(uuid.NameSpaceOID, []byte(p.cfg.Account+"/"+generatedName)).String()
+	resp, err := p.newClient(token).submit(ctx, requestID, request)
+	if err != nil {
+		return nil, nil, pluginErrors.Wrapf(getSubmitErrorCode(err), err, "failed to submit statement")
+	}
+
+	logger.Infof(ctx, "Submitted statement [%v] for [%v]", resp.StatementHandle, generatedName)
+
+	return &ResourceMetaWrapper{
+		StatementHandle: resp.StatementHandle,
+		Account:         p.cfg.Account,
+		Warehouse:       request.Warehouse,
+		Database:        request.Database,
+		Schema:          request.Schema,
+	}, nil, nil
+}
+
+func (p Plugin) Get(ctx context.Context, taskCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, statusCode, err := p.newClient(token).get(ctx, resourceMeta.StatementHandle)
+	if err != nil {
+		return nil, pluginErrors.Wrapf(pluginErrors.DownstreamSystemError, err, "failed to get statement [%v]",
+			resourceMeta.StatementHandle)
+	}
+
+	resource := &ResourceWrapper{
+		StatusCode: statusCode,
+		Code:       resp.Code,
+		Message:    resp.Message,
+		SQLState:   resp.SQLState,
+	}
+
+	if metaData := resp.ResultSetMetaData; metaData != nil {
+		resource.NumRows = metaData.NumRows
+		resource.Columns = make([]string, 0, len(metaData.RowType))
+		for _, column := range metaData.RowType {
+			resource.Columns = append(resource.Columns, column.Name)
+		}
+	}
+
+	if len(resp.Data) > 0 {
+		resource.FirstRow = resp.Data[0]
+	}
+
+	return resource, nil
+}
+
+func (p Plugin) Delete(ctx context.Context, taskCtx webapi.DeleteContext) error {
+	if taskCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = p.newClient(token).cancel(ctx, resourceMeta.StatementHandle)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Cancelled statement [%v]", resourceMeta.StatementHandle)
+
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, taskCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resourceMeta := taskCtx.ResourceMeta().(*ResourceMetaWrapper)
+	resource := taskCtx.Resource().(*ResourceWrapper)
+	taskInfo := createTaskInfo(resourceMeta)
+
+	switch resource.StatusCode {
+	case statusRunning:
+		return core.PhaseInfoRunning(core.DefaultPhaseVersion, taskInfo), nil
+	case statusFailed:
+		if resource.Code == statementCanceledCode {
+			return core.PhaseInfoRetryableFailure("ABORTED", resource.Message, taskInfo), nil
+		}
+
+		return core.PhaseInfoFailure(resource.Code, fmt.Sprintf("%v (SQL state %v)", resource.Message,
+			resource.SQLState), taskInfo), nil
+	case statusSucceeded:
+		return p.writeOutputs(ctx, taskCtx, resourceMeta, resource, taskInfo)
+	}
+
+	return core.PhaseInfoUndefined, pluginErrors.Errorf(pluginErrors.DownstreamSystemError,
+		"unexpected status code [%v]", resource.StatusCode)
+}
+
+func (p Plugin) writeOutputs(ctx context.Context, taskCtx webapi.StatusContext, resourceMeta *ResourceMetaWrapper,
+	resource *ResourceWrapper, taskInfo *core.TaskInfo) (core.PhaseInfo, error) {
+
+	taskTemplate, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	if len(taskTemplate.GetInterface().GetOutputs().GetVariables()) == 0 {
+		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
+		return core.PhaseInfoSuccess(taskInfo), nil
+	}
+
+	outputs, err := getOutputs(taskTemplate.GetInterface().GetOutputs(), formatResultURI(resourceMeta), resource)
+	if err != nil {
+		return core.PhaseInfoFailure(pluginErrors.BadTaskSpecification, err.Error(), taskInfo), nil
+	}
+
+	err = taskCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(outputs, nil))
+	if err != nil {
+		return core.PhaseInfoUndefined, err
+	}
+
+	return core.PhaseInfoSuccess(taskInfo), nil
+}
+
+// getToken reads the token from the secret manager on every call rather than keeping it in the resource meta, which
+// is persisted in the plugin state. This also picks up rotated tokens while the statement runs.
+func (p Plugin) getToken(ctx context.Context) (string, error) {
+	token, err := p.secretManager.Get(ctx, p.cfg.TokenKey)
+	if err != nil {
+		return "", pluginErrors.Wrapf(pluginErrors.RuntimeFailure, err, "unable to get snowflake token")
+	}
+
+	return token, nil
+}
+
+// getSubmitErrorCode treats statements Snowflake rejects as bad task specifications. Throttling, server and transport
+// errors are left to be retried.
+func getSubmitErrorCode(err error) errors.ErrorCode {
+	var apiErr apiError
+	if stdErrors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusBadRequest &&
+		apiErr.StatusCode < http.StatusInternalServerError && apiErr.StatusCode != http.StatusTooManyRequests {
+		return pluginErrors.BadTaskSpecification
+	}
+
+	return pluginErrors.DownstreamSystemError
+}
+
+func (p Plugin) newClient(token string) client {
+	endpoint := p.cfg.snowflakeEndpoint
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("https://%v.snowflakecomputing.com", p.cfg.Account)
+	}
+
+	return client{
+		httpClient: p.httpClient,
+		endpoint:   endpoint,
+		token:      token,
+	}
+}
+
+func formatResultURI(resourceMeta *ResourceMetaWrapper) string {
+	return fmt.Sprintf("snowflake://%v/%v/%v/%v/%v", resourceMeta.Account, resourceMeta.Warehouse,
+		resourceMeta.Database, resourceMeta.Schema, resourceMeta.StatementHandle)
+}
+
+func createTaskInfo(resourceMeta *ResourceMetaWrapper) *core.TaskInfo {
+	timeNow := time.Now()
+
+	return &core.TaskInfo{
+		OccurredAt: &timeNow,
+		Logs: []*flyteIdlCore.TaskLog{
+			{
+				Uri: fmt.Sprintf("https://%v.snowflakecomputing.com/console#/monitoring/queries/detail?queryId=%v",
+					resourceMeta.Account,
+					resourceMeta.StatementHandle),
+				Name: "Snowflake Console",
+			},
+		},
+		Metadata: &event.TaskExecutionMetadata{
+			ExternalResources: []*event.ExternalResourceInfo{
+				{
+					ExternalId: resourceMeta.StatementHandle,
+				},
+			},
+		},
+	}
+}
+
+func NewPlugin(cfg *Config, secretManager core.SecretManager, metricScope promutils.Scope) *Plugin {
+	return &Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		secretManager: secretManager,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func newSnowflakeJobTaskPlugin() webapi.PluginEntry {
+	return webapi.PluginEntry{
+		ID:                 "snowflake",
+		SupportedTaskTypes: []core.TaskType{snowflakeTaskType},
+		PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+			return NewPlugin(GetConfig(), iCtx.SecretManager(), iCtx.MetricsScope()), nil
+		},
+	}
+}
+
+func init() {
+	gob.Register(ResourceMetaWrapper{})
+	gob.Register(ResourceWrapper{})
+
+	pluginmachinery.PluginRegistry().RegisterRemotePlugin(newSnowflakeJobTaskPlugin())
+}
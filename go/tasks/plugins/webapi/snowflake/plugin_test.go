@@ -0,0 +1,112 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTaskInfo(t *testing.T) {
+	taskInfo := createTaskInfo(&ResourceMetaWrapper{StatementHandle: "handle", Account: "test-account"})
+
+	assert.Len(t, taskInfo.Logs, 1)
+	assert.Equal(t, "https://test-account.snowflakecomputing.com/console#/monitoring/queries/detail?queryId=handle",
+		taskInfo.Logs[0].Uri)
+	assert.Equal(t, "handle", taskInfo.Metadata.ExternalResources[0].ExternalId)
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{}
+	resourceMeta := &ResourceMetaWrapper{StatementHandle: "handle", Account: "test-account"}
+
+	newStatusContext := func(resource *ResourceWrapper) *mocks.StatusContext {
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnReadMatch(mock.Anything).Return(&flyteIdlCore.TaskTemplate{}, nil)
+
+		tCtx := &mocks.StatusContext{}
+		tCtx.OnResourceMeta().Return(resourceMeta)
+		tCtx.OnResource().Return(resource)
+		tCtx.OnTaskReader().Return(taskReader)
+		return tCtx
+	}
+
+	t.Run("running", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(&ResourceWrapper{StatusCode: statusRunning}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRunning, phase.Phase())
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(&ResourceWrapper{StatusCode: statusSucceeded}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseSuccess, phase.Phase())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(&ResourceWrapper{
+			StatusCode: statusFailed,
+			Code:       "002003",
+			Message:    "Table 'T' does not exist",
+			SQLState:   "42S02",
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phase.Phase())
+		assert.Equal(t, "002003", phase.Err().Code)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		phase, err := p.Status(ctx, newStatusContext(&ResourceWrapper{
+			StatusCode: statusFailed,
+			Code:       statementCanceledCode,
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseRetryableFailure, phase.Phase())
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		_, err := p.Status(ctx, newStatusContext(&ResourceWrapper{StatusCode: 500}))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSubmitErrorCode(t *testing.T) {
+	assert.Equal(t, pluginErrors.BadTaskSpecification, getSubmitErrorCode(apiError{StatusCode: http.StatusUnprocessableEntity}))
+	assert.Equal(t, pluginErrors.BadTaskSpecification, getSubmitErrorCode(apiError{StatusCode: http.StatusBadRequest}))
+	assert.Equal(t, pluginErrors.DownstreamSystemError, getSubmitErrorCode(apiError{StatusCode: http.StatusTooManyRequests}))
+	assert.Equal(t, pluginErrors.DownstreamSystemError, getSubmitErrorCode(apiError{StatusCode: http.StatusServiceUnavailable}))
+	assert.Equal(t, pluginErrors.DownstreamSystemError, getSubmitErrorCode(fmt.Errorf("connection refused")))
+}
+
+func TestDelete(t *testing.T) {
+	server := newFakeSnowflakeServer()
+	defer server.Close()
+
+	secretManager := &coreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "snowflake-token").Return("fake-secret", nil)
+	p := Plugin{
+		cfg:           &Config{TokenKey: "snowflake-token", snowflakeEndpoint: server.URL},
+		secretManager: secretManager,
+		httpClient:    server.Client(),
+	}
+
+	tCtx := &mocks.DeleteContext{}
+	tCtx.OnResourceMeta().Return(&ResourceMetaWrapper{StatementHandle: "handle"})
+
+	assert.NoError(t, p.Delete(context.Background(), tCtx))
+	secretManager.AssertExpectations(t)
+}
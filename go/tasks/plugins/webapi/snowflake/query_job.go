@@ -0,0 +1,156 @@
+package snowflake
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginUtils "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// QueryJobConfig is the custom part of snowflake task templates.
+type QueryJobConfig struct {
+	// Statement is the SQL statement to execute. Inputs listed in Bindings are bound, in order, to its ? placeholders.
+	Statement string `json:"statement"`
+
+	// Bindings lists the names of the inputs to bind to the statement placeholders.
+	Bindings []string `json:"bindings,omitempty"`
+
+	// Warehouse overrides the warehouse configured for the plugin.
+	Warehouse string `json:"warehouse,omitempty"`
+
+	// Role overrides the role configured for the plugin.
+	Role string `json:"role,omitempty"`
+
+	Database string `json:"database,omitempty"`
+	Schema   string `json:"schema,omitempty"`
+
+	// Timeout in seconds after which Snowflake cancels the statement. Zero means Snowflake's default is used.
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// Binding is the value bound to a statement placeholder, as expected by the Snowflake SQL API.
+type Binding struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func unmarshalQueryJobConfig(structObj *structpb.Struct) (*QueryJobConfig, error) {
+	queryJobConfig := QueryJobConfig{}
+	err := pluginUtils.UnmarshalStructToObj(structObj, &queryJobConfig)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal QueryJobConfig")
+	}
+
+	if len(queryJobConfig.Statement) == 0 {
+		return nil, errors.New("statement is a required field")
+	}
+
+	return &queryJobConfig, nil
+}
+
+// getBindings returns the bindings for the inputs named in the job config, keyed by their 1-based position.
+func getBindings(custom *QueryJobConfig, inputs *flyteIdlCore.LiteralMap) (map[string]Binding, error) {
+	if len(custom.Bindings) == 0 {
+		return nil, nil
+	}
+
+	bindings := make(map[string]Binding, len(custom.Bindings))
+	for i, name := range custom.Bindings {
+		literal, found := inputs.GetLiterals()[name]
+		if !found {
+			return nil, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "input [%v] to bind not found", name)
+		}
+
+		binding, err := getBinding(literal)
+		if err != nil {
+			return nil, err
+		}
+
+		bindings[strconv.Itoa(i+1)] = binding
+	}
+
+	return bindings, nil
+}
+
+func getBinding(literal *flyteIdlCore.Literal) (Binding, error) {
+	if primitive := literal.GetScalar().GetPrimitive(); primitive != nil {
+		switch primitive.Value.(type) {
+		case *flyteIdlCore.Primitive_Integer:
+			return Binding{Type: "FIXED", Value: strconv.FormatInt(primitive.GetInteger(), 10)}, nil
+
+		case *flyteIdlCore.Primitive_StringValue:
+			return Binding{Type: "TEXT", Value: primitive.GetStringValue()}, nil
+
+		case *flyteIdlCore.Primitive_FloatValue:
+			return Binding{Type: "REAL", Value: strconv.FormatFloat(primitive.GetFloatValue(), 'f', -1, 64)}, nil
+
+		case *flyteIdlCore.Primitive_Boolean:
+			return Binding{Type: "BOOLEAN", Value: strconv.FormatBool(primitive.GetBoolean())}, nil
+		}
+	}
+
+	return Binding{}, pluginErrors.Errorf(pluginErrors.BadTaskSpecification, "unsupported literal [%v]", literal)
+}
+
+// getOutputs builds the outputs declared by the task. Schema outputs point to the result of the statement. Other
+// outputs are read from the first row of the result set, from the column with the same name, compared
+// case-insensitively since Snowflake upper-cases unquoted identifiers.
+func getOutputs(outputs *flyteIdlCore.VariableMap, resultURI string, resource *ResourceWrapper) (
+	*flyteIdlCore.LiteralMap, error) {
+	literals := make(map[string]*flyteIdlCore.Literal, len(outputs.GetVariables()))
+	for name, variable := range outputs.GetVariables() {
+		if schemaType := variable.GetType().GetSchema(); schemaType != nil {
+			literals[name] = &flyteIdlCore.Literal{
+				Value: &flyteIdlCore.Literal_Scalar{
+					Scalar: &flyteIdlCore.Scalar{
+						Value: &flyteIdlCore.Scalar_Schema{
+							Schema: &flyteIdlCore.Schema{Uri: resultURI, Type: schemaType},
+						},
+					},
+				},
+			}
+
+			continue
+		}
+
+		value, err := getColumnValue(name, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		literal, err := pluginUtils.ParseLiteral(variable.GetType(), value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert column [%v]", name)
+		}
+
+		literals[name] = literal
+	}
+
+	return &flyteIdlCore.LiteralMap{Literals: literals}, nil
+}
+
+func getColumnValue(name string, resource *ResourceWrapper) (string, error) {
+	if len(resource.FirstRow) == 0 {
+		return "", errors.Errorf("statement returned no rows to read output [%v] from", name)
+	}
+
+	for i, column := range resource.Columns {
+		if !strings.EqualFold(column, name) {
+			continue
+		}
+
+		if i >= len(resource.FirstRow) || resource.FirstRow[i] == nil {
+			return "", errors.Errorf("column [%v] is null", column)
+		}
+
+		return *resource.FirstRow[i], nil
+	}
+
+	return "", errors.Errorf("statement returned no column for output [%v]", name)
+}
@@ -0,0 +1,140 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	flyteIdlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestUnmarshalQueryJobConfig(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		custom := structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"statement": structpb.NewStringValue("SELECT ?"),
+				"bindings":  structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("x")}}),
+				"warehouse": structpb.NewStringValue("compute_wh"),
+			},
+		}
+
+		config, err := unmarshalQueryJobConfig(&custom)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &QueryJobConfig{
+			Statement: "SELECT ?",
+			Bindings:  []string{"x"},
+			Warehouse: "compute_wh",
+		}, config)
+	})
+
+	t.Run("missing statement", func(t *testing.T) {
+		_, err := unmarshalQueryJobConfig(&structpb.Struct{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetBindings(t *testing.T) {
+	inputs, _ := coreutils.MakeLiteralMap(map[string]interface{}{
+		"i": 1,
+		"s": "abc",
+		"f": 1.5,
+		"b": true,
+	})
+
+	t.Run("bindings in order", func(t *testing.T) {
+		bindings, err := getBindings(&QueryJobConfig{Bindings: []string{"s", "i", "f", "b"}}, inputs)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Binding{
+			"1": {Type: "TEXT", Value: "abc"},
+			"2": {Type: "FIXED", Value: "1"},
+			"3": {Type: "REAL", Value: "1.5"},
+			"4": {Type: "BOOLEAN", Value: "true"},
+		}, bindings)
+	})
+
+	t.Run("no bindings", func(t *testing.T) {
+		bindings, err := getBindings(&QueryJobConfig{}, inputs)
+
+		assert.NoError(t, err)
+		assert.Nil(t, bindings)
+	})
+
+	t.Run("missing input", func(t *testing.T) {
+		_, err := getBindings(&QueryJobConfig{Bindings: []string{"missing"}}, inputs)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetOutputs(t *testing.T) {
+	simple := func(simpleType flyteIdlCore.SimpleType) *flyteIdlCore.Variable {
+		return &flyteIdlCore.Variable{Type: &flyteIdlCore.LiteralType{
+			Type: &flyteIdlCore.LiteralType_Simple{Simple: simpleType}}}
+	}
+
+	value := func(v string) *string {
+		return &v
+	}
+
+	resource := &ResourceWrapper{
+		Columns:  []string{"COUNT", "NAME", "RATIO", "VALID", "EMPTY"},
+		FirstRow: []*string{value("3"), value("abc"), value("0.5"), value("true"), nil},
+	}
+
+	t.Run("typed outputs", func(t *testing.T) {
+		outputs, err := getOutputs(&flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{
+				"count": simple(flyteIdlCore.SimpleType_INTEGER),
+				"name":  simple(flyteIdlCore.SimpleType_STRING),
+				"ratio": simple(flyteIdlCore.SimpleType_FLOAT),
+				"valid": simple(flyteIdlCore.SimpleType_BOOLEAN),
+			},
+		}, "snowflake://uri", resource)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), outputs.Literals["count"].GetScalar().GetPrimitive().GetInteger())
+		assert.Equal(t, "abc", outputs.Literals["name"].GetScalar().GetPrimitive().GetStringValue())
+		assert.Equal(t, 0.5, outputs.Literals["ratio"].GetScalar().GetPrimitive().GetFloatValue())
+		assert.True(t, outputs.Literals["valid"].GetScalar().GetPrimitive().GetBoolean())
+	})
+
+	t.Run("result location", func(t *testing.T) {
+		outputs, err := getOutputs(&flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{
+				"results": {Type: &flyteIdlCore.LiteralType{Type: &flyteIdlCore.LiteralType_Schema{
+					Schema: &flyteIdlCore.SchemaType{}}}},
+			},
+		}, "snowflake://uri", &ResourceWrapper{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "snowflake://uri", outputs.Literals["results"].GetScalar().GetSchema().GetUri())
+	})
+
+	t.Run("null column", func(t *testing.T) {
+		_, err := getOutputs(&flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{"empty": simple(flyteIdlCore.SimpleType_STRING)},
+		}, "", resource)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := getOutputs(&flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{"missing": simple(flyteIdlCore.SimpleType_STRING)},
+		}, "", resource)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := getOutputs(&flyteIdlCore.VariableMap{
+			Variables: map[string]*flyteIdlCore.Variable{"name": simple(flyteIdlCore.SimpleType_INTEGER)},
+		}, "", resource)
+
+		assert.Error(t, err)
+	})
+}
@@ -166,6 +166,7 @@ func RunPluginEndToEndTest(t *testing.T, executor pluginCore.Plugin, template *i
 	tMeta.OnGetLabels().Return(map[string]string{})
 	tMeta.OnGetAnnotations().Return(map[string]string{})
 	tMeta.OnIsInterruptible().Return(true)
+	tMeta.OnGetMaxAttempts().Return(2)
 	tMeta.OnGetOwnerReference().Return(v12.OwnerReference{})
 	tMeta.OnGetOwnerID().Return(types.NamespacedName{
 		Namespace: "fake-development",
@@ -241,8 +242,9 @@ func RunPluginEndToEndTest(t *testing.T, executor pluginCore.Plugin, template *i
 	tCtx.OnEventsRecorder().Return(eRecorder)
 	tCtx.OnResourceManager().Return(resourceManager)
 	tCtx.OnMaxDatasetSizeBytes().Return(1000000)
-	// TODO: return that
-	tCtx.OnSecretManager()
+	secretManager := &coreMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, mock.Anything).Return("fake-secret", nil)
+	tCtx.OnSecretManager().Return(secretManager)
 
 	trns := pluginCore.DoTransitionType(pluginCore.TransitionTypeBarrier, pluginCore.PhaseInfoQueued(time.Now(), 0, ""))
 	for !trns.Info().Phase().IsTerminal() {